@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bet/internal/client"
+	"bet/internal/domain"
+	"bet/internal/handler"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const maxPageLimit = 100
+
+var betsCommand = command{
+	name:    "bets",
+	summary: "List, show, void and audit bets",
+	subcommands: []command{
+		{
+			name:    "list",
+			summary: "List bets matching filters",
+			description: `Lists bets using the filters of GET /v1/bets, newest first by default.
+With --all every page is fetched and printed as one list.`,
+			setup:       setupBetsList,
+			completions: map[string][]string{"sort-by": {"amount", "created_at"}, "order": {"asc", "desc"}},
+		},
+		{
+			name:    "get",
+			summary: "Show a bet",
+			args:    "ID",
+			setup:   setupBetsGet,
+		},
+		{
+			name:        "void",
+			summary:     "Void a bet and refund its stake",
+			description: "Voids a pending or settled bet and refunds the stake. Requires an admin key.",
+			args:        "ID",
+			setup:       setupBetsVoid,
+			completions: map[string][]string{"reason": voidReasons()},
+		},
+		{
+			name:    "audit",
+			summary: "Show a bet's audit trail",
+			args:    "ID",
+			setup:   setupBetsAudit,
+		},
+	},
+}
+
+func setupBetsList(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	var params client.ListBetsParams
+	fs.Func("user-id", "only bets placed by this user", func(value string) error {
+		userID, err := strconv.ParseInt(value, 10, 64)
+		params.UserID = &userID
+		return err
+	})
+	fs.Func("min-amount", "minimum bet amount (inclusive)", func(value string) error {
+		amount, err := strconv.ParseFloat(value, 64)
+		params.MinAmount = &amount
+		return err
+	})
+	fs.Func("max-amount", "maximum bet amount (inclusive)", func(value string) error {
+		amount, err := strconv.ParseFloat(value, 64)
+		params.MaxAmount = &amount
+		return err
+	})
+	fs.StringVar(&params.SortBy, "sort-by", "created_at", "sort field: amount or created_at")
+	fs.StringVar(&params.Order, "order", "desc", "sort order: asc or desc")
+	fs.IntVar(&params.Page, "page", 1, "page to fetch")
+	fs.IntVar(&params.Limit, "limit", 10, fmt.Sprintf("bets per page (1-%d)", maxPageLimit))
+	all := fs.Bool("all", false, "fetch every page")
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		api := flags.api()
+		if !*all {
+			page, err := api.ListBets(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := flags.print(stdout, page.Bets, betTable(page.Bets)); err != nil {
+				return err
+			}
+			if flags.table() {
+				fmt.Fprintf(stderr, "page %d, %d of %d bets\n", page.Page, len(page.Bets), page.Total)
+			}
+			return nil
+		}
+
+		params.Page, params.Limit = 1, maxPageLimit
+		var bets []handler.BetDTO
+		for {
+			page, err := api.ListBets(ctx, params)
+			if err != nil {
+				return err
+			}
+			bets = append(bets, page.Bets...)
+			if len(page.Bets) == 0 || len(bets) >= page.Total {
+				break
+			}
+			params.Page++
+		}
+		if bets == nil {
+			bets = []handler.BetDTO{}
+		}
+
+		return flags.print(stdout, bets, betTable(bets))
+	}
+}
+
+func setupBetsGet(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one bet ID"); err != nil {
+			return err
+		}
+
+		bet, err := flags.api().GetBet(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, bet, betTable([]handler.BetDTO{bet}))
+	}
+}
+
+func setupBetsVoid(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+	reason := fs.String("reason", "", "void reason (required): duplicate, technical_error, suspected_fraud, operator_error or regulatory")
+	note := fs.String("note", "", "free-form note recorded in the audit trail")
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one bet ID"); err != nil {
+			return err
+		}
+		if *reason == "" {
+			return &usageError{message: "--reason is required"}
+		}
+
+		bet, err := flags.api().VoidBet(ctx, args[0], *reason, *note)
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, bet, betTable([]handler.BetDTO{bet}))
+	}
+}
+
+func setupBetsAudit(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one bet ID"); err != nil {
+			return err
+		}
+
+		audit, err := flags.api().GetBetAudit(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"AT", "ACTION", "ACTOR", "FROM", "TO", "REASON", "REFUND", "NOTE"}}
+		for _, entry := range audit.Entries {
+			t.rows = append(t.rows, []string{
+				entry.At, entry.Action, entry.Actor, entry.FromStatus, entry.ToStatus,
+				orDash(entry.Reason), money(entry.Refund), orDash(entry.Note),
+			})
+		}
+		return flags.print(stdout, audit.Entries, t)
+	}
+}
+
+func betTable(bets []handler.BetDTO) table {
+	t := table{header: []string{"ID", "USER", "AMOUNT", "TARGET", "STATUS", "PAYOUT", "ROUND", "CREATED", "SETTLED"}}
+	for _, bet := range bets {
+		status := bet.Status
+		if bet.VoidReason != "" {
+			status += " (" + bet.VoidReason + ")"
+		}
+		t.rows = append(t.rows, []string{
+			bet.ID,
+			strconv.FormatInt(bet.UserID, 10),
+			money(bet.Amount),
+			money(bet.CrashPoint),
+			status,
+			money(bet.Payout),
+			orDash(bet.RoundID),
+			bet.CreatedAt,
+			orDash(bet.SettledAt),
+		})
+	}
+	return t
+}
+
+func voidReasons() []string {
+	reasons := make([]string, len(domain.VoidReasons))
+	for i, reason := range domain.VoidReasons {
+		reasons[i] = string(reason)
+	}
+	return reasons
+}
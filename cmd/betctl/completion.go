@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var completionCommand = command{
+	name:    "completion",
+	summary: "Print a shell completion script",
+	description: `Prints a completion script for bash, zsh or fish. For example:
+
+  source <(betctl completion bash)
+  betctl completion fish > ~/.config/fish/completions/betctl.fish`,
+	args:   "bash|zsh|fish",
+	values: []string{"bash", "zsh", "fish"},
+	setup:  setupCompletion,
+}
+
+func setupCompletion(fs *flag.FlagSet) action {
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "a shell: bash, zsh or fish"); err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "bash":
+			writeBashCompletion(stdout)
+		case "zsh":
+			fmt.Fprint(stdout, "autoload -U +X bashcompinit && bashcompinit\n\n")
+			writeBashCompletion(stdout)
+		case "fish":
+			writeFishCompletion(stdout)
+		default:
+			return &usageError{message: fmt.Sprintf("unsupported shell %q", args[0])}
+		}
+		return nil
+	}
+}
+
+type completionFlag struct {
+	name   string
+	usage  string
+	isBool bool
+	values []string
+}
+
+func (f completionFlag) spelling() string {
+	if len(f.name) == 1 {
+		return "-" + f.name
+	}
+	return "--" + f.name
+}
+
+func commandFlags(cmd command) []completionFlag {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	cmd.setup(fs)
+
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{
+			name:   f.Name,
+			usage:  strings.SplitN(f.Usage, "\n", 2)[0],
+			isBool: ok && boolFlag.IsBoolFlag(),
+			values: flagValues(cmd, f.Name),
+		})
+	})
+	return flags
+}
+
+func flagValues(cmd command, name string) []string {
+	if name == "o" || name == "output" {
+		return outputFormats
+	}
+	return cmd.completions[name]
+}
+
+func writeBashCompletion(w io.Writer) {
+	var paths []string
+	var cases strings.Builder
+
+	var walk func(prefix string, cmds []command)
+	walk = func(prefix string, cmds []command) {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.name
+		}
+		fmt.Fprintf(&cases, "        %q)\n            COMPREPLY=($(compgen -W %q -- \"$cur\"))\n            ;;\n", prefix, strings.Join(names, " "))
+
+		for _, cmd := range cmds {
+			path := prefix + "/" + cmd.name
+			paths = append(paths, path)
+			if len(cmd.subcommands) > 0 {
+				walk(path, cmd.subcommands)
+				continue
+			}
+
+			flags := commandFlags(cmd)
+			var spellings []string
+			fmt.Fprintf(&cases, "        %q)\n            case \"$prev\" in\n", path)
+			for _, f := range flags {
+				spellings = append(spellings, f.spelling())
+				if len(f.values) > 0 {
+					fmt.Fprintf(&cases, "                -%s|--%s)\n                    COMPREPLY=($(compgen -W %q -- \"$cur\"))\n                    return\n                    ;;\n",
+						f.name, f.name, strings.Join(f.values, " "))
+				}
+			}
+			fmt.Fprintf(&cases, "            esac\n            if [[ \"$cur\" == -* ]]; then\n                COMPREPLY=($(compgen -W %q -- \"$cur\"))\n",
+				strings.Join(spellings, " "))
+			if len(cmd.values) > 0 {
+				fmt.Fprintf(&cases, "            else\n                COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(cmd.values, " "))
+			}
+			fmt.Fprint(&cases, "            fi\n            ;;\n")
+		}
+	}
+	walk("", commands)
+	sort.Strings(paths)
+
+	fmt.Fprint(w, `_betctl() {
+    local cur prev path word i
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    path=""
+
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        case "$path/$word" in
+`)
+	fmt.Fprintf(w, "            %s)\n                path=\"$path/$word\"\n                ;;\n", strings.Join(paths, "|"))
+	fmt.Fprint(w, `        esac
+    done
+
+    case "$path" in
+`)
+	fmt.Fprint(w, cases.String())
+	fmt.Fprint(w, `    esac
+}
+
+complete -o default -F _betctl betctl
+`)
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprint(w, "complete -c betctl -f\n")
+
+	var walk func(parents []string, cmds []command)
+	walk = func(parents []string, cmds []command) {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.name
+		}
+
+		condition := "__fish_use_subcommand"
+		if len(parents) > 0 {
+			condition = fishSeen(parents) + "; and not __fish_seen_subcommand_from " + strings.Join(names, " ")
+		}
+		for _, cmd := range cmds {
+			fmt.Fprintf(w, "complete -c betctl -n '%s' -a %s -d %s\n", condition, cmd.name, fishQuote(cmd.summary))
+		}
+
+		for _, cmd := range cmds {
+			path := append(append([]string{}, parents...), cmd.name)
+			if len(cmd.subcommands) > 0 {
+				walk(path, cmd.subcommands)
+				continue
+			}
+
+			seen := fishSeen(path)
+			for _, f := range commandFlags(cmd) {
+				option := "-l " + f.name
+				if len(f.name) == 1 {
+					option = "-s " + f.name
+				}
+				switch {
+				case len(f.values) > 0:
+					option += fmt.Sprintf(" -x -a %s", fishQuote(strings.Join(f.values, " ")))
+				case !f.isBool:
+					option += " -r"
+				}
+				fmt.Fprintf(w, "complete -c betctl -n '%s' %s -d %s\n", seen, option, fishQuote(f.usage))
+			}
+			switch {
+			case len(cmd.values) > 0:
+				fmt.Fprintf(w, "complete -c betctl -n '%s' -a %s\n", seen, fishQuote(strings.Join(cmd.values, " ")))
+			case cmd.args == "FILE":
+				fmt.Fprintf(w, "complete -c betctl -n '%s' -F\n", seen)
+			}
+		}
+	}
+	walk(nil, commands)
+}
+
+func fishSeen(path []string) string {
+	conditions := make([]string, len(path))
+	for i, name := range path {
+		conditions[i] = "__fish_seen_subcommand_from " + name
+	}
+	return strings.Join(conditions, "; and ")
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bet/internal/client"
+	"bet/internal/domain"
+	"bet/internal/handler"
+	"bet/internal/importer"
+	"bet/internal/validator"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxImportBatchSize = 2000
+
+var batchItemField = regexp.MustCompile(`^bets\[(\d+)\]\.?(.*)$`)
+
+var importCommand = command{
+	name:    "import",
+	summary: "Load historical bets from a CSV or NDJSON file",
+	description: `Loads settled bets exported from another platform. FILE is CSV with a header
+row or NDJSON, using the columns of GET /v1/bets/export. Original IDs and
+created_at are preserved. Progress is checkpointed after every batch, so an
+interrupted import resumes where it stopped when run again.`,
+	args:        "FILE",
+	setup:       setupImport,
+	completions: map[string][]string{"format": {string(importer.FormatCSV), string(importer.FormatNDJSON)}},
+}
+
+func setupImport(fs *flag.FlagSet) action {
+	var conn connection
+	conn.register(fs)
+	format := fs.String("format", "", "input format: csv or ndjson (default: from the file extension)")
+	batchSize := fs.Int("batch-size", 500, fmt.Sprintf("bets per import request (1-%d, at most the server's BET_IMPORT_BATCH_MAX_SIZE)", maxImportBatchSize))
+	rejectsPath := fs.String("rejects", "", "NDJSON file for rejected rows (default: FILE.rejects.ndjson)")
+	checkpointPath := fs.String("checkpoint", "", "checkpoint file (default: FILE.checkpoint.json)")
+	restart := fs.Bool("restart", false, "ignore an existing checkpoint and start from the beginning")
+	dryRun := fs.Bool("dry-run", false, "validate the file and write rejects without importing")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout per import request")
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one FILE argument"); err != nil {
+			return err
+		}
+		if *batchSize < 1 || *batchSize > maxImportBatchSize {
+			return &usageError{message: fmt.Sprintf("--batch-size must be between 1 and %d", maxImportBatchSize)}
+		}
+
+		path := args[0]
+		inputFormat := importer.Format(*format)
+		if inputFormat == "" {
+			detected, err := importer.DetectFormat(path)
+			if err != nil {
+				return &usageError{message: err.Error()}
+			}
+			inputFormat = detected
+		}
+		if inputFormat != importer.FormatCSV && inputFormat != importer.FormatNDJSON {
+			return &usageError{message: "--format must be csv or ndjson"}
+		}
+
+		return runImport(ctx, importOptions{
+			path:           path,
+			format:         inputFormat,
+			batchSize:      *batchSize,
+			rejectsPath:    *rejectsPath,
+			checkpointPath: *checkpointPath,
+			restart:        *restart,
+			dryRun:         *dryRun,
+		}, conn.client(*timeout), stdout, stderr)
+	}
+}
+
+type importOptions struct {
+	path           string
+	format         importer.Format
+	batchSize      int
+	rejectsPath    string
+	checkpointPath string
+	restart        bool
+	dryRun         bool
+}
+
+func runImport(ctx context.Context, opts importOptions, api *client.Client, stdout, stderr io.Writer) error {
+
+	im := importer.New(importer.Config{
+		Path:           opts.path,
+		Format:         opts.format,
+		BatchSize:      opts.batchSize,
+		RejectsPath:    opts.rejectsPath,
+		CheckpointPath: opts.checkpointPath,
+		Restart:        opts.restart,
+		DryRun:         opts.dryRun,
+		Progress:       stderr,
+	}, validator.NewBetValidator(handler.OpenAPISpec(handler.SpecOptions{})), &apiSink{client: api})
+
+	started := time.Now()
+	stats, err := im.Run(ctx)
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("interrupted at line %d; run the same command again to resume", stats.Line)
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "imported"
+	if opts.dryRun {
+		verb = "valid"
+	}
+	switch {
+	case stats.UpToDate:
+		fmt.Fprintf(stdout, "%s: already imported (%d %s, %d duplicates, %d rejected); use --restart to import again\n",
+			opts.path, stats.Imported, verb, stats.Duplicates, stats.Rejected)
+	default:
+		fmt.Fprintf(stdout, "%s: %d %s, %d duplicates, %d rejected in %s\n",
+			opts.path, stats.Imported, verb, stats.Duplicates, stats.Rejected, time.Since(started).Round(time.Millisecond))
+	}
+	if stats.Rejected > 0 {
+		fmt.Fprintf(stdout, "rejected rows written to %s\n", rejectsFile(opts.path, opts.rejectsPath))
+	}
+
+	return nil
+}
+
+func rejectsFile(path, configured string) string {
+	if configured != "" {
+		return configured
+	}
+	return path + ".rejects.ndjson"
+}
+
+type apiSink struct {
+	client *client.Client
+}
+
+func (s *apiSink) ImportBets(ctx context.Context, bets []domain.Bet) (domain.ImportResult, error) {
+	dtos := make([]handler.BetDTO, len(bets))
+	for i, bet := range bets {
+		dtos[i] = importDTO(bet)
+	}
+
+	result, err := s.client.ImportBets(ctx, dtos)
+	if err != nil {
+		if items := rejectedItems(err); items != nil {
+			return domain.ImportResult{}, items
+		}
+		return domain.ImportResult{}, err
+	}
+
+	return domain.ImportResult{Imported: result.Imported, Duplicates: result.Duplicates}, nil
+}
+
+func importDTO(bet domain.Bet) handler.BetDTO {
+	dto := handler.BetDTOFromDomain(&bet)
+	dto.CreatedAt = bet.CreatedAt.Format(time.RFC3339Nano)
+	if bet.SettledAt != nil {
+		dto.SettledAt = bet.SettledAt.Format(time.RFC3339Nano)
+	}
+	return dto
+}
+
+func rejectedItems(err error) *importer.RejectedItemsError {
+	apiErr, ok := client.IsAPIError(err)
+	if !ok || apiErr.Code != "VALIDATION_ERROR" || len(apiErr.Fields) == 0 {
+		return nil
+	}
+
+	items := make(map[int][]importer.FieldError)
+	for _, field := range apiErr.Fields {
+		match := batchItemField.FindStringSubmatch(field.Field)
+		if match == nil {
+			return nil
+		}
+		index, _ := strconv.Atoi(match[1])
+		name := match[2]
+		if name == "" {
+			name = "row"
+		}
+		items[index] = append(items[index], importer.FieldError{
+			Field:   name,
+			Message: strings.TrimPrefix(field.Message, "bets["+match[1]+"]."),
+		})
+	}
+	return &importer.RejectedItemsError{Items: items}
+}
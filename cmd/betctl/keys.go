@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bet/internal/auth"
+	"bet/internal/handler"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var keysCommand = command{
+	name:    "keys",
+	summary: "Manage API keys",
+	subcommands: []command{
+		{
+			name:    "list",
+			summary: "List API keys",
+			setup:   setupKeysList,
+		},
+		{
+			name:    "create",
+			summary: "Create an API key",
+			description: `Creates an API key with the given scopes. The secret is printed once
+and cannot be retrieved again.`,
+			setup:       setupKeysCreate,
+			completions: map[string][]string{"scope": knownScopes()},
+		},
+		{
+			name:        "revoke",
+			summary:     "Revoke an API key",
+			description: "Revokes an API key created through the API. Keys from AUTH_API_KEYS cannot be revoked.",
+			args:        "ID",
+			setup:       setupKeysRevoke,
+		},
+	},
+}
+
+func setupKeysList(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		resp, err := flags.api().ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, resp.Keys, keyTable(resp.Keys))
+	}
+}
+
+func setupKeysCreate(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+	label := fs.String("label", "", "human-readable label")
+	userID := fs.Int64("user", 0, "bind the key to this user ID")
+	operator := fs.Bool("operator", false, "let the key act for every user")
+	operatorName := fs.String("operator-name", "", "name of the operator the key places bets for; implies --operator")
+	var scopes []string
+	fs.Func("scope", "scope to grant, repeatable or comma-separated: "+strings.Join(knownScopes(), ", "), func(value string) error {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+		return nil
+	})
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+		if len(scopes) == 0 {
+			return &usageError{message: "at least one --scope is required"}
+		}
+
+		resp, err := flags.api().CreateAPIKey(ctx, handler.CreateAPIKeyRequest{
+			Label:        *label,
+			Scopes:       scopes,
+			UserID:       *userID,
+			Operator:     *operator || *operatorName != "",
+			OperatorName: *operatorName,
+		})
+		if err != nil {
+			return err
+		}
+
+		t := table{
+			header: []string{"ID", "SCOPES", "ACTS FOR", "SECRET"},
+			rows:   [][]string{{resp.Key.ID, strings.Join(resp.Key.Scopes, ","), actsFor(resp.Key), resp.Secret}},
+		}
+		if err := flags.print(stdout, resp, t); err != nil {
+			return err
+		}
+		fmt.Fprintln(stderr, "store the secret now, it is not shown again")
+		return nil
+	}
+}
+
+func setupKeysRevoke(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one key ID"); err != nil {
+			return err
+		}
+
+		key, err := flags.api().RevokeAPIKey(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, key, keyTable([]handler.APIKeyDTO{key}))
+	}
+}
+
+func keyTable(keys []handler.APIKeyDTO) table {
+	t := table{header: []string{"ID", "LABEL", "SCOPES", "ACTS FOR", "SOURCE", "CREATED"}}
+	for _, key := range keys {
+		t.rows = append(t.rows, []string{key.ID, orDash(key.Label), strings.Join(key.Scopes, ","), actsFor(key), key.Source, key.CreatedAt})
+	}
+	return t
+}
+
+func actsFor(key handler.APIKeyDTO) string {
+	switch {
+	case key.OperatorName != "":
+		return "operator:" + key.OperatorName
+	case key.Operator:
+		return "operator"
+	case key.UserID != 0:
+		return "user:" + strconv.FormatInt(key.UserID, 10)
+	default:
+		return "-"
+	}
+}
+
+func knownScopes() []string {
+	scopes := make([]string, len(auth.KnownScopes))
+	for i, scope := range auth.KnownScopes {
+		scopes[i] = string(scope)
+	}
+	return scopes
+}
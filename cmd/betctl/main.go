@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bet/internal/client"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+type action func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+
+type command struct {
+	name        string
+	summary     string
+	description string
+	args        string
+	subcommands []command
+	setup       func(fs *flag.FlagSet) action
+	completions map[string][]string
+	values      []string
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		betsCommand,
+		roundsCommand,
+		keysCommand,
+		snapshotsCommand,
+		importCommand,
+		completionCommand,
+	}
+}
+
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	return dispatch(ctx, "betctl", commands, args, stdout, stderr)
+}
+
+func dispatch(ctx context.Context, path string, cmds []command, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printGroupUsage(stderr, path, cmds)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd, ok := findCommand(cmds, args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "%s: unknown command %q\n\n", path, args[0])
+		printGroupUsage(stderr, path, cmds)
+		return 2
+	}
+
+	path += " " + cmd.name
+	if len(cmd.subcommands) > 0 {
+		return dispatch(ctx, path, cmd.subcommands, args[1:], stdout, stderr)
+	}
+
+	fs := newFlagSet(path, cmd, stderr)
+	act := cmd.setup(fs)
+
+	positional, err := parseFlags(fs, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	err = act(ctx, positional, stdout, stderr)
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "%s: %v\nRun \"%s -h\" for usage.\n", path, err, path)
+		return 2
+	default:
+		fmt.Fprintf(stderr, "%s: %v\n", path, err)
+		return 1
+	}
+}
+
+func findCommand(cmds []command, name string) (command, bool) {
+	for _, cmd := range cmds {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func newFlagSet(path string, cmd command, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		synopsis := path + " [flags]"
+		if cmd.args != "" {
+			synopsis += " " + cmd.args
+		}
+		description := cmd.description
+		if description == "" {
+			description = cmd.summary + "."
+		}
+		fmt.Fprintf(stderr, "Usage: %s\n\n%s\n\nFlags:\n", synopsis, strings.TrimSpace(description))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func printGroupUsage(w io.Writer, path string, cmds []command) {
+	if path == "betctl" {
+		fmt.Fprint(w, "betctl is the operator CLI for the bet service.\n\n")
+	}
+	fmt.Fprintf(w, "Usage:\n  %s <command> [flags]\n\nCommands:\n", path)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 4, ' ', 0)
+	for _, cmd := range cmds {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nRun \"%s <command> -h\" for help on a command.\n", path)
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func expectArgs(args []string, n int, what string) error {
+	if len(args) != n {
+		return &usageError{message: fmt.Sprintf("expected %s", what)}
+	}
+	return nil
+}
+
+type connection struct {
+	server string
+	apiKey string
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.server, "server", getEnv("BETCTL_SERVER", "http://localhost:8080"), "bet API base URL (env BETCTL_SERVER)")
+	fs.StringVar(&c.apiKey, "api-key", "", "API key sent as a bearer token (env BETCTL_API_KEY)")
+}
+
+func (c *connection) client(timeout time.Duration) *client.Client {
+	apiKey := c.apiKey
+	if apiKey == "" {
+		apiKey = os.Getenv("BETCTL_API_KEY")
+	}
+	return client.New(client.Config{
+		BaseURL:    c.server,
+		APIKey:     apiKey,
+		Timeout:    timeout,
+		MaxRetries: 3,
+	})
+}
+
+type apiFlags struct {
+	connection
+	output
+	timeout time.Duration
+}
+
+func (f *apiFlags) register(fs *flag.FlagSet) {
+	f.connection.register(fs)
+	f.output.register(fs)
+	fs.DurationVar(&f.timeout, "timeout", 30*time.Second, "request timeout")
+}
+
+func (f *apiFlags) api() *client.Client {
+	return f.connection.client(f.timeout)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
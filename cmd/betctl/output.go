@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+type output struct {
+	format string
+}
+
+func (o *output) register(fs *flag.FlagSet) {
+	o.format = outputTable
+	fs.Func("o", "output format: table, json or yaml (default table)", o.set)
+	fs.Func("output", "same as -o", o.set)
+}
+
+func (o *output) set(value string) error {
+	for _, format := range outputFormats {
+		if value == format {
+			o.format = value
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(outputFormats, ", "))
+}
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (o *output) print(w io.Writer, value interface{}, t table) error {
+	switch o.format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputYAML:
+		return writeYAML(w, value)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (o *output) table() bool {
+	return o.format == outputTable
+}
+
+func writeYAML(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func money(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
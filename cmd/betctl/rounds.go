@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bet/internal/handler"
+	"context"
+	"flag"
+	"io"
+	"strconv"
+)
+
+var roundsCommand = command{
+	name:    "rounds",
+	summary: "Inspect game rounds",
+	subcommands: []command{
+		{
+			name:        "list",
+			summary:     "List the upcoming, live and recent rounds",
+			description: "Lists the next round, the live round and recently crashed rounds, newest first.",
+			setup:       setupRoundsList,
+		},
+		{
+			name:    "get",
+			summary: "Show a round",
+			args:    "ID",
+			setup:   setupRoundsGet,
+		},
+	},
+}
+
+func setupRoundsList(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+	limit := fs.Int("limit", 20, "maximum number of rounds (1-100)")
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		resp, err := flags.api().ListRounds(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, resp.Rounds, roundTable(resp.Rounds))
+	}
+}
+
+func setupRoundsGet(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 1, "exactly one round ID"); err != nil {
+			return err
+		}
+
+		round, err := flags.api().GetRound(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, round, roundTable([]handler.RoundDTO{round}))
+	}
+}
+
+func roundTable(rounds []handler.RoundDTO) table {
+	t := table{header: []string{"ID", "STATUS", "MULTIPLIER", "CRASH", "BETS", "WAGERED", "PAID OUT", "OPENED"}}
+	for _, round := range rounds {
+		crash := "-"
+		if round.CrashPoint > 0 {
+			crash = money(round.CrashPoint)
+		}
+		t.rows = append(t.rows, []string{
+			round.ID,
+			round.Status,
+			money(round.Multiplier),
+			crash,
+			strconv.Itoa(round.Bets),
+			money(round.Wagered),
+			money(round.PaidOut),
+			round.OpenedAt,
+		})
+	}
+	return t
+}
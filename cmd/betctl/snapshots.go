@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bet/internal/handler"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var snapshotsCommand = command{
+	name:    "snapshots",
+	summary: "List, take and compact bet snapshots",
+	subcommands: []command{
+		{
+			name:    "list",
+			summary: "List snapshots, newest first",
+			setup:   setupSnapshotsList,
+		},
+		{
+			name:    "create",
+			summary: "Take a snapshot now",
+			setup:   setupSnapshotsCreate,
+		},
+		{
+			name:        "compact",
+			summary:     "Remove snapshots beyond the retention limit",
+			description: "Removes snapshots beyond SNAPSHOT_RETAIN along with leftover temporary files.",
+			setup:       setupSnapshotsCompact,
+		},
+	},
+}
+
+func setupSnapshotsList(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		resp, err := flags.api().ListSnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, resp.Snapshots, snapshotTable(resp.Snapshots))
+	}
+}
+
+func setupSnapshotsCreate(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		snap, err := flags.api().CreateSnapshot(ctx)
+		if err != nil {
+			return err
+		}
+		return flags.print(stdout, snap, snapshotTable([]handler.SnapshotDTO{snap}))
+	}
+}
+
+func setupSnapshotsCompact(fs *flag.FlagSet) action {
+	var flags apiFlags
+	flags.register(fs)
+
+	return func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+		if err := expectArgs(args, 0, "no arguments"); err != nil {
+			return err
+		}
+
+		resp, err := flags.api().CompactSnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		if err := flags.print(stdout, resp, snapshotTable(resp.Removed)); err != nil {
+			return err
+		}
+		if flags.table() {
+			fmt.Fprintf(stderr, "removed %d, kept %d\n", len(resp.Removed), resp.Kept)
+		}
+		return nil
+	}
+}
+
+func snapshotTable(snapshots []handler.SnapshotDTO) table {
+	t := table{header: []string{"ID", "BETS", "LIMITS", "SIZE", "CREATED", "CHECKSUM"}}
+	for _, snap := range snapshots {
+		checksum := snap.Checksum
+		if len(checksum) > 12 {
+			checksum = checksum[:12]
+		}
+		t.rows = append(t.rows, []string{snap.ID, strconv.Itoa(snap.Bets), strconv.Itoa(snap.Limits), strconv.FormatInt(snap.Bytes, 10), snap.CreatedAt, checksum})
+	}
+	return t
+}
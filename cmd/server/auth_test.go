@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bet/configs"
+	"bet/internal/auth"
+	"bet/internal/handler"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+var pathParameter = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestProtectedRoutesFailClosedWithoutKeys(t *testing.T) {
+	cfg := &configs.Config{}
+	spec := handler.OpenAPISpec(handler.SpecOptions{})
+	keys := auth.NewKeyStore(nil)
+
+	mux, patterns := setupRoutes(cfg, spec, &httpHandlers{authenticator: keys}, zap.NewNop())
+
+	protected := 0
+	for _, pattern := range patterns {
+		method, path, _ := strings.Cut(pattern, " ")
+		op := spec.Operation(method, path)
+		if op == nil || !op.RequiresAuth() {
+			continue
+		}
+		protected++
+
+		req := httptest.NewRequest(method, pathParameter.ReplaceAllString(path, "1"), strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: got status %d without configured keys, want %d", pattern, rec.Code, http.StatusServiceUnavailable)
+		}
+	}
+	if protected == 0 {
+		t.Fatal("no protected routes registered")
+	}
+
+	if _, _, err := keys.Create("bootstrap", auth.Grant{Scopes: []auth.Scope{auth.ScopeAdmin}}); err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	if keys.Enabled() {
+		t.Error("creating a runtime key enabled authentication without configured keys")
+	}
+}
@@ -2,16 +2,33 @@ package main
 
 import (
 	"bet/configs"
+	"bet/internal/auth"
+	"bet/internal/broker"
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/feed"
+	"bet/internal/fraud"
+	"bet/internal/game"
+	"bet/internal/grpcserver"
 	"bet/internal/handler"
+	"bet/internal/leaderboard"
 	"bet/internal/middleware"
+	"bet/internal/openapi"
 	"bet/internal/repository"
+	"bet/internal/risk"
 	"bet/internal/service"
+	"bet/internal/snapshot"
+	"bet/internal/sse"
+	"bet/internal/tracing"
 	"bet/internal/validator"
+	"bet/internal/webhook"
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -24,19 +41,153 @@ func main() {
 
 	cfg := loadConfig(logger)
 
+	tracerProvider := initTracing(cfg, logger)
+
+	spec := handler.OpenAPISpec(handler.SpecOptions{
+		DocsEnabled:   cfg.OpenAPI.DocsEnabled,
+		MaxBatchBets:  cfg.Batch.MaxBets,
+		MaxImportBets: cfg.Batch.MaxImport,
+	})
+	betValidator := validator.NewBetValidator(spec)
 	betRepo := repository.NewInMemoryBetRepository()
-	betService := service.NewBetService(betRepo)
-	betHandler := handler.NewBetHandler(betService, validator.NewBetValidator(), logger)
-	healthHandler := handler.NewHealthHandler(logger, betRepo)
+	limitRepo := repository.NewInMemoryLimitRepository()
+	snapshots := initSnapshots(cfg, betRepo, limitRepo, logger)
+
+	feedHub := feed.NewHub(feed.Config{
+		SendBuffer:   cfg.Feed.SendBuffer,
+		PingInterval: time.Duration(cfg.Feed.PingInterval) * time.Second,
+		PongWait:     time.Duration(cfg.Feed.PongWait) * time.Second,
+		CheckOrigin:  feedOriginChecker(cfg),
+		Logger:       logger,
+	})
+	betStream := sse.NewBroker(sse.Config{
+		ReplaySize:       cfg.Stream.ReplaySize,
+		SubscriberBuffer: cfg.Stream.SubscriberBuffer,
+		Logger:           logger,
+	})
+	riskManager := risk.NewManager(risk.Config{
+		Mode:                domain.RiskMode(cfg.Risk.Mode),
+		RoundLiability:      cfg.Risk.RoundLiability,
+		UserLiability:       cfg.Risk.UserLiability,
+		ThrottleRatio:       cfg.Risk.ThrottleRatio,
+		ThrottledCrashPoint: cfg.Risk.ThrottledCrashPoint,
+		Logger:              logger,
+	})
+	gameEngine := game.NewEngine(game.Config{
+		BettingPhase:  time.Duration(cfg.Game.BettingPhase) * time.Second,
+		TickInterval:  time.Duration(cfg.Game.TickIntervalMS) * time.Millisecond,
+		Cooldown:      time.Duration(cfg.Game.Cooldown) * time.Second,
+		HouseEdge:     cfg.Game.HouseEdge,
+		MaxCrashPoint: cfg.Game.MaxCrashPoint,
+		Risk:          riskManager,
+		Logger:        logger,
+	}, betRepo, game.Fanout(feedHub, betStream))
+
+	eventBus := events.NewBus(events.Config{
+		PollInterval:     time.Duration(cfg.Events.PollIntervalMS) * time.Millisecond,
+		BatchSize:        cfg.Events.BatchSize,
+		MaxAttempts:      cfg.Events.MaxAttempts,
+		RetryBackoff:     time.Duration(cfg.Events.RetryBackoffMS) * time.Millisecond,
+		SubscriberBuffer: cfg.Events.SubscriberBuffer,
+		Logger:           logger,
+	}, betRepo)
+
+	webhookRepo := repository.NewInMemoryWebhookRepository()
+	webhookGuard := webhook.Guard{AllowPrivate: cfg.Webhook.AllowPrivate}
+	webhookDispatcher := webhook.NewDispatcher(webhook.Config{
+		Workers:      cfg.Webhook.Workers,
+		QueueSize:    cfg.Webhook.QueueSize,
+		MaxAttempts:  cfg.Webhook.MaxAttempts,
+		BaseBackoff:  time.Duration(cfg.Webhook.BackoffMS) * time.Millisecond,
+		MaxBackoff:   time.Duration(cfg.Webhook.MaxBackoff) * time.Second,
+		Timeout:      time.Duration(cfg.Webhook.Timeout) * time.Second,
+		DisableAfter: cfg.Webhook.DisableAfter,
+		Guard:        webhookGuard,
+		Logger:       logger,
+	}, webhookRepo)
+	if err := eventBus.Subscribe("webhooks", webhookDispatcher.HandleEvent, events.TypeBetPlaced, events.TypeBetSettled, events.TypeBetCancelled, events.TypeBetVoided); err != nil {
+		logger.Fatal("failed to subscribe webhook dispatcher", zap.Error(err))
+	}
+
+	brokerRelay := initBroker(cfg, eventBus, logger)
+	leaderboards := initLeaderboards(cfg, eventBus, betRepo, logger)
+
+	authenticator := newAuthenticator(cfg)
+	if !authenticator.Enabled() {
+		logger.Warn("AUTH_API_KEYS is empty, bets are placed anonymously and other protected routes and gRPC methods will respond 503 AUTH_NOT_CONFIGURED")
+	}
+
+	limitsService := service.NewLimitsService(limitRepo, betRepo, time.Duration(cfg.Limits.IncreaseDelay)*time.Hour)
+	fraudEngine := initFraud(cfg, logger)
+	fraudService := service.NewFraudService(repository.NewInMemoryFraudFlagRepository(), fraudEngine)
+	betService := service.NewBetService(betRepo, gameEngine, limitsService, fraudService, cfg.Bets.DefaultCurrency)
+	webhookService := service.NewWebhookService(webhookRepo, webhookGuard)
+	adminValidator := validator.NewAdminValidator(spec)
+
+	handlers := &httpHandlers{
+		bet:           handler.NewBetHandler(betService, betValidator, logger),
+		admin:         handler.NewAdminHandler(betService, betValidator, logger),
+		export:        handler.NewExportHandler(betService, betValidator, cfg.Export.RowGroupSize, logger),
+		round:         handler.NewRoundHandler(service.NewRoundService(gameEngine), adminValidator, logger),
+		risk:          handler.NewRiskHandler(service.NewRiskService(riskManager, gameEngine), logger),
+		limits:        handler.NewLimitsHandler(limitsService, validator.NewLimitsValidator(spec), logger),
+		fraud:         handler.NewFraudHandler(fraudService, validator.NewFraudValidator(spec), logger),
+		leaderboard:   handler.NewLeaderboardHandler(service.NewLeaderboardService(leaderboards), validator.NewLeaderboardValidator(spec), logger),
+		report:        handler.NewReportHandler(service.NewReportService(betRepo, cfg.Game.HouseEdge), adminValidator, logger),
+		key:           handler.NewKeyHandler(authenticator, adminValidator, logger),
+		snapshot:      handler.NewSnapshotHandler(snapshots, logger),
+		health:        handler.NewHealthHandler(logger, betRepo),
+		feed:          handler.NewFeedHandler(feedHub, betValidator, logger),
+		stream:        handler.NewStreamHandler(betStream, time.Duration(cfg.Stream.KeepAlive)*time.Second, logger),
+		webhook:       handler.NewWebhookHandler(webhookService, validator.NewWebhookValidator(spec), logger),
+		openapi:       newOpenAPIHandler(spec, logger),
+		authenticator: authenticator,
+	}
 	rateLimiter := middleware.NewRateLimiter(middleware.RateLimitConfig{
 		RequestsPerMinute: cfg.RateLimit.RequestsPerMinute,
 		Logger:            logger,
 	})
 
-	srv := setupServer(cfg, betHandler, healthHandler, rateLimiter, logger)
+	srv := setupServer(cfg, spec, handlers, rateLimiter, logger)
+	grpcServer := setupGRPCServer(cfg, betService, betValidator, betStream, authenticator, rateLimiter, logger)
 
+	webhookDispatcher.Start()
+	if brokerRelay != nil {
+		brokerRelay.Start()
+	}
+	eventBus.Start()
+	gameEngine.Start()
+	fraudEngine.Start()
+	if snapshots != nil {
+		snapshots.Start()
+	}
+	if grpcServer != nil {
+		if err := grpcServer.Start(); err != nil {
+			logger.Fatal("failed to start gRPC server", zap.Error(err))
+		}
+	}
 	startServer(srv, cfg, logger)
-	shutdownServer(srv, rateLimiter, logger)
+	shutdownServer(srv, grpcServer, rateLimiter, gameEngine, feedHub, betStream, eventBus, webhookDispatcher, brokerRelay, snapshots, fraudEngine, tracerProvider, logger)
+}
+
+type httpHandlers struct {
+	bet           *handler.BetHandler
+	admin         *handler.AdminHandler
+	export        *handler.ExportHandler
+	round         *handler.RoundHandler
+	risk          *handler.RiskHandler
+	limits        *handler.LimitsHandler
+	fraud         *handler.FraudHandler
+	leaderboard   *handler.LeaderboardHandler
+	report        *handler.ReportHandler
+	key           *handler.KeyHandler
+	snapshot      *handler.SnapshotHandler
+	health        *handler.HealthHandler
+	feed          *handler.FeedHandler
+	stream        *handler.StreamHandler
+	webhook       *handler.WebhookHandler
+	openapi       *handler.OpenAPIHandler
+	authenticator auth.Authenticator
 }
 
 func initLogger() *zap.Logger {
@@ -61,25 +212,158 @@ func loadConfig(logger *zap.Logger) *configs.Config {
 		zap.Int("write_timeout", cfg.Server.WriteTimeout),
 		zap.Int("idle_timeout", cfg.Server.IdleTimeout),
 		zap.Int("rate_limit", cfg.RateLimit.RequestsPerMinute),
+		zap.String("tracing_exporter", cfg.Tracing.Exporter),
+		zap.Int("request_timeout", cfg.Timeout.Default),
 	)
 
 	return cfg
 }
 
-func setupServer(cfg *configs.Config, betHandler *handler.BetHandler, healthHandler *handler.HealthHandler, rateLimiter *middleware.RateLimiter, logger *zap.Logger) *http.Server {
-	mux := http.NewServeMux()
+func initTracing(cfg *configs.Config, logger *zap.Logger) *tracing.Provider {
+	provider, err := tracing.NewProvider(context.Background(), tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		FilePath:     cfg.Tracing.FilePath,
+		ServiceName:  cfg.Tracing.ServiceName,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	return provider
+}
+
+func initBroker(cfg *configs.Config, eventBus *events.Bus, logger *zap.Logger) *broker.Relay {
+	if cfg.Broker.Driver == "none" {
+		return nil
+	}
+
+	publisher, err := broker.NewNATSPublisher(broker.NATSConfig{
+		URL:           cfg.Broker.URL,
+		Stream:        cfg.Broker.Stream,
+		SubjectPrefix: cfg.Broker.Subject,
+		Logger:        logger,
+	})
+	if err != nil {
+		logger.Fatal("failed to initialize broker publisher", zap.Error(err))
+	}
+
+	buffer, err := broker.NewDiskBuffer(cfg.Broker.BufferDir, cfg.Broker.BufferMax)
+	if err != nil {
+		logger.Fatal("failed to initialize broker buffer", zap.Error(err))
+	}
+
+	relay := broker.NewRelay(broker.RelayConfig{
+		SubjectPrefix:  cfg.Broker.Subject,
+		Partitions:     cfg.Broker.Partitions,
+		PublishTimeout: time.Duration(cfg.Broker.PublishTimeout) * time.Second,
+		RetryInterval:  time.Duration(cfg.Broker.RetryInterval) * time.Second,
+		Logger:         logger,
+	}, publisher, buffer)
+
+	if err := eventBus.Subscribe("broker", relay.HandleEvent, broker.SupportedEvents...); err != nil {
+		logger.Fatal("failed to subscribe broker relay", zap.Error(err))
+	}
+
+	return relay
+}
+
+func initLeaderboards(cfg *configs.Config, eventBus *events.Bus, betRepo repository.BetRepository, logger *zap.Logger) *leaderboard.Manager {
+	manager := leaderboard.NewManager(leaderboard.Config{
+		UserMask:   cfg.Leaderboard.UserMask,
+		MaskSecret: cfg.Leaderboard.MaskSecret,
+	})
+
+	seeded, err := manager.Seed(betRepo.Stream(context.Background(), domain.ExportBetsRequest{}))
+	if err != nil {
+		logger.Fatal("failed to seed leaderboards", zap.Error(err))
+	}
+	logger.Info("leaderboards seeded", zap.Int("bets", seeded))
+
+	if err := eventBus.Subscribe("leaderboards", manager.HandleEvent, events.TypeBetSettled, events.TypeBetVoided); err != nil {
+		logger.Fatal("failed to subscribe leaderboards", zap.Error(err))
+	}
+	return manager
+}
+
+func initSnapshots(cfg *configs.Config, betRepo repository.BetRepository, limitRepo repository.LimitRepository, logger *zap.Logger) *snapshot.Manager {
+	if cfg.Snapshot.Dir == "" {
+		return nil
+	}
+
+	manager, err := snapshot.NewManager(snapshot.Config{
+		Dir:      cfg.Snapshot.Dir,
+		Retain:   cfg.Snapshot.Retain,
+		Interval: time.Duration(cfg.Snapshot.Interval) * time.Second,
+		Logger:   logger,
+	}, betRepo, limitRepo)
+	if err != nil {
+		logger.Fatal("failed to initialize snapshots", zap.Error(err))
+	}
+
+	if !cfg.Snapshot.Restore {
+		return manager
+	}
 
-	mux.HandleFunc("GET /health", healthHandler.Health)
-	mux.HandleFunc("GET /ready", healthHandler.Ready)
-	mux.HandleFunc("GET /live", healthHandler.Live)
+	restored, ok, err := manager.Restore(context.Background())
+	if err != nil {
+		logger.Fatal("failed to restore snapshot", zap.Error(err))
+	}
+	if ok {
+		logger.Info("snapshot restored", zap.String("snapshot_id", restored.ID), zap.Int("bets", restored.Bets), zap.Int("limits", restored.Limits))
+	}
 
-	mux.HandleFunc("POST /bets", betHandler.CreateBet)
-	mux.HandleFunc("GET /bets", betHandler.ListBets)
-	mux.HandleFunc("GET /bets/{id}", betHandler.GetBet)
+	return manager
+}
 
-	httpHandler := middleware.RequestIDMiddleware(mux)
+func initFraud(cfg *configs.Config, logger *zap.Logger) *fraud.Engine {
+	engine, err := fraud.NewEngine(fraud.Config{
+		RulesFile:      cfg.Fraud.RulesFile,
+		ReloadInterval: time.Duration(cfg.Fraud.ReloadInterval) * time.Second,
+		Logger:         logger,
+	})
+	if err != nil {
+		logger.Fatal("failed to load fraud rules", zap.Error(err))
+	}
+	if !engine.Enabled() {
+		logger.Info("FRAUD_RULES_FILE is empty, fraud rules are disabled")
+	}
+	return engine
+}
+
+func feedOriginChecker(cfg *configs.Config) func(r *http.Request) bool {
+	if len(cfg.CORS.AllowedOrigins) == 0 {
+		return nil
+	}
+	return middleware.OriginChecker(cfg.CORS.AllowedOrigins)
+}
+
+func setupServer(cfg *configs.Config, spec *openapi.Document, handlers *httpHandlers, rateLimiter *middleware.RateLimiter, logger *zap.Logger) *http.Server {
+	mux, _ := setupRoutes(cfg, spec, handlers, logger)
+
+	httpHandler := handler.RecoveryMiddleware(logger)(mux)
 	httpHandler = middleware.RateLimitMiddleware(rateLimiter)(httpHandler)
+	if len(cfg.CORS.AllowedOrigins) > 0 {
+		httpHandler = middleware.CORSMiddleware(middleware.CORSConfig{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		})(httpHandler)
+	}
+	if cfg.Compression.Enabled {
+		httpHandler = middleware.CompressionMiddleware(middleware.CompressionConfig{
+			MinSize: cfg.Compression.MinSize,
+		})(httpHandler)
+	}
+	httpHandler = middleware.ClientIPMiddleware(middleware.NewClientIPResolver(cfg.Server.TrustedProxies))(httpHandler)
 	httpHandler = middleware.LoggingMiddleware(logger)(httpHandler)
+	httpHandler = middleware.TracingMiddleware(httpHandler)
+	httpHandler = middleware.RequestIDMiddleware(httpHandler)
 
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
@@ -90,6 +374,139 @@ func setupServer(cfg *configs.Config, betHandler *handler.BetHandler, healthHand
 	}
 }
 
+func newOpenAPIHandler(spec *openapi.Document, logger *zap.Logger) *handler.OpenAPIHandler {
+	h, err := handler.NewOpenAPIHandler(spec, logger)
+	if err != nil {
+		logger.Fatal("failed to build openapi spec", zap.Error(err))
+	}
+	return h
+}
+
+func setupGRPCServer(cfg *configs.Config, betService service.BetServiceUseCase, betValidator validator.BetValidator, betStream *sse.Broker, authenticator auth.Authenticator, rateLimiter *middleware.RateLimiter, logger *zap.Logger) *grpcserver.Server {
+	if !cfg.GRPC.Enabled {
+		return nil
+	}
+
+	return grpcserver.NewServer(grpcserver.Config{
+		Port:          cfg.GRPC.Port,
+		Reflection:    cfg.GRPC.Reflection,
+		Authenticator: authenticator,
+		RateLimiter:   rateLimiter,
+		ClientIPs:     middleware.NewClientIPResolver(cfg.Server.TrustedProxies),
+		Logger:        logger,
+	}, betService, betValidator, betStream)
+}
+
+func newAuthenticator(cfg *configs.Config) *auth.KeyStore {
+	keys := make(map[string]auth.Grant, len(cfg.Auth.APIKeys))
+	for key, apiKey := range cfg.Auth.APIKeys {
+		grant := auth.Grant{UserID: apiKey.UserID, Operator: apiKey.Operator, OperatorName: apiKey.OperatorName}
+		for _, scope := range apiKey.Scopes {
+			grant.Scopes = append(grant.Scopes, auth.Scope(scope))
+		}
+		keys[key] = grant
+	}
+	return auth.NewKeyStore(keys)
+}
+
+func setupRoutes(cfg *configs.Config, spec *openapi.Document, handlers *httpHandlers, logger *zap.Logger) (*http.ServeMux, []string) {
+	mux := http.NewServeMux()
+	var patterns []string
+
+	handle := func(pattern string, h http.Handler, guards ...func(http.Handler) http.Handler) {
+		method, path, _ := strings.Cut(pattern, " ")
+		h = handler.RequestValidationMiddleware(spec, method, path, logger)(h)
+		for _, guard := range guards {
+			h = guard(h)
+		}
+		mux.Handle(pattern, middleware.TraceRoute(pattern)(h))
+		patterns = append(patterns, pattern)
+	}
+	deprecated := middleware.DeprecationMiddleware(middleware.DeprecationConfig{
+		Deprecation:     cfg.API.UnversionedDeprecation,
+		Sunset:          cfg.API.UnversionedSunset,
+		SuccessorPrefix: handler.V1.Prefix(),
+	})
+	versioned := func(pattern string, h http.Handler, guards ...func(http.Handler) http.Handler) {
+		method, path, _ := strings.Cut(pattern, " ")
+		for _, v := range handler.APIVersions {
+			handle(method+" "+v.Prefix()+path, handler.WithAPIVersion(v)(h), guards...)
+		}
+	}
+	api := func(pattern string, h http.Handler, guards ...func(http.Handler) http.Handler) {
+		versioned(pattern, h, guards...)
+		handle(pattern, deprecated(handler.WithAPIVersion(handler.V1)(h)), guards...)
+	}
+	route := func(pattern string, h http.HandlerFunc) {
+		api(pattern, middleware.TimeoutMiddleware(cfg.Timeout.For(pattern))(h))
+	}
+	versionedRoute := func(pattern string, h http.HandlerFunc) {
+		versioned(pattern, middleware.TimeoutMiddleware(cfg.Timeout.For(pattern))(h))
+	}
+	placement := func(pattern string, h http.HandlerFunc) {
+		api(pattern, middleware.TimeoutMiddleware(cfg.Timeout.For(pattern))(h),
+			handler.OptionalScope(handlers.authenticator, auth.ScopeBetsWrite, logger))
+	}
+	protected := func(pattern string, scope auth.Scope, h http.HandlerFunc) {
+		versioned(pattern, middleware.TimeoutMiddleware(cfg.Timeout.For(pattern))(h),
+			handler.RequireScope(handlers.authenticator, scope, logger))
+	}
+	exportRoute := func(pattern string, scope auth.Scope, h http.HandlerFunc) {
+		versioned(pattern, middleware.TimeoutMiddleware(time.Duration(cfg.Export.Timeout)*time.Second)(h),
+			handler.RequireScope(handlers.authenticator, scope, logger))
+	}
+
+	handle("GET /health", http.HandlerFunc(handlers.health.Health))
+	handle("GET /ready", http.HandlerFunc(handlers.health.Ready))
+	handle("GET /live", http.HandlerFunc(handlers.health.Live))
+	handle("GET /debug/vars", expvar.Handler(), handler.RequireScope(handlers.authenticator, auth.ScopeAdmin, logger))
+	handle("GET /openapi.json", http.HandlerFunc(handlers.openapi.Spec))
+	if cfg.OpenAPI.DocsEnabled {
+		handle("GET /docs", http.HandlerFunc(handlers.openapi.Docs))
+		handle("GET /docs/assets/{asset}", http.HandlerFunc(handlers.openapi.DocsAsset))
+	}
+
+	placement("POST /bets", handlers.bet.CreateBet)
+	placement("POST /bets/batch", handlers.bet.CreateBets)
+	route("GET /bets", handlers.bet.ListBets)
+	route("GET /bets/{id}", handlers.bet.GetBet)
+	versionedRoute("GET /users/{id}/stats", handlers.bet.GetUserStats)
+	versioned("GET /leaderboards/{type}", middleware.TimeoutMiddleware(cfg.Timeout.For("GET /leaderboards/{type}"))(http.HandlerFunc(handlers.leaderboard.GetLeaderboard)),
+		handler.RequireScopeWithQuery("around_user", handlers.authenticator, auth.ScopeBetsRead, logger))
+	protected("GET /users/{id}/limits", auth.ScopeBetsRead, handlers.limits.GetLimits)
+	protected("PUT /users/{id}/limits/{type}/{period}", auth.ScopeBetsWrite, handlers.limits.SetLimit)
+	protected("DELETE /users/{id}/limits/{type}/{period}", auth.ScopeBetsWrite, handlers.limits.RemoveLimit)
+	protected("POST /users/{id}/deposits", auth.ScopeBetsWrite, handlers.limits.RecordDeposit)
+	protected("POST /users/{id}/cool-off", auth.ScopeBetsWrite, handlers.limits.StartCoolOff)
+	protected("POST /users/{id}/self-exclusion", auth.ScopeBetsWrite, handlers.limits.SelfExclude)
+	exportRoute("GET /bets/export", auth.ScopeBetsRead, handlers.export.ExportBets)
+	protected("DELETE /bets/{id}", auth.ScopeBetsWrite, handlers.bet.CancelBet)
+	protected("POST /admin/bets/{id}/void", auth.ScopeAdmin, handlers.admin.VoidBet)
+	protected("GET /admin/bets/{id}/audit", auth.ScopeAdmin, handlers.admin.GetBetAudit)
+	protected("POST /admin/bets/import", auth.ScopeAdmin, handlers.admin.ImportBets)
+	protected("GET /reports/summary", auth.ScopeAdmin, handlers.report.Summary)
+	protected("GET /admin/rounds", auth.ScopeAdmin, handlers.round.ListRounds)
+	protected("GET /admin/rounds/{id}", auth.ScopeAdmin, handlers.round.GetRound)
+	protected("GET /admin/risk/exposure", auth.ScopeAdmin, handlers.risk.GetExposure)
+	protected("GET /admin/fraud/flags", auth.ScopeAdmin, handlers.fraud.ListFlags)
+	protected("POST /admin/fraud/flags/{id}/review", auth.ScopeAdmin, handlers.fraud.ReviewFlag)
+	protected("GET /admin/fraud/rules", auth.ScopeAdmin, handlers.fraud.GetRules)
+	protected("POST /admin/fraud/rules/reload", auth.ScopeAdmin, handlers.fraud.ReloadRules)
+	protected("GET /admin/api-keys", auth.ScopeAdmin, handlers.key.ListKeys)
+	protected("POST /admin/api-keys", auth.ScopeAdmin, handlers.key.CreateKey)
+	protected("DELETE /admin/api-keys/{id}", auth.ScopeAdmin, handlers.key.RevokeKey)
+	protected("GET /admin/snapshots", auth.ScopeAdmin, handlers.snapshot.ListSnapshots)
+	protected("POST /admin/snapshots", auth.ScopeAdmin, handlers.snapshot.CreateSnapshot)
+	protected("POST /admin/snapshots/compact", auth.ScopeAdmin, handlers.snapshot.CompactSnapshots)
+	protected("POST /webhooks", auth.ScopeWebhooks, handlers.webhook.CreateWebhook)
+	protected("GET /webhooks/{id}/deliveries", auth.ScopeWebhooks, handlers.webhook.ListDeliveries)
+
+	api("GET /bets/stream", http.HandlerFunc(handlers.stream.StreamBets))
+	handle("GET /feed", http.HandlerFunc(handlers.feed.Stream))
+
+	return mux, patterns
+}
+
 func startServer(srv *http.Server, cfg *configs.Config, logger *zap.Logger) {
 	go func() {
 		logger.Info("starting server", zap.Int("port", cfg.Server.Port))
@@ -105,10 +522,28 @@ func startServer(srv *http.Server, cfg *configs.Config, logger *zap.Logger) {
 	logger.Info("shutting down server...")
 }
 
-func shutdownServer(srv *http.Server, rateLimiter *middleware.RateLimiter, logger *zap.Logger) {
+func shutdownServer(srv *http.Server, grpcServer *grpcserver.Server, rateLimiter *middleware.RateLimiter, gameEngine *game.Engine, feedHub *feed.Hub, betStream *sse.Broker, eventBus *events.Bus, webhookDispatcher *webhook.Dispatcher, brokerRelay *broker.Relay, snapshots *snapshot.Manager, fraudEngine *fraud.Engine, tracerProvider *tracing.Provider, logger *zap.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	if err := gameEngine.Shutdown(ctx); err != nil {
+		logger.Warn("game engine shutdown error", zap.Error(err))
+	}
+
+	if err := feedHub.Shutdown(ctx); err != nil {
+		logger.Warn("feed hub shutdown error", zap.Error(err))
+	}
+
+	if err := betStream.Shutdown(ctx); err != nil {
+		logger.Warn("bet stream shutdown error", zap.Error(err))
+	}
+
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			logger.Warn("gRPC server shutdown error", zap.Error(err))
+		}
+	}
+
 	if err := rateLimiter.Shutdown(ctx); err != nil {
 		logger.Warn("rate limiter shutdown error", zap.Error(err))
 	}
@@ -118,5 +553,33 @@ func shutdownServer(srv *http.Server, rateLimiter *middleware.RateLimiter, logge
 		os.Exit(1)
 	}
 
+	if err := eventBus.Shutdown(ctx); err != nil {
+		logger.Warn("event bus shutdown error", zap.Error(err))
+	}
+
+	if err := webhookDispatcher.Shutdown(ctx); err != nil {
+		logger.Warn("webhook dispatcher shutdown error", zap.Error(err))
+	}
+
+	if brokerRelay != nil {
+		if err := brokerRelay.Shutdown(ctx); err != nil {
+			logger.Warn("broker relay shutdown error", zap.Error(err))
+		}
+	}
+
+	if snapshots != nil {
+		if err := snapshots.Shutdown(ctx); err != nil {
+			logger.Warn("snapshot manager shutdown error", zap.Error(err))
+		}
+	}
+
+	if err := fraudEngine.Shutdown(ctx); err != nil {
+		logger.Warn("fraud engine shutdown error", zap.Error(err))
+	}
+
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		logger.Warn("tracer provider shutdown error", zap.Error(err))
+	}
+
 	logger.Info("server exited")
 }
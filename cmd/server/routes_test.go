@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bet/configs"
+	"bet/internal/auth"
+	"bet/internal/fraud"
+	"bet/internal/game"
+	"bet/internal/handler"
+	"bet/internal/openapi"
+	"bet/internal/repository"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRegisteredRoutesAreDocumented(t *testing.T) {
+	for _, docsEnabled := range []bool{true, false} {
+		cfg := &configs.Config{OpenAPI: configs.OpenAPIConfig{DocsEnabled: docsEnabled}}
+		spec := handler.OpenAPISpec(handler.SpecOptions{DocsEnabled: docsEnabled})
+
+		_, patterns := setupRoutes(cfg, spec, &httpHandlers{}, zap.NewNop())
+		if len(patterns) == 0 {
+			t.Fatal("no routes registered")
+		}
+
+		registered := make(map[string]bool, len(patterns))
+		for _, pattern := range patterns {
+			method, path, ok := strings.Cut(pattern, " ")
+			if !ok {
+				t.Errorf("route %q has no method; register routes as \"METHOD /path\"", pattern)
+				continue
+			}
+			registered[method+" "+path] = true
+
+			if spec.Operation(method, path) == nil {
+				t.Errorf("route %q (docs enabled: %v) is missing from the OpenAPI spec", pattern, docsEnabled)
+			}
+		}
+
+		for path, item := range spec.Paths {
+			for method, op := range map[string]*openapi.Operation{
+				http.MethodGet:    item.Get,
+				http.MethodPost:   item.Post,
+				http.MethodPut:    item.Put,
+				http.MethodPatch:  item.Patch,
+				http.MethodDelete: item.Delete,
+			} {
+				if op == nil {
+					continue
+				}
+				if !registered[method+" "+path] {
+					t.Errorf("spec documents %s %s (docs enabled: %v) but no such route is registered", method, path, docsEnabled)
+				}
+			}
+		}
+	}
+}
+
+func newPlacementHandlers(t *testing.T, keys *auth.KeyStore) *httpHandlers {
+	t.Helper()
+
+	spec := handler.OpenAPISpec(handler.SpecOptions{})
+	betRepo := repository.NewInMemoryBetRepository()
+	engine := game.NewEngine(game.Config{Logger: zap.NewNop()}, betRepo, nil)
+	fraudEngine, err := fraud.NewEngine(fraud.Config{})
+	if err != nil {
+		t.Fatalf("fraud engine: %v", err)
+	}
+	limits := service.NewLimitsService(repository.NewInMemoryLimitRepository(), betRepo, time.Hour)
+	fraudService := service.NewFraudService(repository.NewInMemoryFraudFlagRepository(), fraudEngine)
+	betService := service.NewBetService(betRepo, engine, limits, fraudService, "EUR")
+
+	return &httpHandlers{
+		bet:           handler.NewBetHandler(betService, validator.NewBetValidator(spec), zap.NewNop()),
+		authenticator: keys,
+	}
+}
+
+func TestBetPlacementWithoutKeys(t *testing.T) {
+	spec := handler.OpenAPISpec(handler.SpecOptions{})
+	mux, _ := setupRoutes(&configs.Config{}, spec, newPlacementHandlers(t, auth.NewKeyStore(nil)), zap.NewNop())
+
+	tests := []struct {
+		path           string
+		body           string
+		wantDeprecated bool
+	}{
+		{path: "/bets", body: `{"user_id": 1, "amount": 10, "crash_point": 2}`, wantDeprecated: true},
+		{path: "/v1/bets", body: `{"user_id": 1, "amount": 10, "crash_point": 2}`},
+		{path: "/v2/bets", body: `{"user_id": 1, "amount": "10", "crash_point": "2"}`},
+		{path: "/bets/batch", body: `{"bets": [{"user_id": 1, "amount": 10, "crash_point": 2}, {"user_id": 2, "amount": 10, "crash_point": 2}]}`, wantDeprecated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+			}
+			if deprecated := rec.Header().Get("Deprecation") != ""; deprecated != tt.wantDeprecated {
+				t.Errorf("Deprecation header present = %v, want %v", deprecated, tt.wantDeprecated)
+			}
+		})
+	}
+}
+
+func TestBetPlacementRequiresScopeWithKeys(t *testing.T) {
+	keys := auth.NewKeyStore(map[string]auth.Grant{
+		"reader": {Scopes: []auth.Scope{auth.ScopeBetsRead}},
+		"player": {Scopes: []auth.Scope{auth.ScopeBetsWrite}, UserID: 1},
+	})
+	spec := handler.OpenAPISpec(handler.SpecOptions{})
+	mux, _ := setupRoutes(&configs.Config{}, spec, newPlacementHandlers(t, keys), zap.NewNop())
+
+	tests := []struct {
+		name   string
+		path   string
+		key    string
+		userID int
+		want   int
+	}{
+		{name: "no key", path: "/bets", want: http.StatusUnauthorized, userID: 1},
+		{name: "missing scope", path: "/v1/bets", key: "reader", userID: 1, want: http.StatusForbidden},
+		{name: "other user", path: "/v1/bets", key: "player", userID: 2, want: http.StatusForbidden},
+		{name: "own bet on the alias", path: "/bets", key: "player", userID: 1, want: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"user_id": %d, "amount": 10, "crash_point": 2}`, tt.userID)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.key)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
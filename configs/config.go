@@ -1,27 +1,210 @@
 package configs
 
 import (
+	"bet/internal/domain"
 	"fmt"
+	"net/netip"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Server    ServerConfig
-	RateLimit RateLimitConfig
+	Server      ServerConfig
+	RateLimit   RateLimitConfig
+	Tracing     TracingConfig
+	Timeout     TimeoutConfig
+	Compression CompressionConfig
+	CORS        CORSConfig
+	Game        GameConfig
+	Feed        FeedConfig
+	Stream      StreamConfig
+	Events      EventsConfig
+	Webhook     WebhookConfig
+	Broker      BrokerConfig
+	GRPC        GRPCConfig
+	Auth        AuthConfig
+	OpenAPI     OpenAPIConfig
+	API         APIConfig
+	Batch       BatchConfig
+	Bets        BetsConfig
+	Export      ExportConfig
+	Snapshot    SnapshotConfig
+	Leaderboard LeaderboardConfig
+	Limits      LimitsConfig
+	Risk        RiskConfig
+	Fraud       FraudConfig
 }
 
 type ServerConfig struct {
-	Port         int
-	ReadTimeout  int
-	WriteTimeout int
-	IdleTimeout  int
+	Port           int
+	ReadTimeout    int
+	WriteTimeout   int
+	IdleTimeout    int
+	TrustedProxies []netip.Prefix
 }
 
 type RateLimitConfig struct {
 	RequestsPerMinute int
 }
 
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	FilePath     string
+	ServiceName  string
+	SampleRatio  float64
+}
+
+type TimeoutConfig struct {
+	Default int
+	Routes  map[string]int
+}
+
+func (c TimeoutConfig) For(pattern string) time.Duration {
+	if seconds, ok := c.Routes[pattern]; ok {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Duration(c.Default) * time.Second
+}
+
+type CompressionConfig struct {
+	Enabled bool
+	MinSize int
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+type GameConfig struct {
+	BettingPhase   int
+	TickIntervalMS int
+	Cooldown       int
+	HouseEdge      float64
+	MaxCrashPoint  float64
+}
+
+type FeedConfig struct {
+	SendBuffer   int
+	PingInterval int
+	PongWait     int
+}
+
+type StreamConfig struct {
+	ReplaySize       int
+	SubscriberBuffer int
+	KeepAlive        int
+}
+
+type EventsConfig struct {
+	PollIntervalMS   int
+	BatchSize        int
+	MaxAttempts      int
+	RetryBackoffMS   int
+	SubscriberBuffer int
+}
+
+type WebhookConfig struct {
+	Workers      int
+	QueueSize    int
+	MaxAttempts  int
+	BackoffMS    int
+	MaxBackoff   int
+	Timeout      int
+	DisableAfter int
+	AllowPrivate bool
+}
+
+type BrokerConfig struct {
+	Driver         string
+	URL            string
+	Stream         string
+	Subject        string
+	Partitions     int
+	PublishTimeout int
+	RetryInterval  int
+	BufferDir      string
+	BufferMax      int
+}
+
+type GRPCConfig struct {
+	Enabled    bool
+	Port       int
+	Reflection bool
+}
+
+type AuthConfig struct {
+	APIKeys map[string]APIKeyConfig
+}
+
+type APIKeyConfig struct {
+	Scopes       []string
+	UserID       int64
+	Operator     bool
+	OperatorName string
+}
+
+type OpenAPIConfig struct {
+	DocsEnabled bool
+}
+
+type APIConfig struct {
+	UnversionedDeprecation time.Time
+	UnversionedSunset      time.Time
+}
+
+type BatchConfig struct {
+	MaxBets   int
+	MaxImport int
+}
+
+type BetsConfig struct {
+	DefaultCurrency string
+}
+
+type ExportConfig struct {
+	Timeout      int
+	RowGroupSize int
+}
+
+type SnapshotConfig struct {
+	Dir      string
+	Interval int
+	Retain   int
+	Restore  bool
+}
+
+type LeaderboardConfig struct {
+	UserMask   string
+	MaskSecret string
+}
+
+type LimitsConfig struct {
+	IncreaseDelay int
+}
+
+type RiskConfig struct {
+	Mode                string
+	RoundLiability      float64
+	UserLiability       float64
+	ThrottleRatio       float64
+	ThrottledCrashPoint float64
+}
+
+type FraudConfig struct {
+	RulesFile      string
+	ReloadInterval int
+}
+
 type ConfigError struct {
 	Field   string
 	Message string
@@ -72,77 +255,1101 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
-	cfg := &Config{
-		Server: ServerConfig{
-			Port:         port,
-			ReadTimeout:  readTimeout,
-			WriteTimeout: writeTimeout,
-			IdleTimeout:  idleTimeout,
-		},
-		RateLimit: RateLimitConfig{
-			RequestsPerMinute: rateLimit,
-		},
+	tracingInsecure, err := getEnvAsBool("TRACING_OTLP_INSECURE", false)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "TRACING_OTLP_INSECURE",
+			Message: fmt.Sprintf("invalid flag: %v", err),
+		}
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	tracingSampleRatio, err := getEnvAsFloat("TRACING_SAMPLE_RATIO", 1.0)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "TRACING_SAMPLE_RATIO",
+			Message: fmt.Sprintf("invalid sample ratio: %v", err),
+		}
 	}
 
-	return cfg, nil
-}
+	trustedProxies, err := parseTrustedProxies(getEnvAsSlice("TRUSTED_PROXIES", nil))
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "TRUSTED_PROXIES",
+			Message: fmt.Sprintf("invalid trusted proxies: %v", err),
+		}
+	}
 
-func (c *Config) Validate() error {
-	if err := validateRange("SERVER_PORT", c.Server.Port, 1, 65535); err != nil {
-		return err
+	requestTimeout, err := getEnvAsInt("REQUEST_TIMEOUT", 10)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "REQUEST_TIMEOUT",
+			Message: fmt.Sprintf("invalid request timeout: %v", err),
+		}
 	}
 
-	if err := validateRange("SERVER_READ_TIMEOUT", c.Server.ReadTimeout, 1, 300); err != nil {
-		return err
+	routeTimeouts, err := parseRouteTimeouts(os.Getenv("REQUEST_TIMEOUT_ROUTES"))
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "REQUEST_TIMEOUT_ROUTES",
+			Message: fmt.Sprintf("invalid route timeouts: %v", err),
+		}
 	}
 
-	if err := validateRange("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout, 1, 300); err != nil {
-		return err
+	compressionEnabled, err := getEnvAsBool("COMPRESSION_ENABLED", true)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "COMPRESSION_ENABLED",
+			Message: fmt.Sprintf("invalid flag: %v", err),
+		}
 	}
 
-	if err := validateRange("SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout, 1, 600); err != nil {
-		return err
+	compressionMinSize, err := getEnvAsInt("COMPRESSION_MIN_SIZE", 1024)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "COMPRESSION_MIN_SIZE",
+			Message: fmt.Sprintf("invalid minimum size: %v", err),
+		}
 	}
 
-	if err := validateRange("RATE_LIMIT_REQUESTS_PER_MINUTE", c.RateLimit.RequestsPerMinute, 1, 10000); err != nil {
-		return err
+	corsAllowCredentials, err := getEnvAsBool("CORS_ALLOW_CREDENTIALS", false)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "CORS_ALLOW_CREDENTIALS",
+			Message: fmt.Sprintf("invalid flag: %v", err),
+		}
 	}
 
-	return nil
-}
+	corsMaxAge, err := getEnvAsInt("CORS_MAX_AGE", 600)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "CORS_MAX_AGE",
+			Message: fmt.Sprintf("invalid max age: %v", err),
+		}
+	}
 
-func validateRange(field string, value, min, max int) error {
-	if value < min {
-		return &ConfigError{
-			Field:   field,
-			Message: fmt.Sprintf("must be at least %d, got: %d", min, value),
+	bettingPhase, err := getEnvAsInt("GAME_BETTING_PHASE", 5)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GAME_BETTING_PHASE",
+			Message: fmt.Sprintf("invalid betting phase: %v", err),
 		}
 	}
 
-	if value > max {
-		return &ConfigError{
-			Field:   field,
-			Message: fmt.Sprintf("must not exceed %d, got: %d", max, value),
+	tickInterval, err := getEnvAsInt("GAME_TICK_INTERVAL_MS", 100)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GAME_TICK_INTERVAL_MS",
+			Message: fmt.Sprintf("invalid tick interval: %v", err),
 		}
 	}
 
-	return nil
-}
+	cooldown, err := getEnvAsInt("GAME_COOLDOWN", 3)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GAME_COOLDOWN",
+			Message: fmt.Sprintf("invalid cooldown: %v", err),
+		}
+	}
 
-func getEnvAsInt(key string, defaultValue int) (int, error) {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue, nil
+	houseEdge, err := getEnvAsFloat("GAME_HOUSE_EDGE", 0.01)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GAME_HOUSE_EDGE",
+			Message: fmt.Sprintf("invalid house edge: %v", err),
+		}
 	}
 
-	intValue, err := strconv.Atoi(value)
+	maxRoundCrashPoint, err := getEnvAsFloat("GAME_MAX_CRASH_POINT", 1000)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse %s as integer: %w", key, err)
+		return nil, &ConfigError{
+			Field:   "GAME_MAX_CRASH_POINT",
+			Message: fmt.Sprintf("invalid max crash point: %v", err),
+		}
 	}
 
-	return intValue, nil
+	feedSendBuffer, err := getEnvAsInt("FEED_SEND_BUFFER", 256)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "FEED_SEND_BUFFER",
+			Message: fmt.Sprintf("invalid send buffer: %v", err),
+		}
+	}
+
+	feedPingInterval, err := getEnvAsInt("FEED_PING_INTERVAL", 30)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "FEED_PING_INTERVAL",
+			Message: fmt.Sprintf("invalid ping interval: %v", err),
+		}
+	}
+
+	feedPongWait, err := getEnvAsInt("FEED_PONG_WAIT", 60)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "FEED_PONG_WAIT",
+			Message: fmt.Sprintf("invalid pong wait: %v", err),
+		}
+	}
+
+	streamReplaySize, err := getEnvAsInt("STREAM_REPLAY_SIZE", 1000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "STREAM_REPLAY_SIZE",
+			Message: fmt.Sprintf("invalid replay size: %v", err),
+		}
+	}
+
+	streamSubscriberBuffer, err := getEnvAsInt("STREAM_SUBSCRIBER_BUFFER", 64)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "STREAM_SUBSCRIBER_BUFFER",
+			Message: fmt.Sprintf("invalid subscriber buffer: %v", err),
+		}
+	}
+
+	streamKeepAlive, err := getEnvAsInt("STREAM_KEEPALIVE", 15)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "STREAM_KEEPALIVE",
+			Message: fmt.Sprintf("invalid keep-alive interval: %v", err),
+		}
+	}
+
+	eventsPollInterval, err := getEnvAsInt("EVENTS_POLL_INTERVAL_MS", 100)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EVENTS_POLL_INTERVAL_MS",
+			Message: fmt.Sprintf("invalid poll interval: %v", err),
+		}
+	}
+
+	eventsBatchSize, err := getEnvAsInt("EVENTS_BATCH_SIZE", 100)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EVENTS_BATCH_SIZE",
+			Message: fmt.Sprintf("invalid batch size: %v", err),
+		}
+	}
+
+	eventsMaxAttempts, err := getEnvAsInt("EVENTS_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EVENTS_MAX_ATTEMPTS",
+			Message: fmt.Sprintf("invalid max attempts: %v", err),
+		}
+	}
+
+	eventsRetryBackoff, err := getEnvAsInt("EVENTS_RETRY_BACKOFF_MS", 100)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EVENTS_RETRY_BACKOFF_MS",
+			Message: fmt.Sprintf("invalid retry backoff: %v", err),
+		}
+	}
+
+	eventsSubscriberBuffer, err := getEnvAsInt("EVENTS_SUBSCRIBER_BUFFER", 1024)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EVENTS_SUBSCRIBER_BUFFER",
+			Message: fmt.Sprintf("invalid subscriber buffer: %v", err),
+		}
+	}
+
+	webhookWorkers, err := getEnvAsInt("WEBHOOK_WORKERS", 4)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_WORKERS",
+			Message: fmt.Sprintf("invalid worker count: %v", err),
+		}
+	}
+
+	webhookQueueSize, err := getEnvAsInt("WEBHOOK_QUEUE_SIZE", 1024)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_QUEUE_SIZE",
+			Message: fmt.Sprintf("invalid queue size: %v", err),
+		}
+	}
+
+	webhookMaxAttempts, err := getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_MAX_ATTEMPTS",
+			Message: fmt.Sprintf("invalid max attempts: %v", err),
+		}
+	}
+
+	webhookBackoff, err := getEnvAsInt("WEBHOOK_BACKOFF_MS", 500)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_BACKOFF_MS",
+			Message: fmt.Sprintf("invalid backoff: %v", err),
+		}
+	}
+
+	webhookMaxBackoff, err := getEnvAsInt("WEBHOOK_MAX_BACKOFF", 60)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_MAX_BACKOFF",
+			Message: fmt.Sprintf("invalid max backoff: %v", err),
+		}
+	}
+
+	webhookTimeout, err := getEnvAsInt("WEBHOOK_TIMEOUT", 10)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_TIMEOUT",
+			Message: fmt.Sprintf("invalid timeout: %v", err),
+		}
+	}
+
+	webhookDisableAfter, err := getEnvAsInt("WEBHOOK_DISABLE_AFTER", 10)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_DISABLE_AFTER",
+			Message: fmt.Sprintf("invalid disable threshold: %v", err),
+		}
+	}
+
+	webhookAllowPrivate, err := getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "WEBHOOK_ALLOW_PRIVATE_TARGETS",
+			Message: fmt.Sprintf("invalid flag: %v", err),
+		}
+	}
+
+	brokerPartitions, err := getEnvAsInt("BROKER_PARTITIONS", 16)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BROKER_PARTITIONS",
+			Message: fmt.Sprintf("invalid partition count: %v", err),
+		}
+	}
+
+	brokerPublishTimeout, err := getEnvAsInt("BROKER_PUBLISH_TIMEOUT", 5)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BROKER_PUBLISH_TIMEOUT",
+			Message: fmt.Sprintf("invalid publish timeout: %v", err),
+		}
+	}
+
+	brokerRetryInterval, err := getEnvAsInt("BROKER_RETRY_INTERVAL", 2)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BROKER_RETRY_INTERVAL",
+			Message: fmt.Sprintf("invalid retry interval: %v", err),
+		}
+	}
+
+	brokerBufferMax, err := getEnvAsInt("BROKER_BUFFER_MAX", 100000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BROKER_BUFFER_MAX",
+			Message: fmt.Sprintf("invalid buffer size: %v", err),
+		}
+	}
+
+	grpcEnabled, err := getEnvAsBool("GRPC_ENABLED", true)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GRPC_ENABLED",
+			Message: fmt.Sprintf("invalid boolean: %v", err),
+		}
+	}
+
+	grpcPort, err := getEnvAsInt("GRPC_PORT", 9090)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GRPC_PORT",
+			Message: fmt.Sprintf("invalid port: %v", err),
+		}
+	}
+
+	grpcReflection, err := getEnvAsBool("GRPC_REFLECTION", true)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "GRPC_REFLECTION",
+			Message: fmt.Sprintf("invalid boolean: %v", err),
+		}
+	}
+
+	docsEnabled, err := getEnvAsBool("OPENAPI_DOCS_ENABLED", true)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "OPENAPI_DOCS_ENABLED",
+			Message: fmt.Sprintf("invalid boolean: %v", err),
+		}
+	}
+
+	unversionedDeprecation, err := getEnvAsDate("API_UNVERSIONED_DEPRECATION", "2026-11-01")
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "API_UNVERSIONED_DEPRECATION",
+			Message: fmt.Sprintf("invalid date, expected YYYY-MM-DD: %v", err),
+		}
+	}
+
+	unversionedSunset, err := getEnvAsDate("API_UNVERSIONED_SUNSET", "2027-05-01")
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "API_UNVERSIONED_SUNSET",
+			Message: fmt.Sprintf("invalid date, expected YYYY-MM-DD: %v", err),
+		}
+	}
+
+	batchMaxBets, err := getEnvAsInt("BET_BATCH_MAX_SIZE", 100)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BET_BATCH_MAX_SIZE",
+			Message: fmt.Sprintf("invalid batch size: %v", err),
+		}
+	}
+
+	importMaxBets, err := getEnvAsInt("BET_IMPORT_BATCH_MAX_SIZE", 1000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "BET_IMPORT_BATCH_MAX_SIZE",
+			Message: fmt.Sprintf("invalid batch size: %v", err),
+		}
+	}
+
+	exportTimeout, err := getEnvAsInt("EXPORT_TIMEOUT", 600)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EXPORT_TIMEOUT",
+			Message: fmt.Sprintf("invalid export timeout: %v", err),
+		}
+	}
+
+	exportRowGroupSize, err := getEnvAsInt("EXPORT_PARQUET_ROW_GROUP_SIZE", 10000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "EXPORT_PARQUET_ROW_GROUP_SIZE",
+			Message: fmt.Sprintf("invalid row group size: %v", err),
+		}
+	}
+
+	snapshotInterval, err := getEnvAsInt("SNAPSHOT_INTERVAL", 0)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "SNAPSHOT_INTERVAL",
+			Message: fmt.Sprintf("invalid snapshot interval: %v", err),
+		}
+	}
+
+	snapshotRetain, err := getEnvAsInt("SNAPSHOT_RETAIN", 5)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "SNAPSHOT_RETAIN",
+			Message: fmt.Sprintf("invalid snapshot retention: %v", err),
+		}
+	}
+
+	snapshotRestore, err := getEnvAsBool("SNAPSHOT_RESTORE", true)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "SNAPSHOT_RESTORE",
+			Message: fmt.Sprintf("invalid flag: %v", err),
+		}
+	}
+
+	limitsIncreaseDelay, err := getEnvAsInt("LIMITS_INCREASE_DELAY", 24)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "LIMITS_INCREASE_DELAY",
+			Message: fmt.Sprintf("invalid limit increase delay: %v", err),
+		}
+	}
+
+	riskRoundLiability, err := getEnvAsFloat("RISK_ROUND_LIABILITY", 1000000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "RISK_ROUND_LIABILITY",
+			Message: fmt.Sprintf("invalid round liability: %v", err),
+		}
+	}
+
+	riskUserLiability, err := getEnvAsFloat("RISK_USER_LIABILITY", 250000)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "RISK_USER_LIABILITY",
+			Message: fmt.Sprintf("invalid user liability: %v", err),
+		}
+	}
+
+	riskThrottleRatio, err := getEnvAsFloat("RISK_THROTTLE_RATIO", 0.8)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "RISK_THROTTLE_RATIO",
+			Message: fmt.Sprintf("invalid throttle ratio: %v", err),
+		}
+	}
+
+	riskThrottledCrashPoint, err := getEnvAsFloat("RISK_THROTTLED_CRASH_POINT", 10)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "RISK_THROTTLED_CRASH_POINT",
+			Message: fmt.Sprintf("invalid throttled crash point: %v", err),
+		}
+	}
+
+	fraudReloadInterval, err := getEnvAsInt("FRAUD_RELOAD_INTERVAL", 10)
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "FRAUD_RELOAD_INTERVAL",
+			Message: fmt.Sprintf("invalid fraud rules reload interval: %v", err),
+		}
+	}
+
+	apiKeys, err := parseAPIKeys(os.Getenv("AUTH_API_KEYS"))
+	if err != nil {
+		return nil, &ConfigError{
+			Field:   "AUTH_API_KEYS",
+			Message: fmt.Sprintf("invalid api keys: %v", err),
+		}
+	}
+
+	cfg := &Config{
+		Server: ServerConfig{
+			Port:           port,
+			ReadTimeout:    readTimeout,
+			WriteTimeout:   writeTimeout,
+			IdleTimeout:    idleTimeout,
+			TrustedProxies: trustedProxies,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: rateLimit,
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+			OTLPInsecure: tracingInsecure,
+			FilePath:     getEnv("TRACING_FILE_PATH", "traces.json"),
+			ServiceName:  getEnv("TRACING_SERVICE_NAME", "bet-api"),
+			SampleRatio:  tracingSampleRatio,
+		},
+		Timeout: TimeoutConfig{
+			Default: requestTimeout,
+			Routes:  routeTimeouts,
+		},
+		Compression: CompressionConfig{
+			Enabled: compressionEnabled,
+			MinSize: compressionMinSize,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", nil),
+			AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "DELETE"}),
+			AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-Request-ID", "X-Device-ID", "traceparent", "tracestate"}),
+			ExposedHeaders:   getEnvAsSlice("CORS_EXPOSED_HEADERS", []string{"X-Request-ID", "X-RateLimit-Limit", "Retry-After", "Deprecation", "Sunset", "Link"}),
+			AllowCredentials: corsAllowCredentials,
+			MaxAge:           corsMaxAge,
+		},
+		Game: GameConfig{
+			BettingPhase:   bettingPhase,
+			TickIntervalMS: tickInterval,
+			Cooldown:       cooldown,
+			HouseEdge:      houseEdge,
+			MaxCrashPoint:  maxRoundCrashPoint,
+		},
+		Feed: FeedConfig{
+			SendBuffer:   feedSendBuffer,
+			PingInterval: feedPingInterval,
+			PongWait:     feedPongWait,
+		},
+		Stream: StreamConfig{
+			ReplaySize:       streamReplaySize,
+			SubscriberBuffer: streamSubscriberBuffer,
+			KeepAlive:        streamKeepAlive,
+		},
+		Events: EventsConfig{
+			PollIntervalMS:   eventsPollInterval,
+			BatchSize:        eventsBatchSize,
+			MaxAttempts:      eventsMaxAttempts,
+			RetryBackoffMS:   eventsRetryBackoff,
+			SubscriberBuffer: eventsSubscriberBuffer,
+		},
+		Webhook: WebhookConfig{
+			Workers:      webhookWorkers,
+			QueueSize:    webhookQueueSize,
+			MaxAttempts:  webhookMaxAttempts,
+			BackoffMS:    webhookBackoff,
+			MaxBackoff:   webhookMaxBackoff,
+			Timeout:      webhookTimeout,
+			DisableAfter: webhookDisableAfter,
+			AllowPrivate: webhookAllowPrivate,
+		},
+		Broker: BrokerConfig{
+			Driver:         getEnv("BROKER_DRIVER", "none"),
+			URL:            getEnv("BROKER_URL", "nats://localhost:4222"),
+			Stream:         getEnv("BROKER_STREAM", "BETS"),
+			Subject:        getEnv("BROKER_SUBJECT", "bets.events"),
+			Partitions:     brokerPartitions,
+			PublishTimeout: brokerPublishTimeout,
+			RetryInterval:  brokerRetryInterval,
+			BufferDir:      getEnv("BROKER_BUFFER_DIR", "data/broker-buffer"),
+			BufferMax:      brokerBufferMax,
+		},
+		GRPC: GRPCConfig{
+			Enabled:    grpcEnabled,
+			Port:       grpcPort,
+			Reflection: grpcReflection,
+		},
+		Auth: AuthConfig{
+			APIKeys: apiKeys,
+		},
+		OpenAPI: OpenAPIConfig{
+			DocsEnabled: docsEnabled,
+		},
+		API: APIConfig{
+			UnversionedDeprecation: unversionedDeprecation,
+			UnversionedSunset:      unversionedSunset,
+		},
+		Batch: BatchConfig{
+			MaxBets:   batchMaxBets,
+			MaxImport: importMaxBets,
+		},
+		Bets: BetsConfig{
+			DefaultCurrency: getEnv("BET_DEFAULT_CURRENCY", "EUR"),
+		},
+		Export: ExportConfig{
+			Timeout:      exportTimeout,
+			RowGroupSize: exportRowGroupSize,
+		},
+		Snapshot: SnapshotConfig{
+			Dir:      getEnv("SNAPSHOT_DIR", ""),
+			Interval: snapshotInterval,
+			Retain:   snapshotRetain,
+			Restore:  snapshotRestore,
+		},
+		Leaderboard: LeaderboardConfig{
+			UserMask:   getEnv("LEADERBOARD_USER_MASK", "partial"),
+			MaskSecret: getEnv("LEADERBOARD_MASK_SECRET", ""),
+		},
+		Limits: LimitsConfig{
+			IncreaseDelay: limitsIncreaseDelay,
+		},
+		Risk: RiskConfig{
+			Mode:                getEnv("RISK_MODE", "reject"),
+			RoundLiability:      riskRoundLiability,
+			UserLiability:       riskUserLiability,
+			ThrottleRatio:       riskThrottleRatio,
+			ThrottledCrashPoint: riskThrottledCrashPoint,
+		},
+		Fraud: FraudConfig{
+			RulesFile:      getEnv("FRAUD_RULES_FILE", ""),
+			ReloadInterval: fraudReloadInterval,
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	if err := validateRange("SERVER_PORT", c.Server.Port, 1, 65535); err != nil {
+		return err
+	}
+
+	if err := validateRange("SERVER_READ_TIMEOUT", c.Server.ReadTimeout, 1, 300); err != nil {
+		return err
+	}
+
+	if err := validateRange("SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout, 1, 300); err != nil {
+		return err
+	}
+
+	if err := validateRange("SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout, 1, 600); err != nil {
+		return err
+	}
+
+	if err := validateRange("RATE_LIMIT_REQUESTS_PER_MINUTE", c.RateLimit.RequestsPerMinute, 1, 10000); err != nil {
+		return err
+	}
+
+	if err := validateOneOf("TRACING_EXPORTER", c.Tracing.Exporter, []string{"none", "stdout", "file", "otlp"}); err != nil {
+		return err
+	}
+
+	if c.Tracing.Exporter == "otlp" && c.Tracing.OTLPEndpoint == "" {
+		return &ConfigError{
+			Field:   "TRACING_OTLP_ENDPOINT",
+			Message: "must be set when TRACING_EXPORTER is otlp",
+		}
+	}
+
+	if c.Tracing.Exporter == "file" && c.Tracing.FilePath == "" {
+		return &ConfigError{
+			Field:   "TRACING_FILE_PATH",
+			Message: "must be set when TRACING_EXPORTER is file",
+		}
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return &ConfigError{
+			Field:   "TRACING_SAMPLE_RATIO",
+			Message: fmt.Sprintf("must be between 0 and 1, got: %g", c.Tracing.SampleRatio),
+		}
+	}
+
+	if err := validateRange("REQUEST_TIMEOUT", c.Timeout.Default, 1, 300); err != nil {
+		return err
+	}
+
+	for pattern, seconds := range c.Timeout.Routes {
+		if err := validateRange(fmt.Sprintf("REQUEST_TIMEOUT_ROUTES[%s]", pattern), seconds, 1, 300); err != nil {
+			return err
+		}
+	}
+
+	if err := validateRange("COMPRESSION_MIN_SIZE", c.Compression.MinSize, 0, 1<<20); err != nil {
+		return err
+	}
+
+	if err := validateRange("CORS_MAX_AGE", c.CORS.MaxAge, 0, 86400); err != nil {
+		return err
+	}
+
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowedOrigins, "*") {
+		return &ConfigError{
+			Field:   "CORS_ALLOW_CREDENTIALS",
+			Message: "cannot be combined with CORS_ALLOWED_ORIGINS=*; list the trusted origins instead",
+		}
+	}
+
+	if err := validateRange("GAME_BETTING_PHASE", c.Game.BettingPhase, 1, 300); err != nil {
+		return err
+	}
+
+	if err := validateRange("GAME_TICK_INTERVAL_MS", c.Game.TickIntervalMS, 10, 5000); err != nil {
+		return err
+	}
+
+	if err := validateRange("GAME_COOLDOWN", c.Game.Cooldown, 1, 300); err != nil {
+		return err
+	}
+
+	if c.Game.HouseEdge <= 0 || c.Game.HouseEdge >= 0.5 {
+		return &ConfigError{
+			Field:   "GAME_HOUSE_EDGE",
+			Message: fmt.Sprintf("must be between 0 and 0.5, got: %g", c.Game.HouseEdge),
+		}
+	}
+
+	if c.Game.MaxCrashPoint < 2 {
+		return &ConfigError{
+			Field:   "GAME_MAX_CRASH_POINT",
+			Message: fmt.Sprintf("must be at least 2, got: %g", c.Game.MaxCrashPoint),
+		}
+	}
+
+	if err := validateRange("FEED_SEND_BUFFER", c.Feed.SendBuffer, 1, 65536); err != nil {
+		return err
+	}
+
+	if err := validateRange("FEED_PONG_WAIT", c.Feed.PongWait, 2, 600); err != nil {
+		return err
+	}
+
+	if err := validateRange("FEED_PING_INTERVAL", c.Feed.PingInterval, 1, c.Feed.PongWait-1); err != nil {
+		return err
+	}
+
+	if err := validateRange("STREAM_REPLAY_SIZE", c.Stream.ReplaySize, 1, 100000); err != nil {
+		return err
+	}
+
+	if err := validateRange("STREAM_SUBSCRIBER_BUFFER", c.Stream.SubscriberBuffer, 1, 65536); err != nil {
+		return err
+	}
+
+	if err := validateRange("STREAM_KEEPALIVE", c.Stream.KeepAlive, 1, 300); err != nil {
+		return err
+	}
+
+	if err := validateRange("EVENTS_POLL_INTERVAL_MS", c.Events.PollIntervalMS, 10, 60000); err != nil {
+		return err
+	}
+
+	if err := validateRange("EVENTS_BATCH_SIZE", c.Events.BatchSize, 1, 10000); err != nil {
+		return err
+	}
+
+	if err := validateRange("EVENTS_MAX_ATTEMPTS", c.Events.MaxAttempts, 1, 100); err != nil {
+		return err
+	}
+
+	if err := validateRange("EVENTS_RETRY_BACKOFF_MS", c.Events.RetryBackoffMS, 1, 60000); err != nil {
+		return err
+	}
+
+	if err := validateRange("EVENTS_SUBSCRIBER_BUFFER", c.Events.SubscriberBuffer, 1, 65536); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_WORKERS", c.Webhook.Workers, 1, 256); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_QUEUE_SIZE", c.Webhook.QueueSize, 1, 65536); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_MAX_ATTEMPTS", c.Webhook.MaxAttempts, 1, 20); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_BACKOFF_MS", c.Webhook.BackoffMS, 1, 60000); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_MAX_BACKOFF", c.Webhook.MaxBackoff, 1, 3600); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_TIMEOUT", c.Webhook.Timeout, 1, 60); err != nil {
+		return err
+	}
+
+	if err := validateRange("WEBHOOK_DISABLE_AFTER", c.Webhook.DisableAfter, 1, 1000); err != nil {
+		return err
+	}
+
+	if err := validateOneOf("BROKER_DRIVER", c.Broker.Driver, []string{"none", "nats"}); err != nil {
+		return err
+	}
+
+	if err := validateRange("BROKER_PARTITIONS", c.Broker.Partitions, 1, 1024); err != nil {
+		return err
+	}
+
+	if err := validateRange("BROKER_PUBLISH_TIMEOUT", c.Broker.PublishTimeout, 1, 60); err != nil {
+		return err
+	}
+
+	if err := validateRange("BROKER_RETRY_INTERVAL", c.Broker.RetryInterval, 1, 300); err != nil {
+		return err
+	}
+
+	if err := validateRange("BROKER_BUFFER_MAX", c.Broker.BufferMax, 1, 10000000); err != nil {
+		return err
+	}
+
+	if c.GRPC.Enabled {
+		if err := validateRange("GRPC_PORT", c.GRPC.Port, 1, 65535); err != nil {
+			return err
+		}
+
+		if c.GRPC.Port == c.Server.Port {
+			return &ConfigError{
+				Field:   "GRPC_PORT",
+				Message: fmt.Sprintf("must differ from SERVER_PORT (%d)", c.Server.Port),
+			}
+		}
+	}
+
+	if err := validateRange("BET_BATCH_MAX_SIZE", c.Batch.MaxBets, 1, 1000); err != nil {
+		return err
+	}
+
+	if err := validateRange("BET_IMPORT_BATCH_MAX_SIZE", c.Batch.MaxImport, 1, 2000); err != nil {
+		return err
+	}
+
+	if !domain.ValidCurrency(c.Bets.DefaultCurrency) {
+		return &ConfigError{
+			Field:   "BET_DEFAULT_CURRENCY",
+			Message: fmt.Sprintf("must be a three-letter upper-case currency code, got %q", c.Bets.DefaultCurrency),
+		}
+	}
+
+	if err := validateRange("EXPORT_TIMEOUT", c.Export.Timeout, 1, 3600); err != nil {
+		return err
+	}
+
+	if err := validateRange("EXPORT_PARQUET_ROW_GROUP_SIZE", c.Export.RowGroupSize, 100, 1000000); err != nil {
+		return err
+	}
+
+	if err := validateRange("SNAPSHOT_INTERVAL", c.Snapshot.Interval, 0, 86400); err != nil {
+		return err
+	}
+
+	if err := validateRange("SNAPSHOT_RETAIN", c.Snapshot.Retain, 1, 1000); err != nil {
+		return err
+	}
+
+	if err := validateOneOf("LEADERBOARD_USER_MASK", c.Leaderboard.UserMask, []string{"none", "partial", "hash"}); err != nil {
+		return err
+	}
+
+	if err := validateRange("LIMITS_INCREASE_DELAY", c.Limits.IncreaseDelay, 0, 720); err != nil {
+		return err
+	}
+
+	if err := validateOneOf("RISK_MODE", c.Risk.Mode, []string{"reject", "cap"}); err != nil {
+		return err
+	}
+
+	if c.Risk.RoundLiability < 0 {
+		return &ConfigError{
+			Field:   "RISK_ROUND_LIABILITY",
+			Message: fmt.Sprintf("must not be negative, got: %g", c.Risk.RoundLiability),
+		}
+	}
+
+	if c.Risk.UserLiability < 0 {
+		return &ConfigError{
+			Field:   "RISK_USER_LIABILITY",
+			Message: fmt.Sprintf("must not be negative, got: %g", c.Risk.UserLiability),
+		}
+	}
+
+	if c.Risk.ThrottleRatio <= 0 || c.Risk.ThrottleRatio > 1 {
+		return &ConfigError{
+			Field:   "RISK_THROTTLE_RATIO",
+			Message: fmt.Sprintf("must be greater than 0 and at most 1, got: %g", c.Risk.ThrottleRatio),
+		}
+	}
+
+	if c.Risk.ThrottledCrashPoint <= 1 || c.Risk.ThrottledCrashPoint > 100 {
+		return &ConfigError{
+			Field:   "RISK_THROTTLED_CRASH_POINT",
+			Message: fmt.Sprintf("must be greater than 1 and at most 100, got: %g", c.Risk.ThrottledCrashPoint),
+		}
+	}
+
+	if err := validateRange("FRAUD_RELOAD_INTERVAL", c.Fraud.ReloadInterval, 0, 3600); err != nil {
+		return err
+	}
+
+	if !c.API.UnversionedSunset.After(c.API.UnversionedDeprecation) {
+		return &ConfigError{
+			Field:   "API_UNVERSIONED_SUNSET",
+			Message: fmt.Sprintf("must be after API_UNVERSIONED_DEPRECATION (%s)", c.API.UnversionedDeprecation.Format(time.DateOnly)),
+		}
+	}
+
+	for key, apiKey := range c.Auth.APIKeys {
+		if len(key) < 16 {
+			return &ConfigError{
+				Field:   "AUTH_API_KEYS",
+				Message: "api keys must be at least 16 characters",
+			}
+		}
+		for _, scope := range apiKey.Scopes {
+			if err := validateOneOf("AUTH_API_KEYS", scope, []string{"bets:read", "bets:write", "webhooks", "admin"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateOneOf(field, value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	return &ConfigError{
+		Field:   field,
+		Message: fmt.Sprintf("must be one of %v, got: %q", allowed, value),
+	}
+}
+
+func validateRange(field string, value, min, max int) error {
+	if value < min {
+		return &ConfigError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at least %d, got: %d", min, value),
+		}
+	}
+
+	if value > max {
+		return &ConfigError{
+			Field:   field,
+			Message: fmt.Sprintf("must not exceed %d, got: %d", max, value),
+		}
+	}
+
+	return nil
+}
+
+func getEnvAsInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s as integer: %w", key, err)
+	}
+
+	return intValue, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsDate(key, defaultValue string) (time.Time, error) {
+	return time.Parse(time.DateOnly, getEnv(key, defaultValue))
+}
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func getEnvAsBool(key string, defaultValue bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s as boolean: %w", key, err)
+	}
+
+	return boolValue, nil
+}
+
+func getEnvAsFloat(key string, defaultValue float64) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s as float: %w", key, err)
+	}
+
+	return floatValue, nil
+}
+
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, value := range values {
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+func parseRouteTimeouts(value string) (map[string]int, error) {
+	routes := make(map[string]int)
+	if strings.TrimSpace(value) == "" {
+		return routes, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		pattern, secondsStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in the form 'METHOD /path=seconds'", entry)
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(secondsStr))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timeout for %q: %w", pattern, err)
+		}
+
+		routes[strings.TrimSpace(pattern)] = seconds
+	}
+
+	return routes, nil
+}
+
+func parseAPIKeys(value string) (map[string]APIKeyConfig, error) {
+	keys := make(map[string]APIKeyConfig)
+	if strings.TrimSpace(value) == "" {
+		return keys, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		key, scopesStr, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("entry must be in the form 'key=scope|scope[@binding]'")
+		}
+
+		var apiKey APIKeyConfig
+		scopesStr, binding, bound := strings.Cut(scopesStr, "@")
+		if bound {
+			switch binding = strings.TrimSpace(binding); {
+			case binding == "operator":
+				apiKey.Operator = true
+			case strings.HasPrefix(binding, "operator:"):
+				name := strings.TrimPrefix(binding, "operator:")
+				if !domain.ValidOperatorName(name) {
+					return nil, fmt.Errorf("key %s is bound to an invalid operator name, want %s", maskKey(key), domain.OperatorNamePattern)
+				}
+				apiKey.Operator, apiKey.OperatorName = true, name
+			case strings.HasPrefix(binding, "user:"):
+				userID, err := strconv.ParseInt(strings.TrimPrefix(binding, "user:"), 10, 64)
+				if err != nil || userID <= 0 {
+					return nil, fmt.Errorf("key %s is bound to an invalid user ID", maskKey(key))
+				}
+				apiKey.UserID = userID
+			default:
+				return nil, fmt.Errorf("key %s has binding %q, want 'user:<id>', 'operator' or 'operator:<name>'", maskKey(key), binding)
+			}
+		}
+
+		for _, scope := range strings.Split(scopesStr, "|") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				apiKey.Scopes = append(apiKey.Scopes, scope)
+			}
+		}
+		if len(apiKey.Scopes) == 0 {
+			return nil, fmt.Errorf("key %s has no scopes", maskKey(key))
+		}
+
+		keys[key] = apiKey
+	}
+
+	return keys, nil
+}
+
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return key[:4] + "****"
 }
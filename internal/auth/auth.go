@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"bet/internal/domain"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeBetsRead  Scope = "bets:read"
+	ScopeBetsWrite Scope = "bets:write"
+	ScopeWebhooks  Scope = "webhooks"
+	ScopeAdmin     Scope = "admin"
+)
+
+var KnownScopes = []Scope{ScopeBetsRead, ScopeBetsWrite, ScopeWebhooks, ScopeAdmin}
+
+var (
+	ErrUnauthenticated  = errors.New("missing or invalid credentials")
+	ErrPermissionDenied = errors.New("insufficient scope")
+	ErrNotConfigured    = errors.New("authentication is not configured")
+)
+
+type Principal struct {
+	KeyID        string
+	Scopes       []Scope
+	UserID       int64
+	Operator     bool
+	OperatorName string
+}
+
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type Authenticator interface {
+	Enabled() bool
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+type KeySource string
+
+const (
+	KeySourceConfig KeySource = "config"
+	KeySourceAPI    KeySource = "api"
+
+	keySecretPrefix = "bk_"
+	keySecretBytes  = 24
+)
+
+type Grant struct {
+	Scopes       []Scope
+	UserID       int64
+	Operator     bool
+	OperatorName string
+}
+
+type APIKey struct {
+	ID           string
+	Label        string
+	Scopes       []Scope
+	UserID       int64
+	Operator     bool
+	OperatorName string
+	Source       KeySource
+	CreatedAt    time.Time
+}
+
+type KeyStore struct {
+	mu      sync.RWMutex
+	keys    map[[sha256.Size]byte]APIKey
+	enabled bool
+}
+
+func NewKeyStore(keys map[string]Grant) *KeyStore {
+	s := &KeyStore{
+		keys:    make(map[[sha256.Size]byte]APIKey, len(keys)),
+		enabled: len(keys) > 0,
+	}
+	now := time.Now()
+	for key, grant := range keys {
+		s.keys[sha256.Sum256([]byte(key))] = APIKey{
+			ID:           KeyID(key),
+			Scopes:       grant.Scopes,
+			UserID:       grant.UserID,
+			Operator:     grant.Operator,
+			OperatorName: grant.OperatorName,
+			Source:       KeySourceConfig,
+			CreatedAt:    now,
+		}
+	}
+	return s
+}
+
+func (s *KeyStore) Enabled() bool {
+	return s.enabled
+}
+
+func (s *KeyStore) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	s.mu.RLock()
+	key, ok := s.keys[sha256.Sum256([]byte(token))]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return &Principal{KeyID: key.ID, Scopes: key.Scopes, UserID: key.UserID, Operator: key.Operator, OperatorName: key.OperatorName}, nil
+}
+
+func (s *KeyStore) List() []APIKey {
+	s.mu.RLock()
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
+
+func (s *KeyStore) Create(label string, grant Grant) (APIKey, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		raw := make([]byte, keySecretBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return APIKey{}, "", err
+		}
+		secret := keySecretPrefix + hex.EncodeToString(raw)
+
+		id := KeyID(secret)
+		if _, taken := s.findLocked(id); taken {
+			continue
+		}
+
+		key := APIKey{
+			ID:           id,
+			Label:        label,
+			Scopes:       grant.Scopes,
+			UserID:       grant.UserID,
+			Operator:     grant.Operator,
+			OperatorName: grant.OperatorName,
+			Source:       KeySourceAPI,
+			CreatedAt:    time.Now(),
+		}
+		s.keys[sha256.Sum256([]byte(secret))] = key
+		return key, secret, nil
+	}
+}
+
+func (s *KeyStore) Revoke(id string) (APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sum, ok := s.findLocked(id)
+	if !ok {
+		return APIKey{}, domain.ErrAPIKeyNotFound
+	}
+
+	key := s.keys[sum]
+	if key.Source == KeySourceConfig {
+		return APIKey{}, domain.ErrAPIKeyReadOnly
+	}
+
+	delete(s.keys, sum)
+	return key, nil
+}
+
+func (s *KeyStore) findLocked(id string) ([sha256.Size]byte, bool) {
+	for sum, key := range s.keys {
+		if key.ID == id {
+			return sum, true
+		}
+	}
+	return [sha256.Size]byte{}, false
+}
+
+func KeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+func BearerToken(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+type principalKey struct{}
+
+type openAccessKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+func WithOpenAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, openAccessKey{}, true)
+}
+
+func OpenAccess(ctx context.Context) bool {
+	open, _ := ctx.Value(openAccessKey{}).(bool)
+	return open
+}
+
+func ActorFrom(ctx context.Context, requestID string) domain.Actor {
+	actor := domain.Actor{
+		ID:        "anonymous",
+		Operator:  OpenAccess(ctx),
+		RequestID: requestID,
+	}
+	if principal, ok := PrincipalFrom(ctx); ok {
+		actor.ID = "key:" + principal.KeyID
+		actor.Admin = principal.HasScope(ScopeAdmin)
+		actor.Operator = principal.Operator
+		actor.OperatorName = principal.OperatorName
+		actor.UserID = principal.UserID
+	}
+	return actor
+}
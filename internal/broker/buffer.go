@@ -0,0 +1,183 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	bufferFileExt = ".msg"
+	deadLetterDir = "dead-letter"
+)
+
+var ErrBufferFull = errors.New("broker disk buffer full")
+
+type DiskBuffer struct {
+	dir        string
+	maxEntries int
+
+	mu      sync.Mutex
+	entries []uint64
+	nextSeq uint64
+}
+
+func NewDiskBuffer(dir string, maxEntries int) (*DiskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create buffer dir: %w", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffer dir: %w", err)
+	}
+
+	b := &DiskBuffer{
+		dir:        dir,
+		maxEntries: maxEntries,
+		nextSeq:    1,
+	}
+
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, bufferFileExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, bufferFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		b.entries = append(b.entries, seq)
+		if seq >= b.nextSeq {
+			b.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(b.entries, func(i, j int) bool { return b.entries[i] < b.entries[j] })
+
+	return b, nil
+}
+
+func (b *DiskBuffer) Append(msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode buffered message: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxEntries > 0 && len(b.entries) >= b.maxEntries {
+		return ErrBufferFull
+	}
+
+	seq := b.nextSeq
+	tmp := filepath.Join(b.dir, fmt.Sprintf("%020d.tmp", seq))
+	if err := writeFileSync(tmp, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, b.path(seq)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to commit buffered message: %w", err)
+	}
+
+	b.nextSeq++
+	b.entries = append(b.entries, seq)
+	return nil
+}
+
+func (b *DiskBuffer) Peek() (Message, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) == 0 {
+		return Message{}, false, nil
+	}
+
+	data, err := os.ReadFile(b.path(b.entries[0]))
+	if err != nil {
+		return Message{}, false, fmt.Errorf("failed to read buffered message: %w", err)
+	}
+
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return Message{}, false, fmt.Errorf("failed to decode buffered message: %w", err)
+	}
+
+	return msg, true, nil
+}
+
+func (b *DiskBuffer) Ack() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) == 0 {
+		return nil
+	}
+
+	if err := os.Remove(b.path(b.entries[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove buffered message: %w", err)
+	}
+
+	b.entries = b.entries[1:]
+	return nil
+}
+
+func (b *DiskBuffer) DeadLetter() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.entries) == 0 {
+		return "", nil
+	}
+
+	dir := filepath.Join(b.dir, deadLetterDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create dead-letter dir: %w", err)
+	}
+
+	src := b.path(b.entries[0])
+	dst := filepath.Join(dir, filepath.Base(src))
+	if err := os.Rename(src, dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to dead-letter buffered message: %w", err)
+	}
+
+	b.entries = b.entries[1:]
+	return dst, nil
+}
+
+func (b *DiskBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.entries)
+}
+
+func (b *DiskBuffer) path(seq uint64) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%020d%s", seq, bufferFileExt))
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create buffer file: %w", err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write buffer file: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to sync buffer file: %w", err)
+	}
+
+	return f.Close()
+}
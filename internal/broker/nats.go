@@ -0,0 +1,120 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+type NATSConfig struct {
+	URL           string
+	Stream        string
+	SubjectPrefix string
+	Logger        *zap.Logger
+}
+
+type NATSPublisher struct {
+	config NATSConfig
+	conn   *nats.Conn
+	js     nats.JetStreamContext
+	logger *zap.Logger
+
+	streamMu    sync.Mutex
+	streamReady bool
+}
+
+func NewNATSPublisher(config NATSConfig) (*NATSPublisher, error) {
+	if config.Stream == "" {
+		config.Stream = "BETS"
+	}
+	if config.SubjectPrefix == "" {
+		config.SubjectPrefix = "bets.events"
+	}
+
+	logger := config.Logger
+
+	conn, err := nats.Connect(config.URL,
+		nats.Name("bet-api"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(2*time.Second),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			logger.Warn("broker connection lost", zap.Error(err))
+		}),
+		nats.ReconnectHandler(func(c *nats.Conn) {
+			logger.Info("broker connection restored", zap.String("url", c.ConnectedUrl()))
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	return &NATSPublisher{
+		config: config,
+		conn:   conn,
+		js:     js,
+		logger: logger,
+	}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	if err := p.ensureStream(ctx); err != nil {
+		return err
+	}
+
+	natsMsg := nats.NewMsg(msg.Subject)
+	natsMsg.Data = msg.Data
+	for k, v := range msg.Headers {
+		natsMsg.Header.Set(k, v)
+	}
+
+	ack, err := p.js.PublishMsg(natsMsg, nats.Context(ctx), nats.MsgId(msg.ID))
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", msg.Subject, err)
+	}
+	if ack.Stream != p.config.Stream {
+		return fmt.Errorf("unexpected ack from stream %q", ack.Stream)
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) ensureStream(ctx context.Context) error {
+	p.streamMu.Lock()
+	defer p.streamMu.Unlock()
+
+	if p.streamReady {
+		return nil
+	}
+
+	_, err := p.js.StreamInfo(p.config.Stream, nats.Context(ctx))
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = p.js.AddStream(&nats.StreamConfig{
+			Name:       p.config.Stream,
+			Subjects:   []string{p.config.SubjectPrefix + ".>"},
+			Storage:    nats.FileStorage,
+			Duplicates: 10 * time.Minute,
+		}, nats.Context(ctx))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ensure stream %s: %w", p.config.Stream, err)
+	}
+
+	p.streamReady = true
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,34 @@
+package broker
+
+import (
+	"context"
+	"hash/fnv"
+)
+
+const (
+	HeaderSchemaVersion = "Schema-Version"
+	HeaderEventType     = "Event-Type"
+	HeaderPartitionKey  = "Partition-Key"
+)
+
+type Message struct {
+	ID      string            `json:"id"`
+	Subject string            `json:"subject"`
+	Key     string            `json:"key"`
+	Headers map[string]string `json:"headers"`
+	Data    []byte            `json:"data"`
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+func Partition(key string, partitions int) int {
+	if partitions <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(partitions))
+}
@@ -0,0 +1,218 @@
+package broker
+
+import (
+	"bet/internal/events"
+	"bet/internal/metrics"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var SupportedEvents = []events.Type{
+	events.TypeBetPlaced,
+	events.TypeBetSettled,
+	events.TypeBetCancelled,
+	events.TypeBetVoided,
+}
+
+type RelayConfig struct {
+	SubjectPrefix  string
+	Partitions     int
+	PublishTimeout time.Duration
+	RetryInterval  time.Duration
+	Logger         *zap.Logger
+}
+
+type Relay struct {
+	config    RelayConfig
+	publisher EventPublisher
+	buffer    *DiskBuffer
+	logger    *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewRelay(config RelayConfig, publisher EventPublisher, buffer *DiskBuffer) *Relay {
+	if config.SubjectPrefix == "" {
+		config.SubjectPrefix = "bets.events"
+	}
+	if config.Partitions <= 0 {
+		config.Partitions = 16
+	}
+	if config.PublishTimeout <= 0 {
+		config.PublishTimeout = 5 * time.Second
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = 2 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Relay{
+		config:    config,
+		publisher: publisher,
+		buffer:    buffer,
+		logger:    config.Logger,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func (r *Relay) Start() {
+	metrics.BrokerBuffered.Set(int64(r.buffer.Len()))
+
+	r.wg.Add(1)
+	go r.drainLoop()
+}
+
+func (r *Relay) Shutdown(ctx context.Context) error {
+	r.logger.Info("shutting down broker relay...")
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		r.logger.Warn("broker relay shutdown timeout")
+		return ctx.Err()
+	}
+
+	if err := r.publisher.Close(); err != nil {
+		return err
+	}
+
+	r.logger.Info("broker relay shutdown complete", zap.Int("buffered", r.buffer.Len()))
+	return nil
+}
+
+func (r *Relay) HandleEvent(ctx context.Context, record events.OutboxRecord) error {
+	msg, ok, err := r.message(record)
+	if err != nil || !ok {
+		return err
+	}
+
+	if r.buffer.Len() == 0 {
+		err := r.publish(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		r.logger.Warn("broker publish failed, buffering to disk",
+			zap.String("event_id", msg.ID),
+			zap.String("subject", msg.Subject),
+			zap.Error(err),
+		)
+	}
+
+	if err := r.buffer.Append(msg); err != nil {
+		return fmt.Errorf("failed to buffer broker message: %w", err)
+	}
+	metrics.BrokerBuffered.Add(1)
+
+	return nil
+}
+
+func (r *Relay) message(record events.OutboxRecord) (Message, bool, error) {
+	event, ok := betEventFromRecord(record)
+	if !ok {
+		return Message{}, false, nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, false, fmt.Errorf("failed to encode broker event: %w", err)
+	}
+
+	partition := Partition(event.PartitionKey, r.config.Partitions)
+
+	return Message{
+		ID:      event.EventID,
+		Subject: fmt.Sprintf("%s.%d", r.config.SubjectPrefix, partition),
+		Key:     event.PartitionKey,
+		Headers: map[string]string{
+			HeaderSchemaVersion: strconv.Itoa(SchemaVersion),
+			HeaderEventType:     event.EventType,
+			HeaderPartitionKey:  event.PartitionKey,
+		},
+		Data: data,
+	}, true, nil
+}
+
+func (r *Relay) publish(ctx context.Context, msg Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	defer cancel()
+
+	if err := r.publisher.Publish(ctx, msg); err != nil {
+		metrics.BrokerPublishFailures.Add(1)
+		return err
+	}
+
+	metrics.BrokerPublished.Add(1)
+	return nil
+}
+
+func (r *Relay) drainLoop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.config.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain()
+		}
+	}
+}
+
+func (r *Relay) drain() {
+	drained := 0
+	for r.ctx.Err() == nil {
+		msg, ok, err := r.buffer.Peek()
+		if err != nil {
+			path, dlErr := r.buffer.DeadLetter()
+			if dlErr != nil {
+				r.logger.Error("failed to dead-letter unreadable broker buffer entry", zap.NamedError("read_error", err), zap.Error(dlErr))
+				return
+			}
+			metrics.BrokerBuffered.Add(-1)
+			metrics.BrokerDeadLettered.Add(1)
+			r.logger.Error("moved unreadable broker buffer entry to the dead-letter directory", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if !ok {
+			break
+		}
+
+		if err := r.publish(r.ctx, msg); err != nil {
+			if r.ctx.Err() == nil {
+				r.logger.Debug("broker still unavailable", zap.Int("buffered", r.buffer.Len()), zap.Error(err))
+			}
+			break
+		}
+
+		if err := r.buffer.Ack(); err != nil {
+			r.logger.Error("failed to ack broker buffer", zap.Error(err))
+			return
+		}
+		metrics.BrokerBuffered.Add(-1)
+		drained++
+	}
+
+	if drained > 0 {
+		r.logger.Info("drained broker buffer", zap.Int("published", drained), zap.Int("remaining", r.buffer.Len()))
+	}
+}
@@ -0,0 +1,220 @@
+package broker
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var errBrokerDown = errors.New("broker unavailable")
+
+type fakeBroker struct {
+	mu        sync.Mutex
+	down      bool
+	block     chan struct{}
+	blocked   chan struct{}
+	published []Message
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, msg Message) error {
+	b.mu.Lock()
+	block, blocked := b.block, b.blocked
+	b.mu.Unlock()
+
+	if block != nil {
+		close(blocked)
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.down {
+		return errBrokerDown
+	}
+	b.published = append(b.published, msg)
+	return nil
+}
+
+func (b *fakeBroker) Close() error { return nil }
+
+func (b *fakeBroker) setDown(down bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.down = down
+}
+
+func (b *fakeBroker) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ids := make([]string, len(b.published))
+	for i, msg := range b.published {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+func newTestRelay(t *testing.T, publisher EventPublisher) (*Relay, *DiskBuffer, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	buffer, err := NewDiskBuffer(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relay := NewRelay(RelayConfig{PublishTimeout: time.Second, Logger: zap.NewNop()}, publisher, buffer)
+	t.Cleanup(func() { relay.cancel() })
+	return relay, buffer, dir
+}
+
+func placed(id string) events.OutboxRecord {
+	return events.OutboxRecord{
+		ID:    id,
+		Event: events.BetPlaced{Bet: domain.Bet{ID: "bet-" + id, UserID: 7, Amount: 10, CrashPoint: 2}, At: time.Now()},
+	}
+}
+
+func assertIDs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("published %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRelayBuffersWhileBrokerIsDownAndDrainsInOrder(t *testing.T) {
+	broker := &fakeBroker{}
+	relay, buffer, _ := newTestRelay(t, broker)
+	ctx := context.Background()
+
+	if err := relay.HandleEvent(ctx, placed("a")); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.setDown(true)
+	for _, id := range []string{"b", "c"} {
+		if err := relay.HandleEvent(ctx, placed(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if buffer.Len() != 2 {
+		t.Fatalf("buffered %d messages, want 2", buffer.Len())
+	}
+
+	broker.setDown(false)
+	if err := relay.HandleEvent(ctx, placed("d")); err != nil {
+		t.Fatal(err)
+	}
+	assertIDs(t, broker.ids(), "a")
+
+	relay.drain()
+	assertIDs(t, broker.ids(), "a", "b", "c", "d")
+	if buffer.Len() != 0 {
+		t.Errorf("buffer still holds %d messages", buffer.Len())
+	}
+}
+
+func TestRelayDeadLettersCorruptEntries(t *testing.T) {
+	broker := &fakeBroker{down: true}
+	relay, buffer, dir := newTestRelay(t, broker)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := relay.HandleEvent(ctx, placed(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	corrupt := buffer.path(buffer.entries[1])
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.setDown(false)
+	relay.drain()
+
+	assertIDs(t, broker.ids(), "a", "c")
+	if buffer.Len() != 0 {
+		t.Errorf("buffer still holds %d messages", buffer.Len())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, deadLetterDir, filepath.Base(corrupt)))
+	if err != nil {
+		t.Fatalf("corrupt entry was not dead-lettered: %v", err)
+	}
+	if string(data) != "{not json" {
+		t.Errorf("dead-lettered entry holds %q", data)
+	}
+
+	reopened, err := NewDiskBuffer(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 0 {
+		t.Errorf("reopened buffer holds %d messages, want the dead-letter directory to be skipped", reopened.Len())
+	}
+}
+
+func TestRelayHandleEventDoesNotWaitForDrainPublish(t *testing.T) {
+	broker := &fakeBroker{down: true}
+	relay, buffer, _ := newTestRelay(t, broker)
+	ctx := context.Background()
+
+	if err := relay.HandleEvent(ctx, placed("a")); err != nil {
+		t.Fatal(err)
+	}
+
+	broker.mu.Lock()
+	broker.down = false
+	broker.block = make(chan struct{})
+	broker.blocked = make(chan struct{})
+	broker.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		relay.drain()
+		close(drained)
+	}()
+	<-broker.blocked
+
+	handled := make(chan error, 1)
+	go func() { handled <- relay.HandleEvent(ctx, placed("b")) }()
+
+	select {
+	case err := <-handled:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("HandleEvent waited for the in-flight drain publish")
+	}
+	if buffer.Len() != 2 {
+		t.Errorf("buffered %d messages, want the new event queued behind the one in flight", buffer.Len())
+	}
+
+	broker.mu.Lock()
+	close(broker.block)
+	broker.block = nil
+	broker.mu.Unlock()
+	<-drained
+
+	assertIDs(t, broker.ids(), "a", "b")
+}
@@ -0,0 +1,84 @@
+package broker
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"strconv"
+	"time"
+)
+
+const SchemaVersion = 1
+
+type BetV1 struct {
+	ID         string  `json:"id"`
+	UserID     int64   `json:"user_id"`
+	Amount     float64 `json:"amount"`
+	CrashPoint float64 `json:"crash_point"`
+	Currency   string  `json:"currency,omitempty"`
+	Operator   string  `json:"operator,omitempty"`
+	RoundID    string  `json:"round_id"`
+	Status     string  `json:"status"`
+	Payout     float64 `json:"payout"`
+	CreatedAt  string  `json:"created_at"`
+	SettledAt  string  `json:"settled_at,omitempty"`
+	VoidReason string  `json:"void_reason,omitempty"`
+}
+
+type BetEventV1 struct {
+	SchemaVersion   int     `json:"schema_version"`
+	EventID         string  `json:"event_id"`
+	EventType       string  `json:"event_type"`
+	OccurredAt      string  `json:"occurred_at"`
+	PartitionKey    string  `json:"partition_key"`
+	Bet             BetV1   `json:"bet"`
+	RoundCrashPoint float64 `json:"round_crash_point,omitempty"`
+}
+
+func betEventFromRecord(record events.OutboxRecord) (BetEventV1, bool) {
+	var (
+		bet             domain.Bet
+		roundCrashPoint float64
+	)
+
+	switch e := record.Event.(type) {
+	case events.BetPlaced:
+		bet = e.Bet
+	case events.BetSettled:
+		bet = e.Bet
+		roundCrashPoint = e.RoundCrashPoint
+	case events.BetCancelled:
+		bet = e.Bet
+	case events.BetVoided:
+		bet = e.Bet
+	default:
+		return BetEventV1{}, false
+	}
+
+	event := BetEventV1{
+		SchemaVersion:   SchemaVersion,
+		EventID:         record.ID,
+		EventType:       string(record.Event.EventType()),
+		OccurredAt:      record.Event.OccurredAt().UTC().Format(time.RFC3339Nano),
+		PartitionKey:    strconv.FormatInt(bet.UserID, 10),
+		RoundCrashPoint: roundCrashPoint,
+		Bet: BetV1{
+			ID:         bet.ID,
+			UserID:     bet.UserID,
+			Amount:     bet.Amount,
+			CrashPoint: bet.CrashPoint,
+			Currency:   bet.Currency,
+			Operator:   bet.Operator,
+			RoundID:    bet.RoundID,
+			Status:     string(bet.Status),
+			Payout:     bet.Payout,
+			CreatedAt:  bet.CreatedAt.UTC().Format(time.RFC3339Nano),
+			VoidReason: string(bet.VoidReason),
+		},
+	}
+
+	if bet.SettledAt != nil {
+		event.Bet.SettledAt = bet.SettledAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	return event, true
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"bet/internal/handler"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	retryBaseDelay    = 500 * time.Millisecond
+	maxErrorBodySize  = 1 << 20
+)
+
+type Config struct {
+	BaseURL    string
+	APIKey     string
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+type Client struct {
+	baseURL    string
+	apiKey     string
+	maxRetries int
+	http       *http.Client
+}
+
+func New(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		apiKey:     config.APIKey,
+		maxRetries: config.MaxRetries,
+		http:       &http.Client{Timeout: config.Timeout},
+	}
+}
+
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  []handler.FieldError
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("api error %d %s: %s", e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("api error %d: %s", e.Status, e.Message)
+}
+
+func (e *APIError) Temporary() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+}
+
+func IsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+func (c *Client) ImportBets(ctx context.Context, bets []handler.BetDTO) (handler.ImportBetsResponseDTO, error) {
+	var result handler.ImportBetsResponseDTO
+	err := c.do(ctx, http.MethodPost, "/v1/admin/bets/import", handler.ImportBetsRequest{Bets: bets}, &result)
+	return result, err
+}
+
+type ListBetsParams struct {
+	UserID    *int64
+	MinAmount *float64
+	MaxAmount *float64
+	SortBy    string
+	Order     string
+	Page      int
+	Limit     int
+}
+
+func (p ListBetsParams) query() url.Values {
+	query := url.Values{}
+	if p.UserID != nil {
+		query.Set("user_id", strconv.FormatInt(*p.UserID, 10))
+	}
+	if p.MinAmount != nil {
+		query.Set("min_amount", strconv.FormatFloat(*p.MinAmount, 'f', -1, 64))
+	}
+	if p.MaxAmount != nil {
+		query.Set("max_amount", strconv.FormatFloat(*p.MaxAmount, 'f', -1, 64))
+	}
+	if p.SortBy != "" {
+		query.Set("sort_by", p.SortBy)
+	}
+	if p.Order != "" {
+		query.Set("order", p.Order)
+	}
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+func (c *Client) ListBets(ctx context.Context, params ListBetsParams) (handler.ListBetsResponseDTO, error) {
+	var result handler.ListBetsResponseDTO
+	err := c.do(ctx, http.MethodGet, withQuery("/v1/bets", params.query()), nil, &result)
+	return result, err
+}
+
+func (c *Client) GetBet(ctx context.Context, id string) (handler.BetDTO, error) {
+	var result handler.BetDTO
+	err := c.do(ctx, http.MethodGet, "/v1/bets/"+url.PathEscape(id), nil, &result)
+	return result, err
+}
+
+func (c *Client) VoidBet(ctx context.Context, id, reason, note string) (handler.BetDTO, error) {
+	var result handler.BetDTO
+	err := c.doOnce(ctx, http.MethodPost, "/v1/admin/bets/"+url.PathEscape(id)+"/void", handler.VoidBetRequest{Reason: reason, Note: note}, &result)
+	return result, err
+}
+
+func (c *Client) GetBetAudit(ctx context.Context, id string) (handler.ListAuditEntriesResponseDTO, error) {
+	var result handler.ListAuditEntriesResponseDTO
+	err := c.do(ctx, http.MethodGet, "/v1/admin/bets/"+url.PathEscape(id)+"/audit", nil, &result)
+	return result, err
+}
+
+func (c *Client) ListRounds(ctx context.Context, limit int) (handler.ListRoundsResponseDTO, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var result handler.ListRoundsResponseDTO
+	err := c.do(ctx, http.MethodGet, withQuery("/v1/admin/rounds", query), nil, &result)
+	return result, err
+}
+
+func (c *Client) GetRound(ctx context.Context, id string) (handler.RoundDTO, error) {
+	var result handler.RoundDTO
+	err := c.do(ctx, http.MethodGet, "/v1/admin/rounds/"+url.PathEscape(id), nil, &result)
+	return result, err
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context) (handler.ListAPIKeysResponseDTO, error) {
+	var result handler.ListAPIKeysResponseDTO
+	err := c.do(ctx, http.MethodGet, "/v1/admin/api-keys", nil, &result)
+	return result, err
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, req handler.CreateAPIKeyRequest) (handler.CreateAPIKeyResponseDTO, error) {
+	var result handler.CreateAPIKeyResponseDTO
+	err := c.doOnce(ctx, http.MethodPost, "/v1/admin/api-keys", req, &result)
+	return result, err
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) (handler.APIKeyDTO, error) {
+	var result handler.APIKeyDTO
+	err := c.doOnce(ctx, http.MethodDelete, "/v1/admin/api-keys/"+url.PathEscape(id), nil, &result)
+	return result, err
+}
+
+func (c *Client) ListSnapshots(ctx context.Context) (handler.ListSnapshotsResponseDTO, error) {
+	var result handler.ListSnapshotsResponseDTO
+	err := c.do(ctx, http.MethodGet, "/v1/admin/snapshots", nil, &result)
+	return result, err
+}
+
+func (c *Client) CreateSnapshot(ctx context.Context) (handler.SnapshotDTO, error) {
+	var result handler.SnapshotDTO
+	err := c.doOnce(ctx, http.MethodPost, "/v1/admin/snapshots", nil, &result)
+	return result, err
+}
+
+func (c *Client) CompactSnapshots(ctx context.Context) (handler.CompactSnapshotsResponseDTO, error) {
+	var result handler.CompactSnapshotsResponseDTO
+	err := c.do(ctx, http.MethodPost, "/v1/admin/snapshots/compact", nil, &result)
+	return result, err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.call(ctx, method, path, body, out, c.maxRetries)
+}
+
+func (c *Client) doOnce(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.call(ctx, method, path, body, out, 0)
+}
+
+func (c *Client) call(ctx context.Context, method, path string, body, out interface{}, maxRetries int) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = c.send(ctx, method, path, payload, out)
+		if err == nil || ctx.Err() != nil || attempt >= maxRetries || !retryable(err) {
+			return err
+		}
+
+		delay := retryBaseDelay << attempt
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeAPIError(resp)
+	}
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func decodeAPIError(resp *http.Response) error {
+	apiErr := &APIError{Status: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(raw) == 0 {
+		return apiErr
+	}
+
+	var body handler.ErrorResponse
+	if err := json.Unmarshal(raw, &body); err == nil && body.Error != "" {
+		apiErr.Code = body.Code
+		apiErr.Message = body.Error
+		apiErr.Fields = body.Fields
+		return apiErr
+	}
+
+	apiErr.Message = strings.TrimSpace(string(raw))
+	return apiErr
+}
+
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+func retryable(err error) bool {
+	if apiErr, ok := IsAPIError(err); ok {
+		return apiErr.Temporary()
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionBetCancelled AuditAction = "bet.cancelled"
+	AuditActionBetVoided    AuditAction = "bet.voided"
+)
+
+type Actor struct {
+	ID           string
+	Admin        bool
+	Operator     bool
+	OperatorName string
+	UserID       int64
+	RequestID    string
+}
+
+func (a Actor) ActsFor(userID int64) bool {
+	return a.Admin || a.Operator || (a.UserID != 0 && a.UserID == userID)
+}
+
+type AuditEntry struct {
+	ID         string
+	Action     AuditAction
+	Resource   string
+	ResourceID string
+	Actor      Actor
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	Note       string
+	Refund     float64
+	At         time.Time
+}
+
+func NewBetAuditEntry(action AuditAction, actor Actor, from BetStatus, bet Bet) AuditEntry {
+	entry := AuditEntry{
+		ID:         uuid.New().String(),
+		Action:     action,
+		Resource:   "bet",
+		ResourceID: bet.ID,
+		Actor:      actor,
+		FromStatus: string(from),
+		ToStatus:   string(bet.Status),
+		Reason:     string(bet.VoidReason),
+		Refund:     bet.Payout,
+		At:         time.Now(),
+	}
+	if bet.SettledAt != nil {
+		entry.At = *bet.SettledAt
+	}
+	return entry
+}
@@ -1,16 +1,71 @@
 package domain
 
 import (
+	"regexp"
 	"time"
+
 	"github.com/google/uuid"
 )
 
+type BetStatus string
+
+const (
+	BetStatusPending   BetStatus = "pending"
+	BetStatusWon       BetStatus = "won"
+	BetStatusLost      BetStatus = "lost"
+	BetStatusCancelled BetStatus = "cancelled"
+	BetStatusVoided    BetStatus = "voided"
+)
+
+type VoidReason string
+
+const (
+	VoidReasonDuplicate      VoidReason = "duplicate"
+	VoidReasonTechnicalError VoidReason = "technical_error"
+	VoidReasonSuspectedFraud VoidReason = "suspected_fraud"
+	VoidReasonOperatorError  VoidReason = "operator_error"
+	VoidReasonRegulatory     VoidReason = "regulatory"
+)
+
+var VoidReasons = []VoidReason{
+	VoidReasonDuplicate,
+	VoidReasonTechnicalError,
+	VoidReasonSuspectedFraud,
+	VoidReasonOperatorError,
+	VoidReasonRegulatory,
+}
+
+const (
+	CurrencyPattern     = `^[A-Z]{3}$`
+	OperatorNamePattern = `^[a-z0-9][a-z0-9_-]{0,31}$`
+)
+
+var (
+	currencyCode = regexp.MustCompile(CurrencyPattern)
+	operatorName = regexp.MustCompile(OperatorNamePattern)
+)
+
+func ValidCurrency(code string) bool {
+	return currencyCode.MatchString(code)
+}
+
+func ValidOperatorName(name string) bool {
+	return operatorName.MatchString(name)
+}
+
 type Bet struct {
 	ID         string
 	UserID     int64
 	Amount     float64
 	CrashPoint float64
+	Currency   string
+	Operator   string
+	RoundID    string
+	Status     BetStatus
+	Payout     float64
 	CreatedAt  time.Time
+	SettledAt  *time.Time
+	VoidReason VoidReason
 }
 
 func NewBet(userID int64, amount, crashPoint float64) *Bet {
@@ -19,16 +74,84 @@ func NewBet(userID int64, amount, crashPoint float64) *Bet {
 		UserID:     userID,
 		Amount:     amount,
 		CrashPoint: crashPoint,
+		Status:     BetStatusPending,
 		CreatedAt:  time.Now(),
 	}
 }
 
+type PlaceBetRequest struct {
+	UserID     int64
+	Amount     float64
+	CrashPoint float64
+	Currency   string
+}
+
+type PlaceBetResult struct {
+	Bet *Bet
+	Err error
+}
+
+type VoidBetRequest struct {
+	Reason VoidReason
+	Note   string
+}
+
+type ImportResult struct {
+	Imported   int
+	Duplicates []string
+}
+
+func (b *Bet) PotentialPayout() float64 {
+	return b.Amount * b.CrashPoint
+}
+
+func (b *Bet) Cancel(at time.Time) error {
+	if b.Status != BetStatusPending {
+		return ErrBetNotCancellable
+	}
+
+	b.refund(BetStatusCancelled, at)
+	return nil
+}
+
+func (b *Bet) Void(reason VoidReason, at time.Time) error {
+	if b.Status == BetStatusCancelled || b.Status == BetStatusVoided {
+		return ErrBetNotVoidable
+	}
+
+	b.refund(BetStatusVoided, at)
+	b.VoidReason = reason
+	return nil
+}
+
+func (b *Bet) refund(status BetStatus, at time.Time) {
+	b.Status = status
+	b.Payout = b.Amount
+	b.SettledAt = &at
+}
+
 type BetFilters struct {
 	UserID    *int64
 	MinAmount *float64
 	MaxAmount *float64
 }
 
+func (f BetFilters) Matches(bet Bet) bool {
+	if f.UserID != nil && bet.UserID != *f.UserID {
+		return false
+	}
+
+	if f.MinAmount != nil && bet.Amount < *f.MinAmount {
+		return false
+	}
+
+	if f.MaxAmount != nil && bet.Amount > *f.MaxAmount {
+		return false
+	}
+
+	return true
+}
+
 type PaginationParams struct {
 	Page  int
 	Limit int
@@ -40,9 +163,14 @@ type SortParams struct {
 }
 
 type ListBetsRequest struct {
-	Filters   BetFilters
+	Filters    BetFilters
 	Pagination PaginationParams
-	Sort      SortParams
+	Sort       SortParams
+}
+
+type ExportBetsRequest struct {
+	Filters BetFilters
+	Sort    SortParams
 }
 
 type ListBetsResponse struct {
@@ -51,4 +179,3 @@ type ListBetsResponse struct {
 	Page  int
 	Limit int
 }
-
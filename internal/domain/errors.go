@@ -3,10 +3,18 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
-	ErrBetNotFound = &NotFoundError{Resource: "bet", Message: "bet not found"}
+	ErrBetNotFound       = &NotFoundError{Resource: "bet", Message: "bet not found"}
+	ErrRoundNotFound     = &NotFoundError{Resource: "round", Message: "round not found"}
+	ErrBetNotCancellable = &ConflictError{Reason: "BET_NOT_CANCELLABLE", Message: "bet can only be cancelled while its round is taking bets"}
+	ErrBetNotVoidable    = &ConflictError{Reason: "BET_NOT_VOIDABLE", Message: "bet has already been cancelled or voided"}
+	ErrAPIKeyNotFound    = &NotFoundError{Resource: "api_key", Message: "api key not found"}
+	ErrAPIKeyReadOnly    = &ConflictError{Reason: "API_KEY_READ_ONLY", Message: "api key is configured in AUTH_API_KEYS and cannot be revoked at runtime"}
+	ErrSnapshotsDisabled = &ConflictError{Reason: "SNAPSHOTS_DISABLED", Message: "snapshots are disabled; set SNAPSHOT_DIR to enable them"}
+	ErrNotUsersKey       = &ForbiddenError{Reason: "NOT_USERS_KEY", Message: "api key is not bound to this user"}
 )
 
 type RepositoryError struct {
@@ -51,11 +59,66 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s not found", e.Resource)
 }
 
+func (e *NotFoundError) Code() string {
+	if e.Resource == "" {
+		return "NOT_FOUND"
+	}
+	return strings.ToUpper(e.Resource) + "_NOT_FOUND"
+}
+
 func IsNotFoundError(err error) bool {
 	var notFoundErr *NotFoundError
 	return errors.As(err, &notFoundErr)
 }
 
+type ConflictError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "conflicting resource state"
+}
+
+func (e *ConflictError) Code() string {
+	if e.Reason == "" {
+		return "CONFLICT"
+	}
+	return e.Reason
+}
+
+func IsConflictError(err error) bool {
+	var conflictErr *ConflictError
+	return errors.As(err, &conflictErr)
+}
+
+type ForbiddenError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "forbidden"
+}
+
+func (e *ForbiddenError) Code() string {
+	if e.Reason == "" {
+		return "FORBIDDEN"
+	}
+	return e.Reason
+}
+
+func IsForbiddenError(err error) bool {
+	var forbiddenErr *ForbiddenError
+	return errors.As(err, &forbiddenErr)
+}
+
 type InvalidInputError struct {
 	Message string
 }
@@ -85,3 +148,35 @@ func IsValidationError(err error) bool {
 	var validationErr *ValidationError
 	return errors.As(err, &validationErr)
 }
+
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+func FieldViolations(err error) []*ValidationError {
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return []*ValidationError{validationErr}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultFraudFlagLimit = 50
+	MaxFraudFlagLimit     = 500
+)
+
+var (
+	ErrFraudFlagNotFound  = &NotFoundError{Resource: "fraud_flag", Message: "fraud flag not found"}
+	ErrFraudRulesDisabled = &ConflictError{Reason: "FRAUD_RULES_DISABLED", Message: "fraud rules are disabled; set FRAUD_RULES_FILE to enable them"}
+	ErrBetDeclined        = &FraudError{Reason: "BET_DECLINED", Message: "bet was declined by risk checks"}
+	ErrAccountOnHold      = &FraudError{Reason: "ACCOUNT_ON_HOLD", Message: "betting is on hold pending review"}
+)
+
+type FraudAction string
+
+const (
+	ActionAllow  FraudAction = "allow"
+	ActionFlag   FraudAction = "flag"
+	ActionHold   FraudAction = "hold"
+	ActionReject FraudAction = "reject"
+)
+
+var FraudActions = []FraudAction{
+	ActionAllow,
+	ActionFlag,
+	ActionHold,
+	ActionReject,
+}
+
+func (a FraudAction) Severity() int {
+	switch a {
+	case ActionFlag:
+		return 1
+	case ActionHold:
+		return 2
+	case ActionReject:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (a FraudAction) Valid() bool {
+	for _, action := range FraudActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+type FlagStatus string
+
+const (
+	FlagOpen      FlagStatus = "open"
+	FlagConfirmed FlagStatus = "confirmed"
+	FlagDismissed FlagStatus = "dismissed"
+)
+
+var FlagStatuses = []FlagStatus{
+	FlagOpen,
+	FlagConfirmed,
+	FlagDismissed,
+}
+
+type Client struct {
+	IP       string
+	DeviceID string
+}
+
+type BetAttempt struct {
+	UserID     int64
+	Amount     float64
+	CrashPoint float64
+	Client     Client
+	At         time.Time
+}
+
+type RuleHit struct {
+	Rule   string
+	Type   string
+	Score  float64
+	Action FraudAction
+	Reason string
+}
+
+type FraudAssessment struct {
+	Attempt BetAttempt
+	Score   float64
+	Action  FraudAction
+	Hits    []RuleHit
+}
+
+type FraudFlag struct {
+	ID         string
+	UserID     int64
+	BetID      string
+	Amount     float64
+	CrashPoint float64
+	Client     Client
+	Score      float64
+	Action     FraudAction
+	Hits       []RuleHit
+	Status     FlagStatus
+	CreatedAt  time.Time
+	ReviewedAt *time.Time
+	ReviewedBy string
+	Note       string
+}
+
+func NewFraudFlag(assessment FraudAssessment, betID string) FraudFlag {
+	return FraudFlag{
+		ID:         uuid.New().String(),
+		UserID:     assessment.Attempt.UserID,
+		BetID:      betID,
+		Amount:     assessment.Attempt.Amount,
+		CrashPoint: assessment.Attempt.CrashPoint,
+		Client:     assessment.Attempt.Client,
+		Score:      assessment.Score,
+		Action:     assessment.Action,
+		Hits:       assessment.Hits,
+		Status:     FlagOpen,
+		CreatedAt:  assessment.Attempt.At,
+	}
+}
+
+func (f FraudFlag) Holds() bool {
+	return f.Action == ActionHold && f.Status != FlagDismissed
+}
+
+type FraudFlagFilter struct {
+	Status FlagStatus
+	UserID *int64
+	Limit  int
+}
+
+type FlagReview struct {
+	Status FlagStatus
+	Note   string
+	Actor  Actor
+}
+
+type FraudRule struct {
+	Name      string
+	Type      string
+	Score     float64
+	Action    FraudAction
+	Window    time.Duration
+	MaxBets   int
+	MaxUsers  int
+	MinUsers  int
+	Samples   int
+	MaxJitter float64
+	Tolerance float64
+}
+
+type FraudThresholds struct {
+	Flag   float64
+	Hold   float64
+	Reject float64
+}
+
+type FraudRuleSet struct {
+	Source     string
+	Checksum   string
+	LoadedAt   time.Time
+	Thresholds FraudThresholds
+	Rules      []FraudRule
+}
+
+type FraudError struct {
+	Reason  string
+	Message string
+}
+
+func (e *FraudError) Error() string {
+	return e.Message
+}
+
+func (e *FraudError) Code() string {
+	return e.Reason
+}
+
+func IsFraudError(err error) bool {
+	var fraudErr *FraudError
+	return errors.As(err, &fraudErr)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+const (
+	DefaultLeaderboardLimit = 10
+	MaxLeaderboardLimit     = 100
+)
+
+type LeaderboardType string
+
+const (
+	LeaderboardWins        LeaderboardType = "wins"
+	LeaderboardMultipliers LeaderboardType = "multipliers"
+)
+
+var LeaderboardTypes = []LeaderboardType{
+	LeaderboardWins,
+	LeaderboardMultipliers,
+}
+
+func (t LeaderboardType) Score(bet Bet) float64 {
+	if t == LeaderboardMultipliers {
+		return bet.CrashPoint
+	}
+	return bet.Payout
+}
+
+type LeaderboardWindow string
+
+const (
+	LeaderboardDay  LeaderboardWindow = "day"
+	LeaderboardWeek LeaderboardWindow = "week"
+	LeaderboardAll  LeaderboardWindow = "all"
+)
+
+var LeaderboardWindows = []LeaderboardWindow{
+	LeaderboardDay,
+	LeaderboardWeek,
+	LeaderboardAll,
+}
+
+func (w LeaderboardWindow) Span() time.Duration {
+	switch w {
+	case LeaderboardDay:
+		return 24 * time.Hour
+	case LeaderboardWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+type LeaderboardRequest struct {
+	Type       LeaderboardType
+	Window     LeaderboardWindow
+	Limit      int
+	AroundUser int64
+}
+
+type LeaderboardEntry struct {
+	Rank       int
+	User       string
+	Self       bool
+	Amount     float64
+	Payout     float64
+	Multiplier float64
+	SettledAt  time.Time
+}
+
+type Leaderboard struct {
+	LeaderboardRequest
+	Entries []LeaderboardEntry
+}
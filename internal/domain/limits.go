@@ -0,0 +1,335 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const MaxCoolOffDays = 42
+
+type LimitType string
+
+const (
+	LimitDeposit LimitType = "deposit"
+	LimitLoss    LimitType = "loss"
+	LimitWager   LimitType = "wager"
+)
+
+var LimitTypes = []LimitType{
+	LimitDeposit,
+	LimitLoss,
+	LimitWager,
+}
+
+type LimitPeriod string
+
+const (
+	LimitDay   LimitPeriod = "day"
+	LimitWeek  LimitPeriod = "week"
+	LimitMonth LimitPeriod = "month"
+)
+
+var LimitPeriods = []LimitPeriod{
+	LimitDay,
+	LimitWeek,
+	LimitMonth,
+}
+
+func (p LimitPeriod) Start(t time.Time) time.Time {
+	day := StatsDay(t)
+	switch p {
+	case LimitWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case LimitMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func (p LimitPeriod) End(t time.Time) time.Time {
+	start := p.Start(t)
+	switch p {
+	case LimitWeek:
+		return start.AddDate(0, 0, 7)
+	case LimitMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+func (p LimitPeriod) Adjective() string {
+	switch p {
+	case LimitWeek:
+		return "weekly"
+	case LimitMonth:
+		return "monthly"
+	default:
+		return "daily"
+	}
+}
+
+type ExclusionDuration string
+
+const (
+	Exclusion6Months   ExclusionDuration = "6_months"
+	Exclusion1Year     ExclusionDuration = "1_year"
+	Exclusion5Years    ExclusionDuration = "5_years"
+	ExclusionPermanent ExclusionDuration = "permanent"
+)
+
+var ExclusionDurations = []ExclusionDuration{
+	Exclusion6Months,
+	Exclusion1Year,
+	Exclusion5Years,
+	ExclusionPermanent,
+}
+
+func (d ExclusionDuration) Until(from time.Time) *time.Time {
+	var until time.Time
+	switch d {
+	case Exclusion6Months:
+		until = from.AddDate(0, 6, 0)
+	case Exclusion1Year:
+		until = from.AddDate(1, 0, 0)
+	case Exclusion5Years:
+		until = from.AddDate(5, 0, 0)
+	default:
+		return nil
+	}
+	return &until
+}
+
+type PendingLimit struct {
+	Amount      *float64
+	EffectiveAt time.Time
+}
+
+type Limit struct {
+	Type    LimitType
+	Period  LimitPeriod
+	Amount  *float64
+	Pending *PendingLimit
+}
+
+type Restriction struct {
+	Since time.Time
+	Until *time.Time
+}
+
+func (r *Restriction) ActiveAt(t time.Time) bool {
+	return r != nil && (r.Until == nil || t.Before(*r.Until))
+}
+
+func (r *Restriction) Extend(from time.Time, until *time.Time) *Restriction {
+	if !r.ActiveAt(from) {
+		return &Restriction{Since: from, Until: until}
+	}
+	if r.Until != nil && (until == nil || until.After(*r.Until)) {
+		return &Restriction{Since: r.Since, Until: until}
+	}
+	return r
+}
+
+type Deposit struct {
+	Amount float64
+	At     time.Time
+}
+
+type UserLimits struct {
+	UserID        int64
+	Limits        []Limit
+	CoolOff       *Restriction
+	SelfExclusion *Restriction
+	Deposits      []Deposit
+	UpdatedAt     time.Time
+}
+
+func (u *UserLimits) Apply(now time.Time) {
+	limits := u.Limits[:0]
+	for _, limit := range u.Limits {
+		if limit.Pending != nil && !now.Before(limit.Pending.EffectiveAt) {
+			limit.Amount, limit.Pending = limit.Pending.Amount, nil
+		}
+		if limit.Amount != nil || limit.Pending != nil {
+			limits = append(limits, limit)
+		}
+	}
+	u.Limits = limits
+
+	if u.CoolOff != nil && !u.CoolOff.ActiveAt(now) {
+		u.CoolOff = nil
+	}
+	if u.SelfExclusion != nil && !u.SelfExclusion.ActiveAt(now) {
+		u.SelfExclusion = nil
+	}
+
+	since := LimitWeek.Start(now)
+	if month := LimitMonth.Start(now); month.Before(since) {
+		since = month
+	}
+	deposits := u.Deposits[:0]
+	for _, deposit := range u.Deposits {
+		if !deposit.At.Before(since) {
+			deposits = append(deposits, deposit)
+		}
+	}
+	u.Deposits = deposits
+}
+
+func (u UserLimits) Deposited(from time.Time) float64 {
+	total := 0.0
+	for _, deposit := range u.Deposits {
+		if !deposit.At.Before(from) {
+			total += deposit.Amount
+		}
+	}
+	return total
+}
+
+func (u *UserLimits) Set(limitType LimitType, period LimitPeriod, amount *float64, now time.Time, increaseDelay time.Duration) {
+	u.Apply(now)
+	u.UpdatedAt = now
+
+	index := -1
+	for i, limit := range u.Limits {
+		if limit.Type == limitType && limit.Period == period {
+			index = i
+		}
+	}
+
+	switch {
+	case amount != nil && (index == -1 || *amount <= *u.Limits[index].Amount):
+		if index == -1 {
+			u.Limits = append(u.Limits, Limit{Type: limitType, Period: period})
+			index = len(u.Limits) - 1
+		}
+		u.Limits[index].Amount, u.Limits[index].Pending = amount, nil
+	case index != -1:
+		u.Limits[index].Pending = &PendingLimit{Amount: amount, EffectiveAt: now.Add(increaseDelay)}
+	}
+
+	u.Apply(now)
+	sort.Slice(u.Limits, func(i, j int) bool {
+		return limitOrder(u.Limits[i]) < limitOrder(u.Limits[j])
+	})
+}
+
+func (u UserLimits) Active(limitType LimitType, period LimitPeriod) (float64, bool) {
+	for _, limit := range u.Limits {
+		if limit.Type == limitType && limit.Period == period && limit.Amount != nil {
+			return *limit.Amount, true
+		}
+	}
+	return 0, false
+}
+
+func limitOrder(limit Limit) int {
+	order := 0
+	for i, t := range LimitTypes {
+		if t == limit.Type {
+			order = i * len(LimitPeriods)
+		}
+	}
+	for i, p := range LimitPeriods {
+		if p == limit.Period {
+			order += i
+		}
+	}
+	return order
+}
+
+type LimitUsage struct {
+	Limit
+	Tracked   bool
+	Used      float64
+	Remaining float64
+	ResetsAt  time.Time
+}
+
+type LimitsStatus struct {
+	UserID        int64
+	Limits        []LimitUsage
+	CoolOff       *Restriction
+	SelfExclusion *Restriction
+}
+
+func (s UserStats) LimitUsed(limitType LimitType) (float64, bool) {
+	switch limitType {
+	case LimitWager:
+		return s.Wagered + s.PendingWagered, true
+	case LimitLoss:
+		return max(0, s.Wagered+s.PendingWagered-s.PaidOut), true
+	default:
+		return 0, false
+	}
+}
+
+type LimitError struct {
+	Reason    string
+	Message   string
+	Type      LimitType
+	Period    LimitPeriod
+	Limit     float64
+	Remaining float64
+	Until     *time.Time
+}
+
+func (e *LimitError) Error() string {
+	return e.Message
+}
+
+func (e *LimitError) Code() string {
+	return e.Reason
+}
+
+func IsLimitError(err error) bool {
+	var limitErr *LimitError
+	return errors.As(err, &limitErr)
+}
+
+func NewLimitExceededError(limitType LimitType, period LimitPeriod, limit, remaining float64, resetsAt time.Time) *LimitError {
+	subject := "bet"
+	if limitType == LimitDeposit {
+		subject = "deposit"
+	}
+	return &LimitError{
+		Reason:    fmt.Sprintf("%s_LIMIT_EXCEEDED", limitReason(limitType)),
+		Message:   fmt.Sprintf("%s exceeds the %s %s limit of %.2f; %.2f remaining", subject, period.Adjective(), limitType, limit, remaining),
+		Type:      limitType,
+		Period:    period,
+		Limit:     limit,
+		Remaining: remaining,
+		Until:     &resetsAt,
+	}
+}
+
+func NewCoolOffError(until *time.Time) *LimitError {
+	return &LimitError{
+		Reason:  "COOL_OFF_ACTIVE",
+		Message: "betting is paused by a cool-off period",
+		Until:   until,
+	}
+}
+
+func NewSelfExcludedError(until *time.Time) *LimitError {
+	return &LimitError{
+		Reason:  "SELF_EXCLUDED",
+		Message: "user is self-excluded from betting",
+		Until:   until,
+	}
+}
+
+func limitReason(limitType LimitType) string {
+	switch limitType {
+	case LimitLoss:
+		return "LOSS"
+	case LimitWager:
+		return "WAGER"
+	default:
+		return "DEPOSIT"
+	}
+}
@@ -0,0 +1,424 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+const testIncreaseDelay = 24 * time.Hour
+
+var testNow = time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+
+func amount(v float64) *float64 {
+	return &v
+}
+
+func at(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestUserLimitsSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []Limit
+		amount      *float64
+		at          time.Time
+		wantAmount  *float64
+		wantPending *PendingLimit
+		wantRemoved bool
+	}{
+		{
+			name:       "new limit applies immediately",
+			amount:     amount(100),
+			at:         testNow,
+			wantAmount: amount(100),
+		},
+		{
+			name:       "decrease applies immediately",
+			existing:   []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			amount:     amount(50),
+			at:         testNow,
+			wantAmount: amount(50),
+		},
+		{
+			name:       "same amount applies immediately",
+			existing:   []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			amount:     amount(100),
+			at:         testNow,
+			wantAmount: amount(100),
+		},
+		{
+			name:        "increase is delayed",
+			existing:    []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			amount:      amount(200),
+			at:          testNow,
+			wantAmount:  amount(100),
+			wantPending: &PendingLimit{Amount: amount(200), EffectiveAt: testNow.Add(testIncreaseDelay)},
+		},
+		{
+			name:       "increase applies once the delay has passed",
+			existing:   []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			amount:     amount(200),
+			at:         testNow.Add(testIncreaseDelay),
+			wantAmount: amount(200),
+		},
+		{
+			name:        "removal is delayed",
+			existing:    []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			at:          testNow,
+			wantAmount:  amount(100),
+			wantPending: &PendingLimit{EffectiveAt: testNow.Add(testIncreaseDelay)},
+		},
+		{
+			name:        "removal applies once the delay has passed",
+			existing:    []Limit{{Type: LimitWager, Period: LimitDay, Amount: amount(100)}},
+			at:          testNow.Add(testIncreaseDelay),
+			wantRemoved: true,
+		},
+		{
+			name: "decrease cancels a pending increase",
+			existing: []Limit{{
+				Type:    LimitWager,
+				Period:  LimitDay,
+				Amount:  amount(100),
+				Pending: &PendingLimit{Amount: amount(200), EffectiveAt: testNow.Add(time.Hour)},
+			}},
+			amount:     amount(80),
+			at:         testNow,
+			wantAmount: amount(80),
+		},
+		{
+			name:        "removing a missing limit is a no-op",
+			at:          testNow,
+			wantRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := UserLimits{UserID: 1, Limits: append([]Limit(nil), tt.existing...)}
+			limits.Set(LimitWager, LimitDay, tt.amount, testNow, testIncreaseDelay)
+			limits.Apply(tt.at)
+
+			if tt.wantRemoved {
+				if len(limits.Limits) != 0 {
+					t.Fatalf("limits = %+v, want none", limits.Limits)
+				}
+				return
+			}
+			if len(limits.Limits) != 1 {
+				t.Fatalf("limits = %+v, want exactly one", limits.Limits)
+			}
+
+			got := limits.Limits[0]
+			if !equalAmount(got.Amount, tt.wantAmount) {
+				t.Errorf("amount = %v, want %v", deref(got.Amount), deref(tt.wantAmount))
+			}
+			switch {
+			case tt.wantPending == nil && got.Pending != nil:
+				t.Errorf("pending = %+v, want none", *got.Pending)
+			case tt.wantPending != nil && got.Pending == nil:
+				t.Errorf("pending = none, want %+v", *tt.wantPending)
+			case tt.wantPending != nil:
+				if !equalAmount(got.Pending.Amount, tt.wantPending.Amount) {
+					t.Errorf("pending amount = %v, want %v", deref(got.Pending.Amount), deref(tt.wantPending.Amount))
+				}
+				if !got.Pending.EffectiveAt.Equal(tt.wantPending.EffectiveAt) {
+					t.Errorf("pending effective at = %s, want %s", got.Pending.EffectiveAt, tt.wantPending.EffectiveAt)
+				}
+			}
+			if !limits.UpdatedAt.Equal(testNow) {
+				t.Errorf("updated at = %s, want %s", limits.UpdatedAt, testNow)
+			}
+		})
+	}
+}
+
+func TestUserLimitsSetKeepsLimitsOrdered(t *testing.T) {
+	var limits UserLimits
+	limits.Set(LimitWager, LimitMonth, amount(1000), testNow, testIncreaseDelay)
+	limits.Set(LimitLoss, LimitWeek, amount(300), testNow, testIncreaseDelay)
+	limits.Set(LimitWager, LimitDay, amount(100), testNow, testIncreaseDelay)
+
+	want := []struct {
+		limitType LimitType
+		period    LimitPeriod
+	}{
+		{LimitLoss, LimitWeek},
+		{LimitWager, LimitDay},
+		{LimitWager, LimitMonth},
+	}
+	if len(limits.Limits) != len(want) {
+		t.Fatalf("limits = %+v, want %d entries", limits.Limits, len(want))
+	}
+	for i, w := range want {
+		if limits.Limits[i].Type != w.limitType || limits.Limits[i].Period != w.period {
+			t.Errorf("limits[%d] = %s/%s, want %s/%s", i, limits.Limits[i].Type, limits.Limits[i].Period, w.limitType, w.period)
+		}
+	}
+}
+
+func TestRestrictionExtend(t *testing.T) {
+	since := testNow.Add(-48 * time.Hour)
+	past := testNow.Add(-time.Hour)
+	soon := testNow.Add(24 * time.Hour)
+	later := testNow.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		existing  *Restriction
+		until     *time.Time
+		wantSince time.Time
+		wantUntil *time.Time
+	}{
+		{
+			name:      "starts a new restriction",
+			until:     &soon,
+			wantSince: testNow,
+			wantUntil: &soon,
+		},
+		{
+			name:      "replaces an expired restriction",
+			existing:  &Restriction{Since: since, Until: &past},
+			until:     &soon,
+			wantSince: testNow,
+			wantUntil: &soon,
+		},
+		{
+			name:      "extends an active restriction",
+			existing:  &Restriction{Since: since, Until: &soon},
+			until:     &later,
+			wantSince: since,
+			wantUntil: &later,
+		},
+		{
+			name:      "cannot shorten an active restriction",
+			existing:  &Restriction{Since: since, Until: &later},
+			until:     &soon,
+			wantSince: since,
+			wantUntil: &later,
+		},
+		{
+			name:      "extends an active restriction to permanent",
+			existing:  &Restriction{Since: since, Until: &soon},
+			wantSince: since,
+		},
+		{
+			name:      "cannot end a permanent restriction",
+			existing:  &Restriction{Since: since},
+			until:     &soon,
+			wantSince: since,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.existing.Extend(testNow, tt.until)
+
+			if !got.Since.Equal(tt.wantSince) {
+				t.Errorf("since = %s, want %s", got.Since, tt.wantSince)
+			}
+			switch {
+			case tt.wantUntil == nil && got.Until != nil:
+				t.Errorf("until = %s, want permanent", *got.Until)
+			case tt.wantUntil != nil && got.Until == nil:
+				t.Errorf("until = permanent, want %s", *tt.wantUntil)
+			case tt.wantUntil != nil && !got.Until.Equal(*tt.wantUntil):
+				t.Errorf("until = %s, want %s", *got.Until, *tt.wantUntil)
+			}
+			if !got.ActiveAt(testNow) {
+				t.Error("restriction is not active after extending")
+			}
+		})
+	}
+}
+
+func TestCoolOffAndExclusionExtend(t *testing.T) {
+	var limits UserLimits
+
+	coolOff := testNow.Add(7 * 24 * time.Hour)
+	limits.CoolOff = limits.CoolOff.Extend(testNow, &coolOff)
+	shorter := testNow.Add(24 * time.Hour)
+	limits.CoolOff = limits.CoolOff.Extend(testNow.Add(time.Hour), &shorter)
+	if !limits.CoolOff.Until.Equal(coolOff) {
+		t.Errorf("cool-off until = %s, want %s", *limits.CoolOff.Until, coolOff)
+	}
+
+	limits.SelfExclusion = limits.SelfExclusion.Extend(testNow, Exclusion6Months.Until(testNow))
+	limits.SelfExclusion = limits.SelfExclusion.Extend(testNow, ExclusionPermanent.Until(testNow))
+	limits.SelfExclusion = limits.SelfExclusion.Extend(testNow, Exclusion1Year.Until(testNow))
+	if limits.SelfExclusion.Until != nil {
+		t.Errorf("self-exclusion until = %s, want permanent", *limits.SelfExclusion.Until)
+	}
+
+	limits.Apply(coolOff)
+	if limits.CoolOff != nil {
+		t.Errorf("cool-off = %+v, want cleared once it ended", *limits.CoolOff)
+	}
+	if limits.SelfExclusion == nil {
+		t.Error("permanent self-exclusion was cleared")
+	}
+}
+
+func TestExclusionDurationUntil(t *testing.T) {
+	tests := []struct {
+		duration ExclusionDuration
+		want     *time.Time
+	}{
+		{Exclusion6Months, ptr(at("2026-09-11T15:30:00Z"))},
+		{Exclusion1Year, ptr(at("2027-03-11T15:30:00Z"))},
+		{Exclusion5Years, ptr(at("2031-03-11T15:30:00Z"))},
+		{ExclusionPermanent, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.duration), func(t *testing.T) {
+			got := tt.duration.Until(testNow)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("until = %s, want permanent", *got)
+			case tt.want != nil && (got == nil || !got.Equal(*tt.want)):
+				t.Errorf("until = %v, want %s", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitPeriodBoundaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		period    LimitPeriod
+		at        time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "day",
+			period:    LimitDay,
+			at:        at("2026-03-11T15:30:00Z"),
+			wantStart: at("2026-03-11T00:00:00Z"),
+			wantEnd:   at("2026-03-12T00:00:00Z"),
+		},
+		{
+			name:      "day at midnight",
+			period:    LimitDay,
+			at:        at("2026-03-12T00:00:00Z"),
+			wantStart: at("2026-03-12T00:00:00Z"),
+			wantEnd:   at("2026-03-13T00:00:00Z"),
+		},
+		{
+			name:      "day is UTC",
+			period:    LimitDay,
+			at:        at("2026-03-11T23:30:00-05:00"),
+			wantStart: at("2026-03-12T00:00:00Z"),
+			wantEnd:   at("2026-03-13T00:00:00Z"),
+		},
+		{
+			name:      "week starts on Monday",
+			period:    LimitWeek,
+			at:        at("2026-03-09T00:00:00Z"),
+			wantStart: at("2026-03-09T00:00:00Z"),
+			wantEnd:   at("2026-03-16T00:00:00Z"),
+		},
+		{
+			name:      "Sunday belongs to the previous week",
+			period:    LimitWeek,
+			at:        at("2026-03-15T23:59:59Z"),
+			wantStart: at("2026-03-09T00:00:00Z"),
+			wantEnd:   at("2026-03-16T00:00:00Z"),
+		},
+		{
+			name:      "week across a month boundary",
+			period:    LimitWeek,
+			at:        at("2026-04-01T12:00:00Z"),
+			wantStart: at("2026-03-30T00:00:00Z"),
+			wantEnd:   at("2026-04-06T00:00:00Z"),
+		},
+		{
+			name:      "month",
+			period:    LimitMonth,
+			at:        at("2026-02-28T23:59:59Z"),
+			wantStart: at("2026-02-01T00:00:00Z"),
+			wantEnd:   at("2026-03-01T00:00:00Z"),
+		},
+		{
+			name:      "month across a year boundary",
+			period:    LimitMonth,
+			at:        at("2026-12-31T12:00:00Z"),
+			wantStart: at("2026-12-01T00:00:00Z"),
+			wantEnd:   at("2027-01-01T00:00:00Z"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Start(tt.at); !got.Equal(tt.wantStart) {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := tt.period.End(tt.at); !got.Equal(tt.wantEnd) {
+				t.Errorf("end = %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestUserLimitsDeposits(t *testing.T) {
+	now := at("2026-03-11T15:30:00Z")
+	limits := UserLimits{Deposits: []Deposit{
+		{Amount: 5, At: at("2026-02-27T12:00:00Z")},
+		{Amount: 10, At: at("2026-03-01T09:00:00Z")},
+		{Amount: 20, At: at("2026-03-09T00:00:00Z")},
+		{Amount: 40, At: at("2026-03-11T08:00:00Z")},
+	}}
+
+	limits.Apply(now)
+	if len(limits.Deposits) != 3 {
+		t.Fatalf("deposits after apply = %+v, want the one before the month and week dropped", limits.Deposits)
+	}
+
+	tests := []struct {
+		period LimitPeriod
+		want   float64
+	}{
+		{LimitDay, 40},
+		{LimitWeek, 60},
+		{LimitMonth, 70},
+	}
+	for _, tt := range tests {
+		if got := limits.Deposited(tt.period.Start(now)); got != tt.want {
+			t.Errorf("%s deposits = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+
+	now = at("2026-07-02T10:00:00Z")
+	limits = UserLimits{Deposits: []Deposit{
+		{Amount: 10, At: at("2026-06-28T12:00:00Z")},
+		{Amount: 20, At: at("2026-06-30T12:00:00Z")},
+	}}
+	limits.Apply(now)
+	if len(limits.Deposits) != 1 || limits.Deposited(LimitWeek.Start(now)) != 20 || limits.Deposited(LimitMonth.Start(now)) != 0 {
+		t.Fatalf("deposits = %+v, want the deposit from the week that started in June kept for the weekly window only", limits.Deposits)
+	}
+}
+
+func equalAmount(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func deref(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
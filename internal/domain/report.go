@@ -0,0 +1,126 @@
+package domain
+
+import "time"
+
+const MaxReportBuckets = 1000
+
+type ReportGranularity string
+
+const (
+	GranularityHour  ReportGranularity = "hour"
+	GranularityDay   ReportGranularity = "day"
+	GranularityMonth ReportGranularity = "month"
+)
+
+var ReportGranularities = []ReportGranularity{
+	GranularityHour,
+	GranularityDay,
+	GranularityMonth,
+}
+
+func (g ReportGranularity) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch g {
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case GranularityDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Truncate(time.Hour)
+	}
+}
+
+func (g ReportGranularity) Next(t time.Time) time.Time {
+	switch g {
+	case GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	case GranularityDay:
+		return t.AddDate(0, 0, 1)
+	default:
+		return t.Add(time.Hour)
+	}
+}
+
+func (g ReportGranularity) Buckets(from, to time.Time) int {
+	n := 0
+	for start := g.Truncate(from); start.Before(to) && n <= MaxReportBuckets; start = g.Next(start) {
+		n++
+	}
+	return n
+}
+
+type ReportRequest struct {
+	From        time.Time
+	To          time.Time
+	Granularity ReportGranularity
+	Currency    string
+	Operator    string
+}
+
+type ReportTotals struct {
+	Bets            int
+	Players         int
+	Refunded        int
+	Turnover        float64
+	SettledTurnover float64
+	Payouts         float64
+	Refunds         float64
+}
+
+func (t *ReportTotals) Add(bet Bet) {
+	t.apply(bet, 1)
+}
+
+func (t *ReportTotals) Remove(bet Bet) {
+	t.apply(bet, -1)
+}
+
+func (t *ReportTotals) apply(bet Bet, sign int) {
+	t.Bets += sign
+	amount := float64(sign) * bet.Amount
+
+	switch bet.Status {
+	case BetStatusCancelled, BetStatusVoided:
+		t.Refunded += sign
+		t.Refunds += amount
+	case BetStatusWon, BetStatusLost:
+		t.Turnover += amount
+		t.SettledTurnover += amount
+		t.Payouts += float64(sign) * bet.Payout
+	default:
+		t.Turnover += amount
+	}
+}
+
+func (t *ReportTotals) Merge(other ReportTotals) {
+	t.Bets += other.Bets
+	t.Refunded += other.Refunded
+	t.Turnover += other.Turnover
+	t.SettledTurnover += other.SettledTurnover
+	t.Payouts += other.Payouts
+	t.Refunds += other.Refunds
+}
+
+func (t ReportTotals) GGR() float64 {
+	return t.SettledTurnover - t.Payouts
+}
+
+func (t ReportTotals) RTP() (float64, bool) {
+	if t.SettledTurnover == 0 {
+		return 0, false
+	}
+	return t.Payouts / t.SettledTurnover, true
+}
+
+type ReportBucket struct {
+	Start time.Time
+	End   time.Time
+	ReportTotals
+}
+
+type Report struct {
+	ReportRequest
+	TheoreticalRTP float64
+	Totals         ReportTotals
+	Buckets        []ReportBucket
+}
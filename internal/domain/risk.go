@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	MaxBetCrashPoint = 100.0
+	ExposureTopUsers = 10
+)
+
+type RiskMode string
+
+const (
+	RiskModeReject RiskMode = "reject"
+	RiskModeCap    RiskMode = "cap"
+)
+
+type RoundExposure struct {
+	RoundID       string
+	Status        RoundStatus
+	Bets          int
+	Stakes        float64
+	Exposure      float64
+	MaxCrashPoint float64
+	ThrottledAt   *time.Time
+}
+
+type UserExposure struct {
+	UserID   int64
+	Bets     int
+	Exposure float64
+}
+
+type ExposureReport struct {
+	Mode                RiskMode
+	RoundLiability      float64
+	UserLiability       float64
+	ThrottleRatio       float64
+	ThrottledCrashPoint float64
+	Exposure            float64
+	Rounds              []RoundExposure
+	TopUsers            []UserExposure
+}
+
+type RiskError struct {
+	Reason        string
+	Message       string
+	Limit         float64
+	Remaining     float64
+	MaxCrashPoint float64
+}
+
+func (e *RiskError) Error() string {
+	return e.Message
+}
+
+func (e *RiskError) Code() string {
+	return e.Reason
+}
+
+func IsRiskError(err error) bool {
+	var riskErr *RiskError
+	return errors.As(err, &riskErr)
+}
+
+func NewRoundLiabilityError(limit, remaining float64) *RiskError {
+	return &RiskError{
+		Reason:    "ROUND_LIABILITY_EXCEEDED",
+		Message:   fmt.Sprintf("bet would take the round's potential payout over the house liability cap of %.2f; %.2f remaining", limit, remaining),
+		Limit:     limit,
+		Remaining: remaining,
+	}
+}
+
+func NewUserExposureError(limit, remaining float64) *RiskError {
+	return &RiskError{
+		Reason:    "USER_EXPOSURE_EXCEEDED",
+		Message:   fmt.Sprintf("bet would take the user's potential payout over the exposure cap of %.2f; %.2f remaining", limit, remaining),
+		Limit:     limit,
+		Remaining: remaining,
+	}
+}
+
+func NewCrashPointRestrictedError(maxCrashPoint float64) *RiskError {
+	return &RiskError{
+		Reason:        "CRASH_POINT_RESTRICTED",
+		Message:       fmt.Sprintf("crash point is limited to %.2f for the rest of this round", maxCrashPoint),
+		MaxCrashPoint: maxCrashPoint,
+	}
+}
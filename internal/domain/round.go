@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoundStatus string
+
+const (
+	RoundStatusBetting RoundStatus = "betting"
+	RoundStatusRunning RoundStatus = "running"
+	RoundStatusCrashed RoundStatus = "crashed"
+)
+
+type Round struct {
+	ID         string
+	Status     RoundStatus
+	CrashPoint float64
+	OpenedAt   time.Time
+	StartedAt  *time.Time
+	CrashedAt  *time.Time
+}
+
+type RoundSummary struct {
+	Round      Round
+	Multiplier float64
+	Bets       int
+	Wagered    float64
+	PaidOut    float64
+}
+
+func NewRound() *Round {
+	return &Round{
+		ID:       uuid.New().String(),
+		Status:   RoundStatusBetting,
+		OpenedAt: time.Now(),
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+type Snapshot struct {
+	ID             string
+	Bets           int
+	Bytes          int64
+	Checksum       string
+	Limits         int
+	LimitsBytes    int64
+	LimitsChecksum string
+	CreatedAt      time.Time
+}
+
+type CompactResult struct {
+	Removed []Snapshot
+	Kept    int
+}
@@ -0,0 +1,131 @@
+package domain
+
+import "time"
+
+type UserStatsRequest struct {
+	UserID int64
+	From   *time.Time
+	To     *time.Time
+}
+
+type UserStats struct {
+	UserID         int64
+	Bets           int
+	Pending        int
+	Won            int
+	Lost           int
+	Refunded       int
+	Wagered        float64
+	PendingWagered float64
+	PaidOut        float64
+	CrashPointSum  float64
+	MaxCrashPoint  float64
+	BiggestWin     float64
+	FirstBetAt     time.Time
+	LastBetAt      time.Time
+}
+
+func StatsDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func (s *UserStats) Add(bet Bet) {
+	s.Bets++
+	if s.FirstBetAt.IsZero() || bet.CreatedAt.Before(s.FirstBetAt) {
+		s.FirstBetAt = bet.CreatedAt
+	}
+	if bet.CreatedAt.After(s.LastBetAt) {
+		s.LastBetAt = bet.CreatedAt
+	}
+
+	switch bet.Status {
+	case BetStatusPending:
+		s.Pending++
+		s.PendingWagered += bet.Amount
+	case BetStatusCancelled, BetStatusVoided:
+		s.Refunded++
+	case BetStatusWon, BetStatusLost:
+		if bet.Status == BetStatusWon {
+			s.Won++
+			s.PaidOut += bet.Payout
+			s.BiggestWin = max(s.BiggestWin, bet.Payout)
+		} else {
+			s.Lost++
+		}
+		s.Wagered += bet.Amount
+		s.CrashPointSum += bet.CrashPoint
+		s.MaxCrashPoint = max(s.MaxCrashPoint, bet.CrashPoint)
+	}
+}
+
+func (s *UserStats) Remove(bet Bet) (exact bool) {
+	s.Bets--
+
+	switch bet.Status {
+	case BetStatusPending:
+		s.Pending--
+		s.PendingWagered -= bet.Amount
+	case BetStatusCancelled, BetStatusVoided:
+		s.Refunded--
+	case BetStatusWon, BetStatusLost:
+		if bet.Status == BetStatusWon {
+			s.Won--
+			s.PaidOut -= bet.Payout
+		} else {
+			s.Lost--
+		}
+		s.Wagered -= bet.Amount
+		s.CrashPointSum -= bet.CrashPoint
+		if bet.CrashPoint >= s.MaxCrashPoint || (bet.Status == BetStatusWon && bet.Payout >= s.BiggestWin) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *UserStats) Merge(other UserStats) {
+	if other.Bets == 0 {
+		return
+	}
+
+	s.Bets += other.Bets
+	s.Pending += other.Pending
+	s.Won += other.Won
+	s.Lost += other.Lost
+	s.Refunded += other.Refunded
+	s.Wagered += other.Wagered
+	s.PendingWagered += other.PendingWagered
+	s.PaidOut += other.PaidOut
+	s.CrashPointSum += other.CrashPointSum
+	s.MaxCrashPoint = max(s.MaxCrashPoint, other.MaxCrashPoint)
+	s.BiggestWin = max(s.BiggestWin, other.BiggestWin)
+	if s.FirstBetAt.IsZero() || other.FirstBetAt.Before(s.FirstBetAt) {
+		s.FirstBetAt = other.FirstBetAt
+	}
+	if other.LastBetAt.After(s.LastBetAt) {
+		s.LastBetAt = other.LastBetAt
+	}
+}
+
+func (s UserStats) Settled() int {
+	return s.Won + s.Lost
+}
+
+func (s UserStats) NetPnL() float64 {
+	return s.PaidOut - s.Wagered
+}
+
+func (s UserStats) WinRate() float64 {
+	if s.Settled() == 0 {
+		return 0
+	}
+	return float64(s.Won) / float64(s.Settled())
+}
+
+func (s UserStats) AvgCrashPoint() float64 {
+	if s.Settled() == 0 {
+		return 0
+	}
+	return s.CrashPointSum / float64(s.Settled())
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound = &NotFoundError{Resource: "webhook", Message: "webhook not found"}
+)
+
+type Webhook struct {
+	ID                  string
+	URL                 string
+	Events              []string
+	Secret              string
+	Owner               string
+	OwnerAdmin          bool
+	OwnerOperator       bool
+	OwnerUserID         int64
+	Active              bool
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	DisabledAt          *time.Time
+}
+
+func NewWebhook(url string, events []string, secret string, owner Actor) *Webhook {
+	return &Webhook{
+		ID:            uuid.New().String(),
+		URL:           url,
+		Events:        events,
+		Secret:        secret,
+		Owner:         owner.ID,
+		OwnerAdmin:    owner.Admin,
+		OwnerOperator: owner.Operator,
+		OwnerUserID:   owner.UserID,
+		Active:        true,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func (w *Webhook) VisibleTo(actor Actor) bool {
+	return actor.Admin || w.Owner == actor.ID
+}
+
+func (w *Webhook) ReceivesFor(userID int64) bool {
+	owner := Actor{Admin: w.OwnerAdmin, Operator: w.OwnerOperator, UserID: w.OwnerUserID}
+	return owner.ActsFor(userID)
+}
+
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	ID         string
+	WebhookID  string
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
@@ -0,0 +1,406 @@
+package events
+
+import (
+	"bet/internal/metrics"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const maxDeadLetters = 1000
+
+type Handler func(ctx context.Context, record OutboxRecord) error
+
+type OutboxStore interface {
+	FetchOutbox(ctx context.Context, afterSeq uint64, limit int) ([]OutboxRecord, error)
+	MarkOutboxPublished(ctx context.Context, id string) error
+}
+
+type Config struct {
+	PollInterval     time.Duration
+	BatchSize        int
+	MaxAttempts      int
+	RetryBackoff     time.Duration
+	SubscriberBuffer int
+	Logger           *zap.Logger
+}
+
+type delivery struct {
+	record  OutboxRecord
+	pending int
+	sent    map[*subscriber]bool
+}
+
+type subscriber struct {
+	name    string
+	types   map[Type]struct{}
+	handler Handler
+	queue   chan *delivery
+	cursor  uint64
+}
+
+func (s *subscriber) wants(t Type) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	_, ok := s.types[t]
+	return ok
+}
+
+type Bus struct {
+	config Config
+	store  OutboxStore
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	subscribers []*subscriber
+	deadLetters []DeadLetter
+	started     bool
+
+	ackMu    sync.Mutex
+	inFlight map[string]*delivery
+	rewind   uint64
+	rewound  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBus(config Config, store OutboxStore) *Bus {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 100 * time.Millisecond
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 100 * time.Millisecond
+	}
+	if config.SubscriberBuffer <= 0 {
+		config.SubscriberBuffer = 1024
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Bus{
+		config:   config,
+		store:    store,
+		logger:   config.Logger,
+		inFlight: make(map[string]*delivery),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler, types ...Type) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		return errors.New("event bus: subscribe after start")
+	}
+
+	sub := &subscriber{
+		name:    name,
+		types:   make(map[Type]struct{}, len(types)),
+		handler: handler,
+		queue:   make(chan *delivery, b.config.SubscriberBuffer),
+	}
+	for _, t := range types {
+		sub.types[t] = struct{}{}
+	}
+
+	b.subscribers = append(b.subscribers, sub)
+	return nil
+}
+
+func (b *Bus) Start() {
+	b.mu.Lock()
+	b.started = true
+	subscribers := b.subscribers
+	b.mu.Unlock()
+
+	for _, sub := range subscribers {
+		b.wg.Add(1)
+		go b.consume(sub)
+	}
+
+	b.wg.Add(1)
+	go b.relay(subscribers)
+}
+
+func (b *Bus) DeadLetters() []DeadLetter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	letters := make([]DeadLetter, len(b.deadLetters))
+	copy(letters, b.deadLetters)
+	return letters
+}
+
+func (b *Bus) Shutdown(ctx context.Context) error {
+	b.logger.Info("shutting down event bus...")
+	b.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.logger.Info("event bus shutdown complete")
+		return nil
+	case <-ctx.Done():
+		b.logger.Warn("event bus shutdown timeout")
+		return ctx.Err()
+	}
+}
+
+func (b *Bus) relay(subscribers []*subscriber) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.config.PollInterval)
+	defer ticker.Stop()
+
+	var seen uint64
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if to, ok := b.takeRewind(); ok {
+			seen = min(seen, to)
+			for _, sub := range subscribers {
+				sub.cursor = min(sub.cursor, to)
+			}
+		}
+
+		cursor := seen
+		for _, sub := range subscribers {
+			cursor = min(cursor, sub.cursor)
+		}
+
+		blocked := make(map[*subscriber]bool)
+		for {
+			records, err := b.store.FetchOutbox(b.ctx, cursor, b.config.BatchSize)
+			if err != nil {
+				if b.ctx.Err() == nil {
+					b.logger.Error("failed to fetch outbox", zap.Error(err))
+				}
+				break
+			}
+
+			for _, record := range records {
+				cursor = record.Seq
+				seen = max(seen, record.Seq)
+				b.dispatch(subscribers, record, blocked)
+			}
+
+			if len(records) < b.config.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (b *Bus) dispatch(subscribers []*subscriber, record OutboxRecord, blocked map[*subscriber]bool) {
+	eventType := record.Event.EventType()
+
+	b.ackMu.Lock()
+	d := b.inFlight[record.ID]
+	b.ackMu.Unlock()
+
+	if d == nil {
+		wanted, targets := false, 0
+		for _, sub := range subscribers {
+			if !sub.wants(eventType) {
+				continue
+			}
+			wanted = true
+			if sub.cursor < record.Seq {
+				targets++
+			}
+		}
+		if targets == 0 {
+			for _, sub := range subscribers {
+				sub.cursor = max(sub.cursor, record.Seq)
+			}
+			if !wanted {
+				metrics.EventsPublished.Add(1)
+				b.markPublished(record)
+			}
+			return
+		}
+
+		metrics.EventsPublished.Add(1)
+		d = &delivery{record: record, pending: targets, sent: make(map[*subscriber]bool, targets)}
+		b.ackMu.Lock()
+		b.inFlight[record.ID] = d
+		b.ackMu.Unlock()
+	}
+
+	for _, sub := range subscribers {
+		if blocked[sub] || sub.cursor >= record.Seq {
+			continue
+		}
+		if !sub.wants(eventType) || d.sent[sub] {
+			sub.cursor = record.Seq
+			continue
+		}
+
+		select {
+		case sub.queue <- d:
+			d.sent[sub] = true
+			sub.cursor = record.Seq
+		default:
+			blocked[sub] = true
+			metrics.EventDeliveriesDeferred.Add(1)
+		}
+	}
+}
+
+func (b *Bus) consume(sub *subscriber) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case d := <-sub.queue:
+			if !b.deliver(sub, d) {
+				return
+			}
+			b.ack(d)
+		}
+	}
+}
+
+func (b *Bus) deliver(sub *subscriber, d *delivery) bool {
+	backoff := b.config.RetryBackoff
+
+	var lastErr error
+	for attempt := 1; attempt <= b.config.MaxAttempts; attempt++ {
+		lastErr = b.invoke(sub, d.record)
+		if lastErr == nil {
+			return true
+		}
+
+		if attempt == b.config.MaxAttempts {
+			break
+		}
+
+		metrics.EventDeliveryRetries.Add(1)
+		b.logger.Warn("event delivery failed, retrying",
+			zap.String("subscriber", sub.name),
+			zap.String("event_id", d.record.ID),
+			zap.String("event_type", string(d.record.Event.EventType())),
+			zap.Int("attempt", attempt),
+			zap.Error(lastErr),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-b.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+
+	b.deadLetter(sub, d.record, lastErr)
+	return true
+}
+
+func (b *Bus) invoke(sub *subscriber, record OutboxRecord) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = errors.New("event handler panicked")
+			b.logger.Error("event handler panic",
+				zap.String("subscriber", sub.name),
+				zap.String("event_id", record.ID),
+				zap.Any("panic", rec),
+			)
+		}
+	}()
+
+	return sub.handler(b.ctx, record)
+}
+
+func (b *Bus) deadLetter(sub *subscriber, record OutboxRecord, err error) {
+	metrics.EventsDeadLettered.Add(1)
+	b.logger.Error("event dead-lettered",
+		zap.String("subscriber", sub.name),
+		zap.String("event_id", record.ID),
+		zap.String("event_type", string(record.Event.EventType())),
+		zap.Int("attempts", b.config.MaxAttempts),
+		zap.Error(err),
+	)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.deadLetters = append(b.deadLetters, DeadLetter{
+		Record:     record,
+		Subscriber: sub.name,
+		Attempts:   b.config.MaxAttempts,
+		LastError:  err.Error(),
+		FailedAt:   time.Now(),
+	})
+	if len(b.deadLetters) > maxDeadLetters {
+		b.deadLetters = b.deadLetters[len(b.deadLetters)-maxDeadLetters:]
+	}
+}
+
+func (b *Bus) ack(d *delivery) {
+	b.ackMu.Lock()
+	d.pending--
+	done := d.pending == 0
+	if done {
+		delete(b.inFlight, d.record.ID)
+	}
+	b.ackMu.Unlock()
+
+	if done {
+		b.markPublished(d.record)
+	}
+}
+
+func (b *Bus) takeRewind() (uint64, bool) {
+	b.ackMu.Lock()
+	defer b.ackMu.Unlock()
+
+	to, ok := b.rewind, b.rewound
+	b.rewind, b.rewound = 0, false
+	return to, ok
+}
+
+func (b *Bus) markPublished(record OutboxRecord) {
+	err := b.store.MarkOutboxPublished(b.ctx, record.ID)
+	if err == nil || b.ctx.Err() != nil {
+		return
+	}
+
+	b.logger.Error("failed to mark outbox record published",
+		zap.String("event_id", record.ID),
+		zap.Error(err),
+	)
+
+	b.ackMu.Lock()
+	defer b.ackMu.Unlock()
+
+	if !b.rewound || record.Seq-1 < b.rewind {
+		b.rewind, b.rewound = record.Seq-1, true
+	}
+}
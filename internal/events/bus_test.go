@@ -0,0 +1,199 @@
+package events
+
+import (
+	"bet/internal/domain"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type fakeOutbox struct {
+	mu        sync.Mutex
+	records   []OutboxRecord
+	failMarks int
+	fetches   []uint64
+}
+
+func (s *fakeOutbox) FetchOutbox(ctx context.Context, afterSeq uint64, limit int) ([]OutboxRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetches = append(s.fetches, afterSeq)
+	var records []OutboxRecord
+	for _, record := range s.records {
+		if record.Seq > afterSeq && len(records) < limit {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (s *fakeOutbox) MarkOutboxPublished(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failMarks > 0 {
+		s.failMarks--
+		return errors.New("store unavailable")
+	}
+	for i, record := range s.records {
+		if record.ID == id {
+			s.records = append(s.records[:i], s.records[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *fakeOutbox) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+func TestBusRedeliversUnackedRecords(t *testing.T) {
+	store := &fakeOutbox{failMarks: 1}
+	for seq := uint64(1); seq <= 3; seq++ {
+		store.records = append(store.records, OutboxRecord{
+			ID:    string(rune('a' + seq - 1)),
+			Seq:   seq,
+			Event: BetPlaced{Bet: domain.Bet{ID: "bet"}},
+		})
+	}
+
+	bus := NewBus(Config{PollInterval: 5 * time.Millisecond, BatchSize: 2, Logger: zap.NewNop()}, store)
+
+	var mu sync.Mutex
+	deliveries := make(map[string]int)
+	if err := bus.Subscribe("test", func(ctx context.Context, record OutboxRecord) error {
+		mu.Lock()
+		deliveries[record.ID]++
+		mu.Unlock()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bus.Start()
+	defer bus.Shutdown(context.Background())
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.pending() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d records were never acknowledged", store.pending())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if deliveries["a"] != 2 {
+		t.Errorf("record a was delivered %d times, want 2 after its first ack failed", deliveries["a"])
+	}
+	for _, id := range []string{"b", "c"} {
+		if deliveries[id] != 1 {
+			t.Errorf("record %s was delivered %d times, want 1", id, deliveries[id])
+		}
+	}
+}
+
+func TestBusSlowSubscriberDoesNotStallOthers(t *testing.T) {
+	store := &fakeOutbox{}
+	for seq := uint64(1); seq <= 10; seq++ {
+		store.records = append(store.records, OutboxRecord{
+			ID:    string(rune('a' + seq - 1)),
+			Seq:   seq,
+			Event: BetPlaced{Bet: domain.Bet{ID: "bet"}},
+		})
+	}
+
+	bus := NewBus(Config{PollInterval: 5 * time.Millisecond, SubscriberBuffer: 1, Logger: zap.NewNop()}, store)
+
+	release := make(chan struct{})
+	if err := bus.Subscribe("slow", func(ctx context.Context, record OutboxRecord) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fast := make(chan string, 10)
+	if err := bus.Subscribe("fast", func(ctx context.Context, record OutboxRecord) error {
+		fast <- record.ID
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bus.Start()
+	defer bus.Shutdown(context.Background())
+
+	for i := 0; i < 10; i++ {
+		select {
+		case id := <-fast:
+			if want := string(rune('a' + i)); id != want {
+				t.Fatalf("fast subscriber got %s, want %s", id, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("fast subscriber got %d of 10 records while the slow one was blocked", i)
+		}
+	}
+	if store.pending() != 10 {
+		t.Fatalf("%d records pending, want 10 until the slow subscriber handles them", store.pending())
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for store.pending() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d records were never acknowledged", store.pending())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case id := <-fast:
+		t.Fatalf("fast subscriber got %s again", id)
+	default:
+	}
+}
+
+func TestBusRelayResumesFromCursor(t *testing.T) {
+	store := &fakeOutbox{}
+	bus := NewBus(Config{PollInterval: 5 * time.Millisecond, Logger: zap.NewNop()}, store)
+
+	if err := bus.Subscribe("test", func(ctx context.Context, record OutboxRecord) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	bus.Start()
+	defer bus.Shutdown(context.Background())
+
+	for seq := uint64(1); seq <= 3; seq++ {
+		store.mu.Lock()
+		store.records = append(store.records, OutboxRecord{ID: string(rune('a' + seq - 1)), Seq: seq, Event: BetPlaced{Bet: domain.Bet{ID: "bet"}}})
+		store.mu.Unlock()
+
+		deadline := time.Now().Add(2 * time.Second)
+		for store.pending() > 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("record %d was never acknowledged", seq)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		store.mu.Lock()
+		last := store.fetches[len(store.fetches)-1]
+		store.mu.Unlock()
+		if last != seq {
+			t.Fatalf("relay polled after seq %d once record %d was delivered, want %d", last, seq, seq)
+		}
+	}
+}
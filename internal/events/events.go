@@ -0,0 +1,95 @@
+package events
+
+import (
+	"bet/internal/domain"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeBetPlaced    Type = "bet.placed"
+	TypeBetCashedOut Type = "bet.cashed_out"
+	TypeBetSettled   Type = "bet.settled"
+	TypeBetCancelled Type = "bet.cancelled"
+	TypeBetVoided    Type = "bet.voided"
+	TypeRoundCrashed Type = "round.crashed"
+)
+
+type Event interface {
+	EventType() Type
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+type BetPlaced struct {
+	Bet domain.Bet
+	At  time.Time
+}
+
+func (e BetPlaced) EventType() Type       { return TypeBetPlaced }
+func (e BetPlaced) AggregateID() string   { return e.Bet.ID }
+func (e BetPlaced) OccurredAt() time.Time { return e.At }
+
+type BetCashedOut struct {
+	Bet        domain.Bet
+	Multiplier float64
+	At         time.Time
+}
+
+func (e BetCashedOut) EventType() Type       { return TypeBetCashedOut }
+func (e BetCashedOut) AggregateID() string   { return e.Bet.ID }
+func (e BetCashedOut) OccurredAt() time.Time { return e.At }
+
+type BetSettled struct {
+	Bet             domain.Bet
+	RoundCrashPoint float64
+	At              time.Time
+}
+
+func (e BetSettled) EventType() Type       { return TypeBetSettled }
+func (e BetSettled) AggregateID() string   { return e.Bet.ID }
+func (e BetSettled) OccurredAt() time.Time { return e.At }
+
+type BetCancelled struct {
+	Bet domain.Bet
+	At  time.Time
+}
+
+func (e BetCancelled) EventType() Type       { return TypeBetCancelled }
+func (e BetCancelled) AggregateID() string   { return e.Bet.ID }
+func (e BetCancelled) OccurredAt() time.Time { return e.At }
+
+type BetVoided struct {
+	Bet domain.Bet
+	At  time.Time
+}
+
+func (e BetVoided) EventType() Type       { return TypeBetVoided }
+func (e BetVoided) AggregateID() string   { return e.Bet.ID }
+func (e BetVoided) OccurredAt() time.Time { return e.At }
+
+type RoundCrashed struct {
+	Round domain.Round
+	Bets  int
+	At    time.Time
+}
+
+func (e RoundCrashed) EventType() Type       { return TypeRoundCrashed }
+func (e RoundCrashed) AggregateID() string   { return e.Round.ID }
+func (e RoundCrashed) OccurredAt() time.Time { return e.At }
+
+type OutboxRecord struct {
+	ID        string
+	Seq       uint64
+	Event     Event
+	CreatedAt time.Time
+}
+
+type DeadLetter struct {
+	Record     OutboxRecord
+	Subscriber string
+	Attempts   int
+	LastError  string
+	FailedAt   time.Time
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+	header bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{
+		w:      csv.NewWriter(w),
+		record: make([]string, len(Columns)),
+	}
+}
+
+func (e *csvEncoder) Encode(row Row) error {
+	if !e.header {
+		if err := e.w.Write(Columns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+
+	e.record[0] = row.ID
+	e.record[1] = strconv.FormatInt(row.UserID, 10)
+	e.record[2] = strconv.FormatFloat(row.Amount, 'f', -1, 64)
+	e.record[3] = strconv.FormatFloat(row.CrashPoint, 'f', -1, 64)
+	e.record[4] = row.RoundID
+	e.record[5] = row.Status
+	e.record[6] = strconv.FormatFloat(row.Payout, 'f', -1, 64)
+	e.record[7] = row.CreatedAt.Format(time.RFC3339Nano)
+	e.record[8] = ""
+	if row.SettledAt != nil {
+		e.record[8] = row.SettledAt.Format(time.RFC3339Nano)
+	}
+	e.record[9] = row.VoidReason
+	e.record[10] = row.Currency
+	e.record[11] = row.Operator
+
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	if !e.header {
+		if err := e.w.Write(Columns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	return e.Flush()
+}
+
+type ndjsonEncoder struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	buf := bufio.NewWriter(w)
+	return &ndjsonEncoder{
+		buf: buf,
+		enc: json.NewEncoder(buf),
+	}
+}
+
+func (e *ndjsonEncoder) Encode(row Row) error {
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.buf.Flush()
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.buf.Flush()
+}
+
+type parquetEncoder struct {
+	w    *parquet.GenericWriter[Row]
+	rows []Row
+}
+
+func newParquetEncoder(w io.Writer, config Config) *parquetEncoder {
+	codec := parquet.Compression(&parquet.Snappy)
+	if config.Compression == CompressionGzip {
+		codec = parquet.Compression(&parquet.Gzip)
+	}
+
+	return &parquetEncoder{
+		w: parquet.NewGenericWriter[Row](w,
+			codec,
+			parquet.MaxRowsPerRowGroup(int64(config.RowGroupSize)),
+			parquet.CreatedBy("bet-api", "", ""),
+		),
+		rows: make([]Row, 1),
+	}
+}
+
+func (e *parquetEncoder) Encode(row Row) error {
+	e.rows[0] = row
+	_, err := e.w.Write(e.rows)
+	return err
+}
+
+func (e *parquetEncoder) Flush() error {
+	return nil
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.w.Close()
+}
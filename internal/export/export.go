@@ -0,0 +1,224 @@
+package export
+
+import (
+	"bet/internal/domain"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+var Formats = []Format{FormatCSV, FormatNDJSON, FormatParquet}
+
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+)
+
+var Compressions = []Compression{CompressionNone, CompressionGzip}
+
+const defaultRowGroupSize = 10000
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+type Config struct {
+	Format       Format
+	Compression  Compression
+	RowGroupSize int
+}
+
+type Row struct {
+	ID         string     `parquet:"id" json:"id"`
+	UserID     int64      `parquet:"user_id" json:"user_id"`
+	Amount     float64    `parquet:"amount" json:"amount"`
+	CrashPoint float64    `parquet:"crash_point" json:"crash_point"`
+	RoundID    string     `parquet:"round_id" json:"round_id"`
+	Status     string     `parquet:"status,dict" json:"status"`
+	Payout     float64    `parquet:"payout" json:"payout"`
+	CreatedAt  time.Time  `parquet:"created_at" json:"created_at"`
+	SettledAt  *time.Time `parquet:"settled_at,optional" json:"settled_at,omitempty"`
+	VoidReason string     `parquet:"void_reason,optional,dict" json:"void_reason,omitempty"`
+	Currency   string     `parquet:"currency,optional,dict" json:"currency,omitempty"`
+	Operator   string     `parquet:"operator,optional,dict" json:"operator,omitempty"`
+}
+
+var Columns = []string{"id", "user_id", "amount", "crash_point", "round_id", "status", "payout", "created_at", "settled_at", "void_reason", "currency", "operator"}
+
+func RowFromDomain(bet domain.Bet) Row {
+	row := Row{
+		ID:         bet.ID,
+		UserID:     bet.UserID,
+		Amount:     bet.Amount,
+		CrashPoint: bet.CrashPoint,
+		RoundID:    bet.RoundID,
+		Status:     string(bet.Status),
+		Payout:     bet.Payout,
+		CreatedAt:  bet.CreatedAt.UTC(),
+		VoidReason: string(bet.VoidReason),
+		Currency:   bet.Currency,
+		Operator:   bet.Operator,
+	}
+
+	if bet.SettledAt != nil {
+		settledAt := bet.SettledAt.UTC()
+		row.SettledAt = &settledAt
+	}
+
+	return row
+}
+
+func (r Row) Bet() domain.Bet {
+	return domain.Bet{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Amount:     r.Amount,
+		CrashPoint: r.CrashPoint,
+		RoundID:    r.RoundID,
+		Status:     domain.BetStatus(r.Status),
+		Payout:     r.Payout,
+		CreatedAt:  r.CreatedAt,
+		SettledAt:  r.SettledAt,
+		VoidReason: domain.VoidReason(r.VoidReason),
+		Currency:   r.Currency,
+		Operator:   r.Operator,
+	}
+}
+
+type encoder interface {
+	Encode(row Row) error
+	Flush() error
+	Close() error
+}
+
+type Writer struct {
+	config  Config
+	digest  *digestWriter
+	gzip    *gzip.Writer
+	encoder encoder
+	rows    int
+}
+
+func NewWriter(w io.Writer, config Config) (*Writer, error) {
+	if config.Compression == "" {
+		config.Compression = CompressionNone
+	}
+	if config.RowGroupSize <= 0 {
+		config.RowGroupSize = defaultRowGroupSize
+	}
+
+	writer := &Writer{
+		config: config,
+		digest: &digestWriter{w: w, hash: sha256.New()},
+	}
+
+	var out io.Writer = writer.digest
+	if config.Compression == CompressionGzip && config.Format != FormatParquet {
+		writer.gzip = gzip.NewWriter(writer.digest)
+		out = writer.gzip
+	}
+
+	switch config.Format {
+	case FormatCSV:
+		writer.encoder = newCSVEncoder(out)
+	case FormatNDJSON:
+		writer.encoder = newNDJSONEncoder(out)
+	case FormatParquet:
+		writer.encoder = newParquetEncoder(out, config)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, config.Format)
+	}
+
+	return writer, nil
+}
+
+func (w *Writer) Write(bet domain.Bet) error {
+	if err := w.encoder.Encode(RowFromDomain(bet)); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+func (w *Writer) Flush() error {
+	if err := w.encoder.Flush(); err != nil {
+		return err
+	}
+	if w.gzip != nil {
+		return w.gzip.Flush()
+	}
+	return nil
+}
+
+func (w *Writer) Close() error {
+	if err := w.encoder.Close(); err != nil {
+		return err
+	}
+	if w.gzip != nil {
+		return w.gzip.Close()
+	}
+	return nil
+}
+
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+func (w *Writer) Bytes() int64 {
+	return w.digest.n
+}
+
+func (w *Writer) Checksum() string {
+	return hex.EncodeToString(w.digest.hash.Sum(nil))
+}
+
+func ContentType(format Format, compression Compression) string {
+	if compression == CompressionGzip && format != FormatParquet {
+		return "application/gzip"
+	}
+
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func FileName(format Format, compression Compression, at time.Time) string {
+	name := fmt.Sprintf("bets-%s.%s", at.UTC().Format("20060102T150405Z"), format)
+	if compression == CompressionGzip && format != FormatParquet {
+		name += ".gz"
+	}
+	return name
+}
+
+type digestWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	n    int64
+}
+
+func (d *digestWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	d.hash.Write(p[:n])
+	d.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bet/internal/domain"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+)
+
+func testBets(n int) []domain.Bet {
+	createdAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	bets := make([]domain.Bet, n)
+	for i := range bets {
+		bet := domain.NewBet(int64(i+1), float64(10*(i+1)), 2.5)
+		bet.CreatedAt = createdAt.Add(time.Duration(i) * time.Second)
+		bet.Currency = "EUR"
+		if i%2 == 0 {
+			settledAt := bet.CreatedAt.Add(time.Minute)
+			bet.Status, bet.Payout, bet.SettledAt = domain.BetStatusWon, bet.Amount*2.5, &settledAt
+		}
+		bets[i] = *bet
+	}
+	return bets
+}
+
+func decodeRows(t *testing.T, format Format, payload []byte) []Row {
+	t.Helper()
+
+	var rows []Row
+	switch format {
+	case FormatCSV:
+		records, err := csv.NewReader(bytes.NewReader(payload)).ReadAll()
+		if err != nil {
+			t.Fatalf("read csv: %v", err)
+		}
+		if len(records) == 0 || len(records[0]) != len(Columns) {
+			t.Fatalf("csv header = %v, want %v", records, Columns)
+		}
+		for _, record := range records[1:] {
+			rows = append(rows, Row{ID: record[0], Status: record[5], Currency: record[10]})
+		}
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(bytes.NewReader(payload))
+		for scanner.Scan() {
+			var row Row
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				t.Fatalf("decode ndjson line %q: %v", scanner.Text(), err)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func TestWriterChecksumMatchesPayload(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		compression Compression
+		bets        int
+	}{
+		{name: "csv", format: FormatCSV, bets: 3},
+		{name: "csv gzip", format: FormatCSV, compression: CompressionGzip, bets: 3},
+		{name: "csv empty", format: FormatCSV, bets: 0},
+		{name: "ndjson", format: FormatNDJSON, bets: 3},
+		{name: "ndjson gzip", format: FormatNDJSON, compression: CompressionGzip, bets: 3},
+		{name: "ndjson empty", format: FormatNDJSON, bets: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, Config{Format: tt.format, Compression: tt.compression})
+			if err != nil {
+				t.Fatalf("NewWriter: %v", err)
+			}
+
+			bets := testBets(tt.bets)
+			for i, bet := range bets {
+				if err := w.Write(bet); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+				if i == 0 {
+					if err := w.Flush(); err != nil {
+						t.Fatalf("Flush: %v", err)
+					}
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			sum := sha256.Sum256(buf.Bytes())
+			if got, want := w.Checksum(), hex.EncodeToString(sum[:]); got != want {
+				t.Errorf("Checksum = %s, want %s", got, want)
+			}
+			if w.Bytes() != int64(buf.Len()) {
+				t.Errorf("Bytes = %d, want %d", w.Bytes(), buf.Len())
+			}
+			if w.Rows() != len(bets) {
+				t.Errorf("Rows = %d, want %d", w.Rows(), len(bets))
+			}
+
+			payload := buf.Bytes()
+			if tt.compression == CompressionGzip {
+				zr, err := gzip.NewReader(&buf)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				if payload, err = io.ReadAll(zr); err != nil {
+					t.Fatalf("read gzip payload: %v", err)
+				}
+			}
+
+			rows := decodeRows(t, tt.format, payload)
+			if len(rows) != len(bets) {
+				t.Fatalf("payload has %d rows, want %d", len(rows), len(bets))
+			}
+			for i, row := range rows {
+				if row.ID != bets[i].ID || row.Status != string(bets[i].Status) || row.Currency != bets[i].Currency {
+					t.Errorf("row %d = %s/%s/%s, want %s/%s/%s", i, row.ID, row.Status, row.Currency, bets[i].ID, bets[i].Status, bets[i].Currency)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package feed
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const maxClientMessageSize = 4096
+
+type client struct {
+	hub        *Hub
+	conn       *websocket.Conn
+	send       chan []byte
+	remoteAddr string
+
+	filterMu sync.RWMutex
+	current  Filter
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newClient(hub *Hub, conn *websocket.Conn, filter Filter, remoteAddr string) *client {
+	return &client{
+		hub:        hub,
+		conn:       conn,
+		send:       make(chan []byte, hub.config.SendBuffer),
+		remoteAddr: remoteAddr,
+		current:    filter,
+		done:       make(chan struct{}),
+	}
+}
+
+func (c *client) filter() Filter {
+	c.filterMu.RLock()
+	defer c.filterMu.RUnlock()
+	return c.current
+}
+
+func (c *client) setFilter(filter Filter) {
+	c.filterMu.Lock()
+	c.current = filter
+	c.filterMu.Unlock()
+}
+
+func (c *client) close(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+func (c *client) readPump() {
+	defer c.close(websocket.CloseNormalClosure, "")
+
+	c.conn.SetReadLimit(maxClientMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(c.hub.config.PongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.hub.config.PongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.hub.logger.Debug("feed connection closed", zap.String("remote_addr", c.remoteAddr), zap.Error(err))
+			}
+			return
+		}
+
+		var msg subscribeMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Action != actionSubscribe {
+			c.hub.logger.Debug("ignoring feed client message", zap.String("remote_addr", c.remoteAddr))
+			continue
+		}
+
+		filter := Filter{}
+		if msg.UserID != nil && *msg.UserID > 0 {
+			userID := *msg.UserID
+			filter.UserID = &userID
+		}
+		c.setFilter(filter)
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(c.hub.config.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(c.hub.config.WriteWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.hub.config.WriteWait)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-c.done:
+			if c.closeCode != websocket.CloseAbnormalClosure {
+				c.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(c.closeCode, c.closeText),
+					time.Now().Add(c.hub.config.WriteWait),
+				)
+			}
+			return
+		}
+	}
+}
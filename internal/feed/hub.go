@@ -0,0 +1,179 @@
+package feed
+
+import (
+	"bet/internal/game"
+	"bet/internal/metrics"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+var ErrHubClosed = errors.New("feed hub closed")
+
+type Config struct {
+	SendBuffer   int
+	PingInterval time.Duration
+	PongWait     time.Duration
+	WriteWait    time.Duration
+	CheckOrigin  func(r *http.Request) bool
+	Logger       *zap.Logger
+}
+
+type Filter struct {
+	UserID *int64
+}
+
+func (f Filter) Matches(event game.Event) bool {
+	if !event.IsBetEvent() || f.UserID == nil {
+		return true
+	}
+	return event.Bet.UserID == *f.UserID
+}
+
+type Hub struct {
+	config   Config
+	upgrader websocket.Upgrader
+	logger   *zap.Logger
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+func NewHub(config Config) *Hub {
+	if config.SendBuffer <= 0 {
+		config.SendBuffer = 256
+	}
+	if config.PongWait <= 0 {
+		config.PongWait = 60 * time.Second
+	}
+	if config.PingInterval <= 0 || config.PingInterval >= config.PongWait {
+		config.PingInterval = config.PongWait * 9 / 10
+	}
+	if config.WriteWait <= 0 {
+		config.WriteWait = 10 * time.Second
+	}
+
+	return &Hub{
+		config: config,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			CheckOrigin:     config.CheckOrigin,
+		},
+		logger:  config.Logger,
+		clients: make(map[*client]struct{}),
+	}
+}
+
+func (h *Hub) Publish(event game.Event) {
+	msg, err := json.Marshal(messageFromEvent(event))
+	if err != nil {
+		h.logger.Error("failed to encode feed event", zap.String("type", string(event.Type)), zap.Error(err))
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.clients {
+		if !c.filter().Matches(event) {
+			continue
+		}
+
+		select {
+		case c.send <- msg:
+		default:
+			if event.Type == game.EventMultiplierTick {
+				metrics.FeedDroppedTicks.Add(1)
+				continue
+			}
+			metrics.FeedSlowConsumers.Add(1)
+			h.logger.Warn("disconnecting slow feed consumer", zap.String("remote_addr", c.remoteAddr))
+			c.close(websocket.CloseTryAgainLater, "slow consumer")
+		}
+	}
+}
+
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, filter Filter) error {
+	h.mu.RLock()
+	closed := h.closed
+	h.mu.RUnlock()
+	if closed {
+		return ErrHubClosed
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	c := newClient(h, conn, filter, r.RemoteAddr)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		c.close(websocket.CloseGoingAway, "server shutting down")
+		c.writePump()
+		return ErrHubClosed
+	}
+	h.clients[c] = struct{}{}
+	h.wg.Add(2)
+	h.mu.Unlock()
+
+	metrics.FeedConnections.Add(1)
+
+	go func() {
+		defer h.wg.Done()
+		c.writePump()
+	}()
+	go func() {
+		defer h.wg.Done()
+		c.readPump()
+		h.unregister(c)
+	}()
+
+	return nil
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		metrics.FeedConnections.Add(-1)
+	}
+	h.mu.Unlock()
+}
+
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.logger.Info("shutting down feed hub...")
+
+	h.mu.Lock()
+	h.closed = true
+	for c := range h.clients {
+		c.close(websocket.CloseGoingAway, "server shutting down")
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		h.logger.Info("feed hub shutdown complete")
+		return nil
+	case <-ctx.Done():
+		h.logger.Warn("feed hub shutdown timeout")
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"bet/internal/game"
+	"time"
+)
+
+const actionSubscribe = "subscribe"
+
+type subscribeMessage struct {
+	Action string `json:"action"`
+	UserID *int64 `json:"user_id,omitempty"`
+}
+
+type roundMessage struct {
+	ID         string  `json:"id"`
+	Status     string  `json:"status"`
+	CrashPoint float64 `json:"crash_point,omitempty"`
+	OpenedAt   string  `json:"opened_at"`
+	StartedAt  string  `json:"started_at,omitempty"`
+	CrashedAt  string  `json:"crashed_at,omitempty"`
+}
+
+type betMessage struct {
+	ID         string  `json:"id"`
+	UserID     int64   `json:"user_id"`
+	Amount     float64 `json:"amount"`
+	CrashPoint float64 `json:"crash_point"`
+	Status     string  `json:"status"`
+	Payout     float64 `json:"payout"`
+}
+
+type eventMessage struct {
+	Type       string       `json:"type"`
+	Timestamp  string       `json:"timestamp"`
+	Multiplier float64      `json:"multiplier,omitempty"`
+	Round      roundMessage `json:"round"`
+	Bet        *betMessage  `json:"bet,omitempty"`
+}
+
+func messageFromEvent(event game.Event) eventMessage {
+	msg := eventMessage{
+		Type:       string(event.Type),
+		Timestamp:  event.Timestamp.UTC().Format(time.RFC3339Nano),
+		Multiplier: event.Multiplier,
+		Round: roundMessage{
+			ID:         event.Round.ID,
+			Status:     string(event.Round.Status),
+			CrashPoint: event.Round.CrashPoint,
+			OpenedAt:   event.Round.OpenedAt.UTC().Format(time.RFC3339),
+		},
+	}
+
+	if event.Round.StartedAt != nil {
+		msg.Round.StartedAt = event.Round.StartedAt.UTC().Format(time.RFC3339Nano)
+	}
+	if event.Round.CrashedAt != nil {
+		msg.Round.CrashedAt = event.Round.CrashedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	if event.Bet != nil {
+		msg.Bet = &betMessage{
+			ID:         event.Bet.ID,
+			UserID:     event.Bet.UserID,
+			Amount:     event.Bet.Amount,
+			CrashPoint: event.Bet.CrashPoint,
+			Status:     string(event.Bet.Status),
+			Payout:     event.Bet.Payout,
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,251 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	RulesFile      string
+	ReloadInterval time.Duration
+	Logger         *zap.Logger
+	Now            func() time.Time
+}
+
+type Engine struct {
+	config Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	set     *ruleSet
+	raw     []byte
+	history *History
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewEngine(config Config) (*Engine, error) {
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := &Engine{
+		config:  config,
+		logger:  config.Logger,
+		set:     &ruleSet{meta: domain.FraudRuleSet{Rules: []domain.FraudRule{}}},
+		history: newHistory(),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	if config.RulesFile == "" {
+		return e, nil
+	}
+
+	if _, err := e.load(); err != nil {
+		cancel()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Engine) Enabled() bool {
+	return e.config.RulesFile != ""
+}
+
+func (e *Engine) Start() {
+	if !e.Enabled() || e.config.ReloadInterval <= 0 {
+		return
+	}
+
+	e.wg.Add(1)
+	go e.loop()
+}
+
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.logger.Info("shutting down fraud engine...")
+	e.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		e.logger.Info("fraud engine shutdown complete")
+		return nil
+	case <-ctx.Done():
+		e.logger.Warn("fraud engine shutdown timeout")
+		return ctx.Err()
+	}
+}
+
+func (e *Engine) Evaluate(attempts []domain.BetAttempt) []domain.FraudAssessment {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.history.unstage()
+
+	assessments := make([]domain.FraudAssessment, len(attempts))
+	for i, attempt := range attempts {
+		e.history.stage(attempt)
+		assessments[i] = e.evaluate(attempt)
+	}
+	return assessments
+}
+
+func (e *Engine) Record(attempts []domain.BetAttempt) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, attempt := range attempts {
+		e.history.record(attempt.UserID, attempt.Client.IP, attempt.Client.DeviceID, attempt.At, attempt.Amount, attempt.CrashPoint)
+	}
+}
+
+func (e *Engine) evaluate(attempt domain.BetAttempt) domain.FraudAssessment {
+	assessment := domain.FraudAssessment{Attempt: attempt, Action: domain.ActionAllow}
+	for _, compiled := range e.set.rules {
+		reason, hit := compiled.rule.Evaluate(e.history, attempt)
+		if !hit {
+			continue
+		}
+		assessment.Hits = append(assessment.Hits, domain.RuleHit{
+			Rule:   compiled.config.Name,
+			Type:   compiled.config.Type,
+			Score:  compiled.config.Score,
+			Action: compiled.config.Action,
+			Reason: reason,
+		})
+		assessment.Score += compiled.config.Score
+		if compiled.config.Action.Severity() > assessment.Action.Severity() {
+			assessment.Action = compiled.config.Action
+		}
+	}
+
+	if action := thresholdAction(e.set.meta.Thresholds, assessment.Score); action.Severity() > assessment.Action.Severity() {
+		assessment.Action = action
+	}
+	return assessment
+}
+
+func thresholdAction(t domain.FraudThresholds, score float64) domain.FraudAction {
+	switch {
+	case t.Reject > 0 && score >= t.Reject:
+		return domain.ActionReject
+	case t.Hold > 0 && score >= t.Hold:
+		return domain.ActionHold
+	case t.Flag > 0 && score >= t.Flag:
+		return domain.ActionFlag
+	default:
+		return domain.ActionAllow
+	}
+}
+
+func (e *Engine) Rules() (domain.FraudRuleSet, error) {
+	if !e.Enabled() {
+		return domain.FraudRuleSet{}, domain.ErrFraudRulesDisabled
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.set.meta, nil
+}
+
+func (e *Engine) Reload() (domain.FraudRuleSet, error) {
+	if !e.Enabled() {
+		return domain.FraudRuleSet{}, domain.ErrFraudRulesDisabled
+	}
+
+	set, err := e.load()
+	if err != nil {
+		metrics.FraudRuleReloadFailures.Add(1)
+		return domain.FraudRuleSet{}, &domain.ConflictError{Reason: "FRAUD_RULES_INVALID", Message: err.Error()}
+	}
+	return set.meta, nil
+}
+
+func (e *Engine) load() (*ruleSet, error) {
+	data, err := os.ReadFile(e.config.RulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.raw != nil && bytes.Equal(data, e.raw) {
+		return e.set, nil
+	}
+
+	set, err := parseRules(data, e.config.RulesFile, e.config.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := 1
+	horizon, syncSpan := time.Duration(0), time.Duration(0)
+	for _, rule := range set.meta.Rules {
+		capacity = max(capacity, rule.MaxBets+1, rule.Samples+1)
+		horizon = max(horizon, rule.Window)
+		if rule.MinUsers > 0 {
+			syncSpan = max(syncSpan, rule.Window)
+		}
+	}
+	e.history.resize(capacity, horizon, syncSpan)
+
+	e.set = set
+	e.raw = data
+	metrics.FraudRuleReloads.Add(1)
+	e.logger.Info("fraud rules loaded",
+		zap.String("file", e.config.RulesFile),
+		zap.String("checksum", set.meta.Checksum),
+		zap.Int("rules", len(set.rules)),
+	)
+	return set, nil
+}
+
+func (e *Engine) loop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.config.ReloadInterval)
+	defer ticker.Stop()
+
+	var lastErr string
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := e.load()
+		switch {
+		case err == nil:
+			lastErr = ""
+		case err.Error() != lastErr:
+			lastErr = err.Error()
+			metrics.FraudRuleReloadFailures.Add(1)
+			e.logger.Error("failed to reload fraud rules, keeping the previous rules", zap.String("file", e.config.RulesFile), zap.Error(err))
+		}
+
+		e.mu.Lock()
+		e.history.sweep(e.config.Now())
+		e.mu.Unlock()
+	}
+}
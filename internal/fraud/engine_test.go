@@ -0,0 +1,148 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+
+const velocityRules = `
+rules:
+  - name: fast-bettor
+    type: velocity
+    window: 1m
+    max_bets: 2
+    action: reject
+`
+
+func newTestEngine(t *testing.T, rules string) (*Engine, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := NewEngine(Config{RulesFile: path, Now: func() time.Time { return testNow }})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	return e, path
+}
+
+func attemptAt(userID int64, offset time.Duration) domain.BetAttempt {
+	return domain.BetAttempt{
+		UserID:     userID,
+		Amount:     10,
+		CrashPoint: 2,
+		Client:     domain.Client{IP: "203.0.113.7"},
+		At:         testNow.Add(offset),
+	}
+}
+
+func TestEngineEvaluateDoesNotRecordHistory(t *testing.T) {
+	e, _ := newTestEngine(t, velocityRules)
+
+	for i := range 5 {
+		assessments := e.Evaluate([]domain.BetAttempt{attemptAt(1, time.Duration(i)*time.Second)})
+		if assessments[0].Action != domain.ActionAllow {
+			t.Fatalf("attempt %d: action = %s, want allow while nothing was placed", i, assessments[0].Action)
+		}
+	}
+
+	e.Record([]domain.BetAttempt{attemptAt(1, 5*time.Second), attemptAt(1, 6*time.Second)})
+
+	assessments := e.Evaluate([]domain.BetAttempt{attemptAt(1, 7*time.Second)})
+	if assessments[0].Action != domain.ActionReject {
+		t.Fatalf("action = %s, want reject after two placed bets", assessments[0].Action)
+	}
+}
+
+func TestEngineEvaluateScoresBatchItemsTogether(t *testing.T) {
+	e, _ := newTestEngine(t, velocityRules)
+
+	assessments := e.Evaluate([]domain.BetAttempt{
+		attemptAt(1, 0),
+		attemptAt(1, time.Second),
+		attemptAt(2, time.Second),
+		attemptAt(1, 2*time.Second),
+	})
+
+	want := []domain.FraudAction{domain.ActionAllow, domain.ActionAllow, domain.ActionAllow, domain.ActionReject}
+	for i, assessment := range assessments {
+		if assessment.Action != want[i] {
+			t.Errorf("attempt %d: action = %s, want %s", i, assessment.Action, want[i])
+		}
+	}
+
+	if got := e.Evaluate([]domain.BetAttempt{attemptAt(1, 3*time.Second)}); got[0].Action != domain.ActionAllow {
+		t.Fatalf("action = %s, want allow because the batch was never placed", got[0].Action)
+	}
+}
+
+func TestEngineSharedIPCountsStagedUsers(t *testing.T) {
+	e, _ := newTestEngine(t, `
+rules:
+  - name: shared-ip
+    type: shared_ip
+    window: 1m
+    max_users: 1
+    action: hold
+`)
+
+	e.Record([]domain.BetAttempt{attemptAt(1, 0)})
+
+	if got := e.Evaluate([]domain.BetAttempt{attemptAt(1, time.Second)}); got[0].Action != domain.ActionAllow {
+		t.Fatalf("same user: action = %s, want allow", got[0].Action)
+	}
+	if got := e.Evaluate([]domain.BetAttempt{attemptAt(2, time.Second)}); got[0].Action != domain.ActionHold {
+		t.Fatalf("second user: action = %s, want hold", got[0].Action)
+	}
+}
+
+func TestEngineReloadKeepsPreviousRulesOnError(t *testing.T) {
+	e, path := newTestEngine(t, velocityRules)
+
+	before, err := e.Rules()
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n  - name: broken\n    type: nope\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.Reload()
+	var conflict *domain.ConflictError
+	if !errors.As(err, &conflict) || conflict.Reason != "FRAUD_RULES_INVALID" {
+		t.Fatalf("Reload error = %v, want FRAUD_RULES_INVALID", err)
+	}
+
+	after, err := e.Rules()
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+	if after.Checksum != before.Checksum || len(after.Rules) != 1 || after.Rules[0].Name != "fast-bettor" {
+		t.Fatalf("rules after failed reload = %+v, want the previous set", after)
+	}
+
+	e.Record([]domain.BetAttempt{attemptAt(1, 0), attemptAt(1, time.Second)})
+	if got := e.Evaluate([]domain.BetAttempt{attemptAt(1, 2*time.Second)}); got[0].Action != domain.ActionReject {
+		t.Fatalf("action = %s, want the previous velocity rule to still apply", got[0].Action)
+	}
+
+	if err := os.WriteFile(path, []byte(velocityRules+"thresholds:\n  flag: 5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := e.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if reloaded.Checksum == before.Checksum || reloaded.Thresholds.Flag != 5 {
+		t.Fatalf("rules after reload = %+v, want the new file", reloaded)
+	}
+}
@@ -0,0 +1,160 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type fileThresholds struct {
+	Flag   float64 `yaml:"flag"`
+	Hold   float64 `yaml:"hold"`
+	Reject float64 `yaml:"reject"`
+}
+
+type fileRule struct {
+	Name      string  `yaml:"name"`
+	Type      string  `yaml:"type"`
+	Enabled   *bool   `yaml:"enabled"`
+	Score     float64 `yaml:"score"`
+	Action    string  `yaml:"action"`
+	Window    string  `yaml:"window"`
+	MaxBets   int     `yaml:"max_bets"`
+	MaxUsers  int     `yaml:"max_users"`
+	MinUsers  int     `yaml:"min_users"`
+	Samples   int     `yaml:"samples"`
+	MaxJitter float64 `yaml:"max_jitter"`
+	Tolerance float64 `yaml:"tolerance"`
+}
+
+type rulesFile struct {
+	Thresholds fileThresholds `yaml:"thresholds"`
+	Rules      []fileRule     `yaml:"rules"`
+}
+
+type compiledRule struct {
+	config domain.FraudRule
+	rule   Rule
+}
+
+type ruleSet struct {
+	meta  domain.FraudRuleSet
+	rules []compiledRule
+}
+
+func parseRules(data []byte, source string, loadedAt time.Time) (*ruleSet, error) {
+	var file rulesFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse %s: %w", source, err)
+	}
+
+	thresholds := domain.FraudThresholds(file.Thresholds)
+	if err := validateThresholds(thresholds); err != nil {
+		return nil, fmt.Errorf("%s: thresholds: %w", source, err)
+	}
+
+	checksum := sha256.Sum256(data)
+	set := &ruleSet{meta: domain.FraudRuleSet{
+		Source:     source,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		LoadedAt:   loadedAt,
+		Thresholds: thresholds,
+		Rules:      []domain.FraudRule{},
+	}}
+
+	names := make(map[string]bool)
+	for i, fr := range file.Rules {
+		if fr.Name == "" {
+			return nil, fmt.Errorf("%s: rules[%d]: name is required", source, i)
+		}
+		if names[fr.Name] {
+			return nil, fmt.Errorf("%s: rule %q: duplicate name", source, fr.Name)
+		}
+		names[fr.Name] = true
+
+		if fr.Enabled != nil && !*fr.Enabled {
+			continue
+		}
+
+		config, err := fr.config()
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule %q: %w", source, fr.Name, err)
+		}
+		factory, ok := factoryFor(config.Type)
+		if !ok {
+			return nil, fmt.Errorf("%s: rule %q: unknown type %q", source, fr.Name, config.Type)
+		}
+		rule, err := factory(config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: rule %q: %w", source, fr.Name, err)
+		}
+
+		set.rules = append(set.rules, compiledRule{config: config, rule: rule})
+		set.meta.Rules = append(set.meta.Rules, config)
+	}
+
+	return set, nil
+}
+
+func (fr fileRule) config() (domain.FraudRule, error) {
+	config := domain.FraudRule{
+		Name:      fr.Name,
+		Type:      fr.Type,
+		Score:     fr.Score,
+		Action:    domain.FraudAction(fr.Action),
+		MaxBets:   fr.MaxBets,
+		MaxUsers:  fr.MaxUsers,
+		MinUsers:  fr.MinUsers,
+		Samples:   fr.Samples,
+		MaxJitter: fr.MaxJitter,
+		Tolerance: fr.Tolerance,
+	}
+	if config.Action == "" {
+		config.Action = domain.ActionFlag
+	}
+	if !config.Action.Valid() {
+		return domain.FraudRule{}, fmt.Errorf("unknown action %q", fr.Action)
+	}
+	if config.Score < 0 {
+		return domain.FraudRule{}, fmt.Errorf("score must not be negative")
+	}
+	if fr.Window != "" {
+		window, err := time.ParseDuration(fr.Window)
+		if err != nil {
+			return domain.FraudRule{}, fmt.Errorf("invalid window: %w", err)
+		}
+		config.Window = window
+	}
+	return config, nil
+}
+
+func validateThresholds(t domain.FraudThresholds) error {
+	levels := []struct {
+		name  string
+		value float64
+	}{{"flag", t.Flag}, {"hold", t.Hold}, {"reject", t.Reject}}
+
+	previous := 0.0
+	for _, level := range levels {
+		if level.value < 0 {
+			return fmt.Errorf("%s must not be negative", level.name)
+		}
+		if level.value == 0 {
+			continue
+		}
+		if level.value < previous {
+			return fmt.Errorf("%s must not be lower than the thresholds before it", level.name)
+		}
+		previous = level.value
+	}
+	return nil
+}
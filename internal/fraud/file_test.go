@@ -0,0 +1,217 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantErr   string
+		wantRules []string
+	}{
+		{
+			name:      "empty file",
+			data:      "",
+			wantRules: []string{},
+		},
+		{
+			name: "valid rules",
+			data: `
+thresholds:
+  flag: 10
+  hold: 20
+  reject: 30
+rules:
+  - name: fast
+    type: velocity
+    window: 30s
+    max_bets: 5
+    score: 10
+  - name: same-amount
+    type: amount_pattern
+    samples: 4
+    tolerance: 0.05
+    action: hold
+`,
+			wantRules: []string{"fast", "same-amount"},
+		},
+		{
+			name: "disabled rules are skipped without validation",
+			data: `
+rules:
+  - name: off
+    type: nope
+    enabled: false
+  - name: fast
+    type: velocity
+    window: 30s
+    max_bets: 5
+`,
+			wantRules: []string{"fast"},
+		},
+		{
+			name:    "unknown field",
+			data:    "rules:\n  - name: fast\n    type: velocity\n    windw: 30s\n",
+			wantErr: "field windw not found",
+		},
+		{
+			name:    "missing name",
+			data:    "rules:\n  - type: velocity\n",
+			wantErr: "rules[0]: name is required",
+		},
+		{
+			name:    "duplicate name",
+			data:    "rules:\n  - name: a\n    type: velocity\n    window: 1m\n    max_bets: 1\n  - name: a\n    type: velocity\n",
+			wantErr: `rule "a": duplicate name`,
+		},
+		{
+			name:    "unknown type",
+			data:    "rules:\n  - name: a\n    type: nope\n",
+			wantErr: `unknown type "nope"`,
+		},
+		{
+			name:    "unknown action",
+			data:    "rules:\n  - name: a\n    type: velocity\n    action: ban\n",
+			wantErr: `unknown action "ban"`,
+		},
+		{
+			name:    "negative score",
+			data:    "rules:\n  - name: a\n    type: velocity\n    score: -1\n",
+			wantErr: "score must not be negative",
+		},
+		{
+			name:    "invalid window",
+			data:    "rules:\n  - name: a\n    type: velocity\n    window: soon\n",
+			wantErr: "invalid window",
+		},
+		{
+			name:    "rule settings are validated by the factory",
+			data:    "rules:\n  - name: a\n    type: velocity\n    window: 1m\n",
+			wantErr: "max_bets must be at least 1",
+		},
+		{
+			name:    "negative threshold",
+			data:    "thresholds:\n  flag: -1\n",
+			wantErr: "thresholds: flag must not be negative",
+		},
+		{
+			name:    "thresholds out of order",
+			data:    "thresholds:\n  flag: 20\n  hold: 10\n",
+			wantErr: "thresholds: hold must not be lower than the thresholds before it",
+		},
+		{
+			name:      "unset thresholds are skipped when ordering",
+			data:      "thresholds:\n  flag: 20\n  reject: 30\n",
+			wantRules: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := parseRules([]byte(tt.data), "rules.yaml", testNow)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseRules error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if !strings.HasPrefix(err.Error(), "rules.yaml") && !strings.HasPrefix(err.Error(), "parse rules.yaml") {
+					t.Errorf("parseRules error = %q, want it to name the source", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRules: %v", err)
+			}
+
+			names := []string{}
+			for _, rule := range set.meta.Rules {
+				names = append(names, rule.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("rules = %v, want %v", names, tt.wantRules)
+			}
+			if len(set.rules) != len(set.meta.Rules) {
+				t.Errorf("compiled %d rules for %d configs", len(set.rules), len(set.meta.Rules))
+			}
+			if len(set.meta.Checksum) != 64 || !set.meta.LoadedAt.Equal(testNow) || set.meta.Source != "rules.yaml" {
+				t.Errorf("meta = %+v, want checksum, source and load time", set.meta)
+			}
+		})
+	}
+}
+
+func TestParseRulesDefaults(t *testing.T) {
+	set, err := parseRules([]byte("rules:\n  - name: fast\n    type: velocity\n    window: 90s\n    max_bets: 3\n"), "rules.yaml", testNow)
+	if err != nil {
+		t.Fatalf("parseRules: %v", err)
+	}
+
+	rule := set.meta.Rules[0]
+	if rule.Action != domain.ActionFlag {
+		t.Errorf("action = %s, want the flag default", rule.Action)
+	}
+	if rule.Window != 90*time.Second || rule.MaxBets != 3 {
+		t.Errorf("rule = %+v, want window 90s and max_bets 3", rule)
+	}
+}
+
+func TestThresholdAction(t *testing.T) {
+	thresholds := domain.FraudThresholds{Flag: 10, Hold: 20, Reject: 30}
+
+	tests := []struct {
+		name       string
+		thresholds domain.FraudThresholds
+		score      float64
+		want       domain.FraudAction
+	}{
+		{"below every threshold", thresholds, 9.99, domain.ActionAllow},
+		{"at the flag threshold", thresholds, 10, domain.ActionFlag},
+		{"between flag and hold", thresholds, 19, domain.ActionFlag},
+		{"at the hold threshold", thresholds, 20, domain.ActionHold},
+		{"at the reject threshold", thresholds, 30, domain.ActionReject},
+		{"above every threshold", thresholds, 100, domain.ActionReject},
+		{"no thresholds", domain.FraudThresholds{}, 100, domain.ActionAllow},
+		{"unset hold falls through to flag", domain.FraudThresholds{Flag: 10, Reject: 30}, 25, domain.ActionFlag},
+		{"reject only", domain.FraudThresholds{Reject: 30}, 29, domain.ActionAllow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thresholdAction(tt.thresholds, tt.score); got != tt.want {
+				t.Errorf("thresholdAction(%v) = %s, want %s", tt.score, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngineThresholdRaisesAction(t *testing.T) {
+	e, _ := newTestEngine(t, `
+thresholds:
+  hold: 15
+rules:
+  - name: fast
+    type: velocity
+    window: 1m
+    max_bets: 1
+    score: 10
+  - name: same-amount
+    type: amount_pattern
+    samples: 2
+    tolerance: 0.01
+    score: 10
+`)
+
+	e.Record([]domain.BetAttempt{attemptAt(1, 0), attemptAt(1, time.Second)})
+	assessment := e.Evaluate([]domain.BetAttempt{attemptAt(1, 2*time.Second)})[0]
+
+	if assessment.Score != 20 || len(assessment.Hits) != 2 {
+		t.Fatalf("assessment = %+v, want both rules to hit for 20 points", assessment)
+	}
+	if assessment.Action != domain.ActionHold {
+		t.Fatalf("action = %s, want hold from the score threshold", assessment.Action)
+	}
+}
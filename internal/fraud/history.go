@@ -0,0 +1,182 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"slices"
+	"time"
+)
+
+const defaultHorizon = time.Hour
+
+type Record struct {
+	At         time.Time
+	Amount     float64
+	CrashPoint float64
+}
+
+type userHistory struct {
+	attempts []Record
+}
+
+type syncRecord struct {
+	at         time.Time
+	userID     int64
+	crashPoint float64
+}
+
+type History struct {
+	users    map[int64]*userHistory
+	ips      map[string]map[int64]time.Time
+	devices  map[string]map[int64]time.Time
+	recent   []syncRecord
+	staged   []domain.BetAttempt
+	capacity int
+	horizon  time.Duration
+	syncSpan time.Duration
+}
+
+func newHistory() *History {
+	return &History{
+		users:    make(map[int64]*userHistory),
+		ips:      make(map[string]map[int64]time.Time),
+		devices:  make(map[string]map[int64]time.Time),
+		capacity: 1,
+		horizon:  defaultHorizon,
+	}
+}
+
+func (h *History) resize(capacity int, horizon, syncSpan time.Duration) {
+	h.capacity = max(capacity, 1)
+	h.horizon = max(horizon, defaultHorizon)
+	h.syncSpan = syncSpan
+}
+
+func (h *History) record(userID int64, ip, device string, at time.Time, amount, crashPoint float64) {
+	u, ok := h.users[userID]
+	if !ok {
+		u = &userHistory{}
+		h.users[userID] = u
+	}
+	u.attempts = append(u.attempts, Record{At: at, Amount: amount, CrashPoint: crashPoint})
+	if excess := len(u.attempts) - h.capacity; excess > 0 {
+		u.attempts = append(u.attempts[:0], u.attempts[excess:]...)
+	}
+
+	touch(h.ips, ip, userID, at)
+	touch(h.devices, device, userID, at)
+
+	if h.syncSpan > 0 {
+		cutoff := at.Add(-h.syncSpan)
+		keep := 0
+		for keep < len(h.recent) && h.recent[keep].at.Before(cutoff) {
+			keep++
+		}
+		h.recent = append(h.recent[keep:], syncRecord{at: at, userID: userID, crashPoint: crashPoint})
+	}
+}
+
+func (h *History) stage(attempt domain.BetAttempt) {
+	h.staged = append(h.staged, attempt)
+}
+
+func (h *History) unstage() {
+	h.staged = h.staged[:0]
+}
+
+func touch(index map[string]map[int64]time.Time, key string, userID int64, at time.Time) {
+	if key == "" {
+		return
+	}
+	users, ok := index[key]
+	if !ok {
+		users = make(map[int64]time.Time)
+		index[key] = users
+	}
+	users[userID] = at
+}
+
+func (h *History) Attempts(userID int64) []Record {
+	var records []Record
+	if u, ok := h.users[userID]; ok {
+		records = u.attempts
+	}
+
+	var staged []Record
+	for _, attempt := range h.staged {
+		if attempt.UserID == userID {
+			staged = append(staged, Record{At: attempt.At, Amount: attempt.Amount, CrashPoint: attempt.CrashPoint})
+		}
+	}
+	if len(staged) == 0 {
+		return records
+	}
+
+	records = append(slices.Clone(records), staged...)
+	if excess := len(records) - h.capacity; excess > 0 {
+		records = records[excess:]
+	}
+	return records
+}
+
+func (h *History) SharedIP(ip string, since time.Time) int {
+	return h.countSince(h.ips[ip], since, func(c domain.Client) bool { return c.IP == ip })
+}
+
+func (h *History) SharedDevice(device string, since time.Time) int {
+	return h.countSince(h.devices[device], since, func(c domain.Client) bool { return c.DeviceID == device })
+}
+
+func (h *History) countSince(users map[int64]time.Time, since time.Time, matches func(domain.Client) bool) int {
+	seen := make(map[int64]struct{})
+	for userID, at := range users {
+		if !at.Before(since) {
+			seen[userID] = struct{}{}
+		}
+	}
+	for _, attempt := range h.staged {
+		if matches(attempt.Client) && !attempt.At.Before(since) {
+			seen[attempt.UserID] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+func (h *History) Synchronized(crashPoint float64, at time.Time, window time.Duration) int {
+	users := make(map[int64]struct{})
+	for _, attempt := range h.staged {
+		if attempt.CrashPoint == crashPoint && !attempt.At.Before(at.Add(-window)) {
+			users[attempt.UserID] = struct{}{}
+		}
+	}
+	for i := len(h.recent) - 1; i >= 0; i-- {
+		r := h.recent[i]
+		if r.at.Before(at.Add(-window)) {
+			break
+		}
+		if r.crashPoint == crashPoint {
+			users[r.userID] = struct{}{}
+		}
+	}
+	return len(users)
+}
+
+func (h *History) sweep(now time.Time) {
+	cutoff := now.Add(-h.horizon)
+	for userID, u := range h.users {
+		if len(u.attempts) == 0 || u.attempts[len(u.attempts)-1].At.Before(cutoff) {
+			delete(h.users, userID)
+		}
+	}
+	for _, index := range []map[string]map[int64]time.Time{h.ips, h.devices} {
+		for key, users := range index {
+			for userID, seen := range users {
+				if seen.Before(cutoff) {
+					delete(users, userID)
+				}
+			}
+			if len(users) == 0 {
+				delete(index, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package fraud
+
+import (
+	"bet/internal/domain"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Rule interface {
+	Evaluate(h *History, attempt domain.BetAttempt) (string, bool)
+}
+
+type Factory func(config domain.FraudRule) (Rule, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{
+		"velocity":          newVelocityRule,
+		"shared_ip":         newSharedIPRule,
+		"shared_device":     newSharedDeviceRule,
+		"timing_regularity": newTimingRule,
+		"amount_pattern":    newAmountRule,
+		"synchronized":      newSynchronizedRule,
+	}
+)
+
+func Register(ruleType string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	factories[ruleType] = factory
+}
+
+func RuleTypes() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	types := make([]string, 0, len(factories))
+	for ruleType := range factories {
+		types = append(types, ruleType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func factoryFor(ruleType string) (Factory, bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	factory, ok := factories[ruleType]
+	return factory, ok
+}
+
+type velocityRule struct {
+	window  time.Duration
+	maxBets int
+}
+
+func newVelocityRule(config domain.FraudRule) (Rule, error) {
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("window must be positive")
+	}
+	if config.MaxBets < 1 {
+		return nil, fmt.Errorf("max_bets must be at least 1")
+	}
+	return velocityRule{window: config.Window, maxBets: config.MaxBets}, nil
+}
+
+func (r velocityRule) Evaluate(h *History, attempt domain.BetAttempt) (string, bool) {
+	since := attempt.At.Add(-r.window)
+	count := 0
+	for _, record := range h.Attempts(attempt.UserID) {
+		if !record.At.Before(since) {
+			count++
+		}
+	}
+	if count <= r.maxBets {
+		return "", false
+	}
+	return fmt.Sprintf("%d bets in %s", count, r.window), true
+}
+
+type sharedRule struct {
+	label    string
+	window   time.Duration
+	maxUsers int
+	key      func(domain.Client) string
+	count    func(h *History, key string, since time.Time) int
+}
+
+func newSharedRule(config domain.FraudRule, label string, key func(domain.Client) string, count func(*History, string, time.Time) int) (Rule, error) {
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("window must be positive")
+	}
+	if config.MaxUsers < 1 {
+		return nil, fmt.Errorf("max_users must be at least 1")
+	}
+	return sharedRule{label: label, window: config.Window, maxUsers: config.MaxUsers, key: key, count: count}, nil
+}
+
+func newSharedIPRule(config domain.FraudRule) (Rule, error) {
+	return newSharedRule(config, "IP", func(c domain.Client) string { return c.IP }, (*History).SharedIP)
+}
+
+func newSharedDeviceRule(config domain.FraudRule) (Rule, error) {
+	return newSharedRule(config, "device", func(c domain.Client) string { return c.DeviceID }, (*History).SharedDevice)
+}
+
+func (r sharedRule) Evaluate(h *History, attempt domain.BetAttempt) (string, bool) {
+	key := r.key(attempt.Client)
+	if key == "" {
+		return "", false
+	}
+	users := r.count(h, key, attempt.At.Add(-r.window))
+	if users <= r.maxUsers {
+		return "", false
+	}
+	return fmt.Sprintf("%d users on %s %s in %s", users, r.label, key, r.window), true
+}
+
+type timingRule struct {
+	samples   int
+	maxJitter float64
+}
+
+func newTimingRule(config domain.FraudRule) (Rule, error) {
+	if config.Samples < 3 {
+		return nil, fmt.Errorf("samples must be at least 3")
+	}
+	if config.MaxJitter <= 0 || config.MaxJitter >= 1 {
+		return nil, fmt.Errorf("max_jitter must be between 0 and 1")
+	}
+	return timingRule{samples: config.Samples, maxJitter: config.MaxJitter}, nil
+}
+
+func (r timingRule) Evaluate(h *History, attempt domain.BetAttempt) (string, bool) {
+	records := h.Attempts(attempt.UserID)
+	if len(records) < r.samples+1 {
+		return "", false
+	}
+	records = records[len(records)-r.samples-1:]
+
+	intervals := make([]float64, r.samples)
+	var mean float64
+	for i := range intervals {
+		intervals[i] = float64(records[i+1].At.Sub(records[i].At).Milliseconds())
+		mean += intervals[i]
+	}
+	mean /= float64(r.samples)
+	if mean <= 0 {
+		return fmt.Sprintf("last %d bets placed at the same millisecond", r.samples+1), true
+	}
+
+	var variance float64
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	jitter := math.Sqrt(variance/float64(r.samples)) / mean
+	if jitter > r.maxJitter {
+		return "", false
+	}
+	return fmt.Sprintf("last %d bets %.0fms apart with %.1f%% jitter", r.samples+1, mean, jitter*100), true
+}
+
+type amountRule struct {
+	samples   int
+	tolerance float64
+}
+
+func newAmountRule(config domain.FraudRule) (Rule, error) {
+	if config.Samples < 2 {
+		return nil, fmt.Errorf("samples must be at least 2")
+	}
+	if config.Tolerance < 0 || config.Tolerance >= 1 {
+		return nil, fmt.Errorf("tolerance must be between 0 and 1")
+	}
+	return amountRule{samples: config.Samples, tolerance: config.Tolerance}, nil
+}
+
+func (r amountRule) Evaluate(h *History, attempt domain.BetAttempt) (string, bool) {
+	records := h.Attempts(attempt.UserID)
+	if len(records) < r.samples {
+		return "", false
+	}
+	for _, record := range records[len(records)-r.samples:] {
+		if math.Abs(record.Amount-attempt.Amount) > attempt.Amount*r.tolerance+1e-9 {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("last %d bets of %.2f", r.samples, attempt.Amount), true
+}
+
+type synchronizedRule struct {
+	window   time.Duration
+	minUsers int
+}
+
+func newSynchronizedRule(config domain.FraudRule) (Rule, error) {
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("window must be positive")
+	}
+	if config.MinUsers < 2 {
+		return nil, fmt.Errorf("min_users must be at least 2")
+	}
+	return synchronizedRule{window: config.Window, minUsers: config.MinUsers}, nil
+}
+
+func (r synchronizedRule) Evaluate(h *History, attempt domain.BetAttempt) (string, bool) {
+	users := h.Synchronized(attempt.CrashPoint, attempt.At, r.window)
+	if users < r.minUsers {
+		return "", false
+	}
+	return fmt.Sprintf("%d users cashing out at %.2fx within %s", users, attempt.CrashPoint, r.window), true
+}
@@ -0,0 +1,674 @@
+package game
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/metrics"
+	"bet/internal/repository"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	growthRate           = 0.06
+	maxRoundsKept        = 100
+	settleTimeout        = 10 * time.Second
+	settleAttempts       = 3
+	settleBackoff        = 100 * time.Millisecond
+	defaultHouseEdge     = 0.01
+	defaultMaxCrashPoint = 1000.0
+)
+
+var ErrEngineStopped = errors.New("game engine stopped")
+
+var errNotPending = errors.New("bet is no longer pending")
+
+type Config struct {
+	BettingPhase  time.Duration
+	TickInterval  time.Duration
+	Cooldown      time.Duration
+	HouseEdge     float64
+	MaxCrashPoint float64
+	Risk          Risk
+	Logger        *zap.Logger
+}
+
+type roundState struct {
+	round      domain.Round
+	bets       map[string]*domain.Bet
+	cashedOut  map[string]bool
+	multiplier float64
+	closing    bool
+	placing    sync.WaitGroup
+}
+
+type settlement struct {
+	round *roundState
+	won   bool
+	at    time.Time
+}
+
+func newRoundState(round *domain.Round) *roundState {
+	return &roundState{
+		round:      *round,
+		bets:       make(map[string]*domain.Bet),
+		cashedOut:  make(map[string]bool),
+		multiplier: 1.0,
+	}
+}
+
+type Risk interface {
+	Reserve(roundID string, bets []*domain.Bet) error
+	Release(roundID, betID string)
+	Settle(roundID string)
+}
+
+type noRisk struct{}
+
+func (noRisk) Reserve(string, []*domain.Bet) error { return nil }
+func (noRisk) Release(string, string)              {}
+func (noRisk) Settle(string)                       {}
+
+type Engine struct {
+	config    Config
+	repo      repository.BetRepository
+	publisher Publisher
+	risk      Risk
+	logger    *zap.Logger
+
+	mu      sync.Mutex
+	current *roundState
+	next    *roundState
+	history []domain.RoundSummary
+	stopped bool
+
+	unsettled map[string]settlement
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewEngine(config Config, repo repository.BetRepository, publisher Publisher) *Engine {
+	if config.BettingPhase <= 0 {
+		config.BettingPhase = 5 * time.Second
+	}
+	if config.TickInterval <= 0 {
+		config.TickInterval = 100 * time.Millisecond
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 3 * time.Second
+	}
+	if config.HouseEdge <= 0 || config.HouseEdge >= 1 {
+		config.HouseEdge = defaultHouseEdge
+	}
+	if config.MaxCrashPoint <= 1 {
+		config.MaxCrashPoint = defaultMaxCrashPoint
+	}
+	if config.Risk == nil {
+		config.Risk = noRisk{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Engine{
+		config:    config,
+		repo:      repo,
+		publisher: publisher,
+		risk:      config.Risk,
+		logger:    config.Logger,
+		current:   newRoundState(domain.NewRound()),
+		next:      newRoundState(domain.NewRound()),
+		unsettled: make(map[string]settlement),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func (e *Engine) Start() {
+	e.wg.Add(1)
+	go e.run()
+}
+
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.logger.Info("shutting down game engine...")
+
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+	e.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		e.logger.Info("game engine shutdown complete")
+		return nil
+	case <-ctx.Done():
+		e.logger.Warn("game engine shutdown timeout")
+		return ctx.Err()
+	}
+}
+
+func (e *Engine) PlaceBet(ctx context.Context, bet *domain.Bet) error {
+	bets := []*domain.Bet{bet}
+	target, err := e.reserve(bets)
+	if err != nil {
+		return err
+	}
+
+	err = e.repo.Create(ctx, bet, events.BetPlaced{Bet: *bet, At: bet.CreatedAt})
+	e.admit(target, bets, err)
+	return err
+}
+
+func (e *Engine) PlaceBets(ctx context.Context, bets []*domain.Bet) error {
+	target, err := e.reserve(bets)
+	if err != nil {
+		return err
+	}
+
+	outbox := make([]events.Event, len(bets))
+	for i, bet := range bets {
+		outbox[i] = events.BetPlaced{Bet: *bet, At: bet.CreatedAt}
+	}
+
+	err = e.repo.CreateBatch(ctx, bets, outbox...)
+	e.admit(target, bets, err)
+	return err
+}
+
+func (e *Engine) reserve(bets []*domain.Bet) (*roundState, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil, ErrEngineStopped
+	}
+
+	target := e.current
+	if target.round.Status != domain.RoundStatusBetting || target.closing {
+		target = e.next
+	}
+
+	if err := e.risk.Reserve(target.round.ID, bets); err != nil {
+		return nil, err
+	}
+
+	for _, bet := range bets {
+		bet.RoundID = target.round.ID
+	}
+	target.placing.Add(1)
+
+	return target, nil
+}
+
+func (e *Engine) admit(target *roundState, bets []*domain.Bet, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer target.placing.Done()
+
+	if err != nil {
+		for _, bet := range bets {
+			e.risk.Release(target.round.ID, bet.ID)
+			bet.RoundID = ""
+		}
+		return
+	}
+
+	for _, bet := range bets {
+		betCopy := *bet
+		target.bets[bet.ID] = &betCopy
+		e.publish(EventBetPlaced, target, &betCopy)
+	}
+}
+
+func (e *Engine) CancelBet(ctx context.Context, id string, actor domain.Actor) (*domain.Bet, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil, ErrEngineStopped
+	}
+
+	var state *roundState
+	bet, err := e.repo.Modify(ctx, id, func(bet *domain.Bet) (repository.Change, error) {
+		if !actor.ActsFor(bet.UserID) {
+			return repository.Change{}, domain.ErrNotUsersKey
+		}
+
+		state = e.bettingRound(bet.RoundID)
+		if state == nil || state.bets[bet.ID] == nil {
+			return repository.Change{}, domain.ErrBetNotCancellable
+		}
+
+		from := bet.Status
+		now := time.Now()
+		if err := bet.Cancel(now); err != nil {
+			return repository.Change{}, err
+		}
+		return repository.Change{
+			Outbox: []events.Event{events.BetCancelled{Bet: *bet, At: now}},
+			Audit:  []domain.AuditEntry{domain.NewBetAuditEntry(domain.AuditActionBetCancelled, actor, from, *bet)},
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delete(state.bets, id)
+	e.risk.Release(state.round.ID, id)
+	e.publish(EventBetCancelled, state, bet)
+
+	return bet, nil
+}
+
+func (e *Engine) VoidBet(ctx context.Context, id string, req domain.VoidBetRequest, actor domain.Actor) (*domain.Bet, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return nil, ErrEngineStopped
+	}
+
+	bet, err := e.repo.Modify(ctx, id, func(bet *domain.Bet) (repository.Change, error) {
+		if state := e.liveRound(bet.RoundID); state != nil && state.bets[bet.ID] == nil && bet.Status == domain.BetStatusPending {
+			return repository.Change{}, domain.ErrBetNotVoidable
+		}
+
+		from := bet.Status
+		now := time.Now()
+		if err := bet.Void(req.Reason, now); err != nil {
+			return repository.Change{}, err
+		}
+
+		entry := domain.NewBetAuditEntry(domain.AuditActionBetVoided, actor, from, *bet)
+		entry.Note = req.Note
+		return repository.Change{
+			Outbox: []events.Event{events.BetVoided{Bet: *bet, At: now}},
+			Audit:  []domain.AuditEntry{entry},
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if state := e.liveRound(bet.RoundID); state != nil {
+		delete(state.bets, id)
+		delete(state.cashedOut, id)
+		e.risk.Release(state.round.ID, id)
+		e.publish(EventBetVoided, state, bet)
+	} else if round, ok := e.settledRound(bet.RoundID); ok {
+		e.publish(EventBetVoided, &roundState{round: round}, bet)
+	}
+
+	return bet, nil
+}
+
+func (e *Engine) bettingRound(roundID string) *roundState {
+	if state := e.liveRound(roundID); state != nil && state.round.Status == domain.RoundStatusBetting {
+		return state
+	}
+	return nil
+}
+
+func (e *Engine) liveRound(roundID string) *roundState {
+	for _, state := range []*roundState{e.current, e.next} {
+		if state.round.ID == roundID {
+			return state
+		}
+	}
+	return nil
+}
+
+func (e *Engine) settledRound(roundID string) (domain.Round, bool) {
+	for i := len(e.history) - 1; i >= 0; i-- {
+		if e.history[i].Round.ID == roundID {
+			return e.history[i].Round, true
+		}
+	}
+	return domain.Round{}, false
+}
+
+func (e *Engine) CurrentRound() domain.Round {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.current.publicRound()
+}
+
+func (e *Engine) Rounds(limit int) []domain.RoundSummary {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rounds := []domain.RoundSummary{e.next.summary()}
+	if e.current.round.Status != domain.RoundStatusCrashed {
+		rounds = append(rounds, e.current.summary())
+	}
+	for i := len(e.history) - 1; i >= 0; i-- {
+		rounds = append(rounds, e.history[i])
+	}
+
+	if limit > 0 && len(rounds) > limit {
+		rounds = rounds[:limit]
+	}
+	return rounds
+}
+
+func (e *Engine) Round(id string) (domain.RoundSummary, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if state := e.liveRound(id); state != nil && state.round.Status != domain.RoundStatusCrashed {
+		return state.summary(), nil
+	}
+	for i := len(e.history) - 1; i >= 0; i-- {
+		if e.history[i].Round.ID == id {
+			return e.history[i], nil
+		}
+	}
+	return domain.RoundSummary{}, domain.ErrRoundNotFound
+}
+
+func (e *Engine) run() {
+	defer e.wg.Done()
+
+	for {
+		e.openRound()
+		if !e.sleep(e.config.BettingPhase) {
+			return
+		}
+
+		e.startRound()
+		if !e.runRound() {
+			return
+		}
+
+		e.crashRound()
+		if !e.sleep(e.config.Cooldown) {
+			return
+		}
+	}
+}
+
+func (e *Engine) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-e.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (e *Engine) openRound() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current.round.Status == domain.RoundStatusCrashed {
+		e.current = e.next
+		e.next = newRoundState(domain.NewRound())
+	}
+
+	e.current.round.OpenedAt = time.Now()
+	e.current.round.CrashPoint = generateCrashPoint(e.config.HouseEdge, e.config.MaxCrashPoint)
+
+	e.publish(EventRoundOpened, e.current, nil)
+}
+
+func (e *Engine) startRound() {
+	e.mu.Lock()
+	state := e.current
+	state.closing = true
+	e.mu.Unlock()
+
+	state.placing.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	e.current.round.Status = domain.RoundStatusRunning
+	e.current.round.StartedAt = &now
+
+	e.publish(EventRoundStarted, e.current, nil)
+}
+
+func (e *Engine) runRound() bool {
+	ticker := time.NewTicker(e.config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return false
+		case <-ticker.C:
+			if e.tick() {
+				return true
+			}
+		}
+	}
+}
+
+func (e *Engine) tick() bool {
+	e.mu.Lock()
+
+	state := e.current
+	multiplier := multiplierAt(time.Since(*state.round.StartedAt))
+	crashed := multiplier >= state.round.CrashPoint
+	if crashed {
+		multiplier = state.round.CrashPoint
+	}
+	state.multiplier = multiplier
+
+	var cashOuts []events.Event
+	for id, bet := range state.bets {
+		if state.cashedOut[id] || bet.CrashPoint > multiplier || bet.CrashPoint >= state.round.CrashPoint {
+			continue
+		}
+		state.cashedOut[id] = true
+		cashOuts = append(cashOuts, events.BetCashedOut{Bet: *bet, Multiplier: bet.CrashPoint, At: time.Now()})
+
+		e.publish(EventBetCashedOut, state, bet)
+	}
+
+	if !crashed {
+		e.publish(EventMultiplierTick, state, nil)
+	}
+
+	e.mu.Unlock()
+
+	if len(cashOuts) > 0 {
+		if err := e.repo.AppendOutbox(e.ctx, cashOuts...); err != nil {
+			e.logger.Error("failed to record cash-out events", zap.String("round_id", state.round.ID), zap.Int("bets", len(cashOuts)), zap.Error(err))
+		}
+	}
+
+	return crashed
+}
+
+func (e *Engine) crashRound() {
+	e.mu.Lock()
+
+	state := e.current
+	now := time.Now()
+	state.round.Status = domain.RoundStatusCrashed
+	state.round.CrashedAt = &now
+
+	e.publish(EventRoundCrashed, state, nil)
+
+	crashed := events.RoundCrashed{Round: state.round, Bets: len(state.bets), At: now}
+	snapshot := &roundState{round: state.round, multiplier: state.multiplier}
+	pending := make(map[string]settlement, len(state.bets)+len(e.unsettled))
+	for id := range state.bets {
+		pending[id] = settlement{round: snapshot, won: state.cashedOut[id], at: now}
+	}
+
+	e.risk.Settle(state.round.ID)
+
+	e.history = append(e.history, state.summary())
+	if len(e.history) > maxRoundsKept {
+		e.history = e.history[len(e.history)-maxRoundsKept:]
+	}
+
+	e.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), settleTimeout)
+	defer cancel()
+
+	if err := e.repo.AppendOutbox(ctx, crashed); err != nil {
+		e.logger.Error("failed to record round crash event", zap.String("round_id", snapshot.round.ID), zap.Error(err))
+	}
+
+	for id, s := range e.unsettled {
+		pending[id] = s
+	}
+	e.settleAll(ctx, pending)
+
+	e.logger.Info("round settled",
+		zap.String("round_id", snapshot.round.ID),
+		zap.Float64("crash_point", snapshot.round.CrashPoint),
+		zap.Int("bets", crashed.Bets),
+		zap.Int("unsettled", len(e.unsettled)),
+	)
+}
+
+func (e *Engine) settleAll(ctx context.Context, pending map[string]settlement) {
+	for id, s := range pending {
+		bet, err := e.settle(ctx, id, s)
+		switch {
+		case err == nil:
+			delete(e.unsettled, id)
+			e.publish(EventBetSettled, s.round, bet)
+		case errors.Is(err, errNotPending) || errors.Is(err, domain.ErrBetNotFound):
+			delete(e.unsettled, id)
+		default:
+			if _, queued := e.unsettled[id]; !queued {
+				e.logger.Error("failed to settle bet; retrying after the next round",
+					zap.String("bet_id", id),
+					zap.String("round_id", s.round.round.ID),
+					zap.Error(err),
+				)
+			}
+			e.unsettled[id] = s
+		}
+	}
+
+	metrics.BetsUnsettled.Set(int64(len(e.unsettled)))
+}
+
+func (e *Engine) settle(ctx context.Context, id string, s settlement) (*domain.Bet, error) {
+	var err error
+	for attempt := 0; attempt < settleAttempts; attempt++ {
+		if attempt > 0 {
+			metrics.BetSettlementRetries.Add(1)
+			if !e.sleep(settleBackoff << (attempt - 1)) {
+				return nil, ErrEngineStopped
+			}
+		}
+
+		var bet *domain.Bet
+		bet, err = e.repo.Modify(ctx, id, func(bet *domain.Bet) (repository.Change, error) {
+			if bet.Status != domain.BetStatusPending {
+				return repository.Change{}, errNotPending
+			}
+
+			bet.SettledAt = &s.at
+			if s.won {
+				bet.Status = domain.BetStatusWon
+				bet.Payout = bet.PotentialPayout()
+			} else {
+				bet.Status = domain.BetStatusLost
+				bet.Payout = 0
+			}
+			return repository.Change{
+				Outbox: []events.Event{events.BetSettled{Bet: *bet, RoundCrashPoint: s.round.round.CrashPoint, At: s.at}},
+			}, nil
+		})
+		if err == nil || errors.Is(err, errNotPending) || errors.Is(err, domain.ErrBetNotFound) || ctx.Err() != nil {
+			return bet, err
+		}
+	}
+	return nil, err
+}
+
+func (e *Engine) publish(eventType EventType, state *roundState, bet *domain.Bet) {
+	if e.publisher == nil {
+		return
+	}
+
+	event := Event{
+		Type:       eventType,
+		Round:      state.publicRound(),
+		Multiplier: state.multiplier,
+		Timestamp:  time.Now(),
+	}
+	if bet != nil {
+		betCopy := *bet
+		event.Bet = &betCopy
+	}
+
+	e.publisher.Publish(event)
+}
+
+func (s *roundState) summary() domain.RoundSummary {
+	summary := domain.RoundSummary{
+		Round:      s.publicRound(),
+		Multiplier: s.multiplier,
+		Bets:       len(s.bets),
+	}
+	for id, bet := range s.bets {
+		summary.Wagered += bet.Amount
+		if s.cashedOut[id] {
+			summary.PaidOut += bet.PotentialPayout()
+		}
+	}
+	return summary
+}
+
+func (s *roundState) publicRound() domain.Round {
+	round := s.round
+	if round.Status != domain.RoundStatusCrashed {
+		round.CrashPoint = 0
+	}
+	return round
+}
+
+func multiplierAt(elapsed time.Duration) float64 {
+	return math.Floor(math.Exp(growthRate*elapsed.Seconds())*100) / 100
+}
+
+func generateCrashPoint(houseEdge, maxCrashPoint float64) float64 {
+	crashPoint := (1 - houseEdge) / (1 - randomFloat())
+	crashPoint = math.Floor(crashPoint*100) / 100
+
+	if crashPoint < 1 {
+		crashPoint = 1
+	}
+	if crashPoint > maxCrashPoint {
+		crashPoint = maxCrashPoint
+	}
+
+	return crashPoint
+}
+
+func randomFloat() float64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return float64(binary.BigEndian.Uint64(b[:])>>11) / (1 << 53)
+}
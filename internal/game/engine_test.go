@@ -0,0 +1,247 @@
+package game
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/repository"
+	"bet/internal/risk"
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestEngineReleasesExposure(t *testing.T) {
+	admin := domain.Actor{ID: "ops", Admin: true}
+
+	tests := []struct {
+		name   string
+		remove func(e *Engine, id string) error
+	}{
+		{
+			name: "cancel",
+			remove: func(e *Engine, id string) error {
+				_, err := e.CancelBet(context.Background(), id, admin)
+				return err
+			},
+		},
+		{
+			name: "void",
+			remove: func(e *Engine, id string) error {
+				_, err := e.VoidBet(context.Background(), id, domain.VoidBetRequest{Reason: domain.VoidReasonOperatorError}, admin)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := risk.NewManager(risk.Config{Mode: domain.RiskModeReject, RoundLiability: 1000, UserLiability: 500})
+			e := NewEngine(Config{Risk: manager, Logger: zap.NewNop()}, repository.NewInMemoryBetRepository(), nil)
+
+			bet := domain.NewBet(1, 100, 5)
+			if err := e.PlaceBet(context.Background(), bet); err != nil {
+				t.Fatalf("PlaceBet: %v", err)
+			}
+			if err := e.PlaceBet(context.Background(), domain.NewBet(1, 1, 2)); !domain.IsRiskError(err) {
+				t.Fatalf("PlaceBet over the user cap: error = %v, want a risk error", err)
+			}
+
+			if err := tt.remove(e, bet.ID); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+
+			round := manager.Exposure([]string{bet.RoundID}).Rounds[0]
+			if round.Bets != 0 || round.Exposure != 0 {
+				t.Fatalf("round exposure after %s = %+v, want released", tt.name, round)
+			}
+			if err := e.PlaceBet(context.Background(), domain.NewBet(1, 100, 5)); err != nil {
+				t.Fatalf("PlaceBet after %s: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+type flakyRepository struct {
+	repository.BetRepository
+	mu        sync.Mutex
+	failures  int
+	gate      chan struct{}
+	modifyErr error
+}
+
+func (r *flakyRepository) Create(ctx context.Context, bet *domain.Bet, outbox ...events.Event) error {
+	r.mu.Lock()
+	gate := r.gate
+	r.gate = nil
+	r.mu.Unlock()
+
+	if gate != nil {
+		gate <- struct{}{}
+		<-gate
+	}
+	return r.BetRepository.Create(ctx, bet, outbox...)
+}
+
+func (r *flakyRepository) Modify(ctx context.Context, id string, apply func(bet *domain.Bet) (repository.Change, error)) (*domain.Bet, error) {
+	r.mu.Lock()
+	if r.failures > 0 {
+		r.failures--
+		r.mu.Unlock()
+		return nil, r.modifyErr
+	}
+	r.mu.Unlock()
+	return r.BetRepository.Modify(ctx, id, apply)
+}
+
+func TestEngineSettlementRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		rounds    int
+		unsettled int
+	}{
+		{name: "succeeds on retry", failures: settleAttempts - 1, rounds: 1},
+		{name: "queued for the next round", failures: settleAttempts, rounds: 1, unsettled: 1},
+		{name: "settled after the next round", failures: settleAttempts, rounds: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &flakyRepository{BetRepository: repository.NewInMemoryBetRepository(), modifyErr: errors.New("store unavailable")}
+			e := NewEngine(Config{Logger: zap.NewNop()}, repo, nil)
+
+			bet := domain.NewBet(1, 10, 2)
+			if err := e.PlaceBet(context.Background(), bet); err != nil {
+				t.Fatalf("PlaceBet: %v", err)
+			}
+			e.current.round.CrashPoint = 1.5
+
+			repo.failures = tt.failures
+			for i := 0; i < tt.rounds; i++ {
+				if i > 0 {
+					e.openRound()
+				}
+				e.crashRound()
+			}
+
+			if len(e.unsettled) != tt.unsettled {
+				t.Fatalf("unsettled = %d, want %d", len(e.unsettled), tt.unsettled)
+			}
+
+			stored, err := repo.GetByID(context.Background(), bet.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			want := domain.BetStatusLost
+			if tt.unsettled > 0 {
+				want = domain.BetStatusPending
+			}
+			if stored.Status != want {
+				t.Fatalf("status = %s, want %s", stored.Status, want)
+			}
+		})
+	}
+}
+
+func TestEngineSettlementSkipsVoidedBets(t *testing.T) {
+	repo := &flakyRepository{BetRepository: repository.NewInMemoryBetRepository(), modifyErr: errors.New("store unavailable")}
+	e := NewEngine(Config{Logger: zap.NewNop()}, repo, nil)
+
+	bet := domain.NewBet(1, 10, 2)
+	if err := e.PlaceBet(context.Background(), bet); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	repo.failures = settleAttempts
+	e.crashRound()
+	if len(e.unsettled) != 1 {
+		t.Fatalf("unsettled = %d, want 1", len(e.unsettled))
+	}
+
+	admin := domain.Actor{ID: "ops", Admin: true}
+	if _, err := e.VoidBet(context.Background(), bet.ID, domain.VoidBetRequest{Reason: domain.VoidReasonTechnicalError}, admin); err != nil {
+		t.Fatalf("VoidBet: %v", err)
+	}
+
+	e.openRound()
+	e.crashRound()
+
+	stored, err := repo.GetByID(context.Background(), bet.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Status != domain.BetStatusVoided || len(e.unsettled) != 0 {
+		t.Fatalf("status = %s with %d unsettled, want voided and none", stored.Status, len(e.unsettled))
+	}
+}
+
+func TestEnginePlacementDoesNotBlockRounds(t *testing.T) {
+	gate := make(chan struct{})
+	repo := &flakyRepository{BetRepository: repository.NewInMemoryBetRepository(), gate: gate}
+	e := NewEngine(Config{Logger: zap.NewNop()}, repo, nil)
+	roundID := e.CurrentRound().ID
+
+	bet := domain.NewBet(1, 10, 2)
+	placed := make(chan error, 1)
+	go func() { placed <- e.PlaceBet(context.Background(), bet) }()
+	<-gate
+
+	rounds := make(chan struct{})
+	go func() {
+		e.CurrentRound()
+		e.Rounds(10)
+		close(rounds)
+	}()
+	select {
+	case <-rounds:
+	case <-time.After(time.Second):
+		t.Fatal("round queries blocked while a bet was being stored")
+	}
+
+	started := make(chan struct{})
+	go func() {
+		e.startRound()
+		close(started)
+	}()
+	select {
+	case <-started:
+		t.Fatal("round started before an in-flight bet was admitted")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	late := domain.NewBet(2, 10, 2)
+	if err := e.PlaceBet(context.Background(), late); err != nil {
+		t.Fatalf("PlaceBet while the round closes: %v", err)
+	}
+	if late.RoundID == roundID {
+		t.Fatalf("bet placed while the round closed went to the closing round")
+	}
+
+	gate <- struct{}{}
+	if err := <-placed; err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+	<-started
+
+	summary, err := e.Round(roundID)
+	if err != nil {
+		t.Fatalf("Round: %v", err)
+	}
+	if summary.Bets != 1 || summary.Round.Status != domain.RoundStatusRunning {
+		t.Fatalf("round = %d bets, %s, want 1 bet, running", summary.Bets, summary.Round.Status)
+	}
+}
+
+func TestGenerateCrashPoint(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		crashPoint := generateCrashPoint(0.01, 1000)
+		if crashPoint < 1 || crashPoint > 1000 || math.Abs(crashPoint*100-math.Round(crashPoint*100)) > 1e-6 {
+			t.Fatalf("crash point %v is outside [1, 1000] or not rounded to cents", crashPoint)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"bet/internal/domain"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventRoundOpened    EventType = "round.opened"
+	EventRoundStarted   EventType = "round.started"
+	EventMultiplierTick EventType = "round.tick"
+	EventRoundCrashed   EventType = "round.crashed"
+	EventBetPlaced      EventType = "bet.placed"
+	EventBetCashedOut   EventType = "bet.cashed_out"
+	EventBetSettled     EventType = "bet.settled"
+	EventBetCancelled   EventType = "bet.cancelled"
+	EventBetVoided      EventType = "bet.voided"
+)
+
+type Event struct {
+	Type       EventType
+	Round      domain.Round
+	Bet        *domain.Bet
+	Multiplier float64
+	Timestamp  time.Time
+}
+
+func (e Event) IsBetEvent() bool {
+	return e.Bet != nil
+}
+
+type Publisher interface {
+	Publish(event Event)
+}
+
+type fanout []Publisher
+
+func Fanout(publishers ...Publisher) Publisher {
+	return fanout(publishers)
+}
+
+func (f fanout) Publish(event Event) {
+	for _, p := range f {
+		p.Publish(event)
+	}
+}
@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bet/v1/bet.proto
+
+package betv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BetService_CreateBet_FullMethodName = "/bet.v1.BetService/CreateBet"
+	BetService_GetBet_FullMethodName    = "/bet.v1.BetService/GetBet"
+	BetService_ListBets_FullMethodName  = "/bet.v1.BetService/ListBets"
+	BetService_WatchBets_FullMethodName = "/bet.v1.BetService/WatchBets"
+)
+
+// BetServiceClient is the client API for BetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BetServiceClient interface {
+	CreateBet(ctx context.Context, in *CreateBetRequest, opts ...grpc.CallOption) (*CreateBetResponse, error)
+	GetBet(ctx context.Context, in *GetBetRequest, opts ...grpc.CallOption) (*GetBetResponse, error)
+	ListBets(ctx context.Context, in *ListBetsRequest, opts ...grpc.CallOption) (*ListBetsResponse, error)
+	WatchBets(ctx context.Context, in *WatchBetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchBetsResponse], error)
+}
+
+type betServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBetServiceClient(cc grpc.ClientConnInterface) BetServiceClient {
+	return &betServiceClient{cc}
+}
+
+func (c *betServiceClient) CreateBet(ctx context.Context, in *CreateBetRequest, opts ...grpc.CallOption) (*CreateBetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBetResponse)
+	err := c.cc.Invoke(ctx, BetService_CreateBet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *betServiceClient) GetBet(ctx context.Context, in *GetBetRequest, opts ...grpc.CallOption) (*GetBetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBetResponse)
+	err := c.cc.Invoke(ctx, BetService_GetBet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *betServiceClient) ListBets(ctx context.Context, in *ListBetsRequest, opts ...grpc.CallOption) (*ListBetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBetsResponse)
+	err := c.cc.Invoke(ctx, BetService_ListBets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *betServiceClient) WatchBets(ctx context.Context, in *WatchBetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchBetsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BetService_ServiceDesc.Streams[0], BetService_WatchBets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBetsRequest, WatchBetsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BetService_WatchBetsClient = grpc.ServerStreamingClient[WatchBetsResponse]
+
+// BetServiceServer is the server API for BetService service.
+// All implementations must embed UnimplementedBetServiceServer
+// for forward compatibility.
+type BetServiceServer interface {
+	CreateBet(context.Context, *CreateBetRequest) (*CreateBetResponse, error)
+	GetBet(context.Context, *GetBetRequest) (*GetBetResponse, error)
+	ListBets(context.Context, *ListBetsRequest) (*ListBetsResponse, error)
+	WatchBets(*WatchBetsRequest, grpc.ServerStreamingServer[WatchBetsResponse]) error
+	mustEmbedUnimplementedBetServiceServer()
+}
+
+// UnimplementedBetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBetServiceServer struct{}
+
+func (UnimplementedBetServiceServer) CreateBet(context.Context, *CreateBetRequest) (*CreateBetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBet not implemented")
+}
+func (UnimplementedBetServiceServer) GetBet(context.Context, *GetBetRequest) (*GetBetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBet not implemented")
+}
+func (UnimplementedBetServiceServer) ListBets(context.Context, *ListBetsRequest) (*ListBetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBets not implemented")
+}
+func (UnimplementedBetServiceServer) WatchBets(*WatchBetsRequest, grpc.ServerStreamingServer[WatchBetsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBets not implemented")
+}
+func (UnimplementedBetServiceServer) mustEmbedUnimplementedBetServiceServer() {}
+func (UnimplementedBetServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeBetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BetServiceServer will
+// result in compilation errors.
+type UnsafeBetServiceServer interface {
+	mustEmbedUnimplementedBetServiceServer()
+}
+
+func RegisterBetServiceServer(s grpc.ServiceRegistrar, srv BetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BetService_ServiceDesc, srv)
+}
+
+func _BetService_CreateBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BetServiceServer).CreateBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BetService_CreateBet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BetServiceServer).CreateBet(ctx, req.(*CreateBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BetService_GetBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BetServiceServer).GetBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BetService_GetBet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BetServiceServer).GetBet(ctx, req.(*GetBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BetService_ListBets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BetServiceServer).ListBets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BetService_ListBets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BetServiceServer).ListBets(ctx, req.(*ListBetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BetService_WatchBets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BetServiceServer).WatchBets(m, &grpc.GenericServerStream[WatchBetsRequest, WatchBetsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BetService_WatchBetsServer = grpc.ServerStreamingServer[WatchBetsResponse]
+
+// BetService_ServiceDesc is the grpc.ServiceDesc for BetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bet.v1.BetService",
+	HandlerType: (*BetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBet",
+			Handler:    _BetService_CreateBet_Handler,
+		},
+		{
+			MethodName: "GetBet",
+			Handler:    _BetService_GetBet_Handler,
+		},
+		{
+			MethodName: "ListBets",
+			Handler:    _BetService_ListBets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBets",
+			Handler:       _BetService_WatchBets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bet/v1/bet.proto",
+}
@@ -0,0 +1,80 @@
+package grpcserver
+
+import (
+	"bet/internal/domain"
+	betv1 "bet/internal/gen/bet/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var betStatusToProto = map[domain.BetStatus]betv1.BetStatus{
+	domain.BetStatusPending:   betv1.BetStatus_BET_STATUS_PENDING,
+	domain.BetStatusWon:       betv1.BetStatus_BET_STATUS_WON,
+	domain.BetStatusLost:      betv1.BetStatus_BET_STATUS_LOST,
+	domain.BetStatusCancelled: betv1.BetStatus_BET_STATUS_CANCELLED,
+	domain.BetStatusVoided:    betv1.BetStatus_BET_STATUS_VOIDED,
+}
+
+func betToProto(bet *domain.Bet) *betv1.Bet {
+	pb := &betv1.Bet{
+		Id:         bet.ID,
+		UserId:     bet.UserID,
+		Amount:     bet.Amount,
+		CrashPoint: bet.CrashPoint,
+		RoundId:    bet.RoundID,
+		Status:     betStatusToProto[bet.Status],
+		Payout:     bet.Payout,
+		CreatedAt:  timestamppb.New(bet.CreatedAt),
+	}
+
+	if bet.SettledAt != nil {
+		pb.SettledAt = timestamppb.New(*bet.SettledAt)
+	}
+
+	return pb
+}
+
+func filtersFromProto(pb *betv1.BetFilters) domain.BetFilters {
+	var filters domain.BetFilters
+	if pb == nil {
+		return filters
+	}
+
+	if pb.UserId != nil {
+		userID := pb.GetUserId()
+		filters.UserID = &userID
+	}
+	if pb.MinAmount != nil {
+		minAmount := pb.GetMinAmount()
+		filters.MinAmount = &minAmount
+	}
+	if pb.MaxAmount != nil {
+		maxAmount := pb.GetMaxAmount()
+		filters.MaxAmount = &maxAmount
+	}
+
+	return filters
+}
+
+func listBetsRequestFromProto(pb *betv1.ListBetsRequest) domain.ListBetsRequest {
+	req := domain.ListBetsRequest{
+		Filters: filtersFromProto(pb.GetFilters()),
+		Pagination: domain.PaginationParams{
+			Page:  int(pb.GetPage()),
+			Limit: int(pb.GetLimit()),
+		},
+		Sort: domain.SortParams{
+			SortBy: pb.GetSortBy(),
+			Order:  pb.GetOrder(),
+		},
+	}
+
+	if req.Pagination.Page == 0 {
+		req.Pagination.Page = 1
+	}
+	if req.Pagination.Limit == 0 {
+		req.Pagination.Limit = 10
+	}
+
+	return req
+}
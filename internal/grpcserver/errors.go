@@ -0,0 +1,149 @@
+package grpcserver
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "bet-api"
+
+func toStatus(ctx context.Context, method string, err error, logger *zap.Logger) error {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+
+	logFields := []zap.Field{
+		zap.String("request_id", middleware.GetRequestID(ctx)),
+		zap.String("trace_id", middleware.GetTraceID(ctx)),
+		zap.Error(err),
+		zap.String("grpc_method", method),
+	}
+
+	ctxErr := ctx.Err()
+	if ctxErr == nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+		ctxErr = err
+	}
+	if ctxErr != nil {
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			metrics.RequestTimeouts.Add(1)
+			span.SetStatus(otelcodes.Error, "DEADLINE_EXCEEDED")
+			logger.Warn("request deadline exceeded", append(logFields, zap.String("error_code", "DEADLINE_EXCEEDED"))...)
+			return newStatus(codes.DeadlineExceeded, "DEADLINE_EXCEEDED", "Request timed out")
+		}
+		metrics.ClientDisconnects.Add(1)
+		logger.Info("client closed request", append(logFields, zap.String("error_code", "CLIENT_CLOSED_REQUEST"))...)
+		return newStatus(codes.Canceled, "CLIENT_CLOSED_REQUEST", "Client closed request")
+	}
+
+	var (
+		validationErr   *domain.ValidationError
+		notFoundErr     *domain.NotFoundError
+		conflictErr     *domain.ConflictError
+		forbiddenErr    *domain.ForbiddenError
+		invalidInputErr *domain.InvalidInputError
+		limitErr        *domain.LimitError
+		riskErr         *domain.RiskError
+		fraudErr        *domain.FraudError
+		repoErr         *domain.RepositoryError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		logger.Warn("validation error", append(logFields, zap.String("error_code", "VALIDATION_ERROR"))...)
+		violations := domain.FieldViolations(err)
+		fieldViolations := make([]*errdetails.BadRequest_FieldViolation, len(violations))
+		for i, v := range violations {
+			fieldViolations[i] = &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Message}
+		}
+		st := status.New(codes.InvalidArgument, domain.ValidationErrors(violations).Error())
+		if detailed, detailErr := st.WithDetails(
+			&errdetails.ErrorInfo{Reason: "VALIDATION_ERROR", Domain: errorDomain},
+			&errdetails.BadRequest{FieldViolations: fieldViolations},
+		); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+
+	case errors.As(err, &notFoundErr):
+		logger.Info("resource not found", append(logFields, zap.String("error_code", notFoundErr.Code()))...)
+		return newStatus(codes.NotFound, notFoundErr.Code(), notFoundErr.Error())
+
+	case errors.As(err, &conflictErr):
+		logger.Info("state conflict", append(logFields, zap.String("error_code", conflictErr.Code()))...)
+		return newStatus(codes.FailedPrecondition, conflictErr.Code(), conflictErr.Error())
+
+	case errors.As(err, &forbiddenErr):
+		logger.Warn("request forbidden", append(logFields, zap.String("error_code", forbiddenErr.Code()))...)
+		return newStatus(codes.PermissionDenied, forbiddenErr.Code(), forbiddenErr.Error())
+
+	case errors.As(err, &limitErr):
+		logger.Info("responsible gambling limit", append(logFields, zap.String("error_code", limitErr.Code()))...)
+		metadata := map[string]string{"remaining": strconv.FormatFloat(limitErr.Remaining, 'f', 2, 64)}
+		if limitErr.Type != "" {
+			metadata["type"] = string(limitErr.Type)
+			metadata["period"] = string(limitErr.Period)
+			metadata["amount"] = strconv.FormatFloat(limitErr.Limit, 'f', 2, 64)
+		}
+		if limitErr.Until != nil {
+			metadata["until"] = limitErr.Until.UTC().Format(time.RFC3339)
+		}
+		st := status.New(codes.FailedPrecondition, limitErr.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: limitErr.Code(), Domain: errorDomain, Metadata: metadata}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+
+	case errors.As(err, &riskErr):
+		logger.Info("liability cap", append(logFields, zap.String("error_code", riskErr.Code()))...)
+		metadata := map[string]string{}
+		if riskErr.Limit > 0 {
+			metadata["limit"] = strconv.FormatFloat(riskErr.Limit, 'f', 2, 64)
+			metadata["remaining"] = strconv.FormatFloat(riskErr.Remaining, 'f', 2, 64)
+		}
+		if riskErr.MaxCrashPoint > 0 {
+			metadata["max_crash_point"] = strconv.FormatFloat(riskErr.MaxCrashPoint, 'f', 2, 64)
+		}
+		st := status.New(codes.FailedPrecondition, riskErr.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: riskErr.Code(), Domain: errorDomain, Metadata: metadata}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+
+	case errors.As(err, &fraudErr):
+		logger.Info("fraud check", append(logFields, zap.String("error_code", fraudErr.Code()))...)
+		return newStatus(codes.FailedPrecondition, fraudErr.Code(), fraudErr.Error())
+
+	case errors.As(err, &invalidInputErr):
+		logger.Warn("invalid input", append(logFields, zap.String("error_code", "INVALID_INPUT"))...)
+		return newStatus(codes.InvalidArgument, "INVALID_INPUT", invalidInputErr.Error())
+
+	case errors.As(err, &repoErr):
+		span.SetStatus(otelcodes.Error, "REPOSITORY_ERROR")
+		logger.Error("repository error", append(logFields, zap.String("error_code", "REPOSITORY_ERROR"))...)
+		return newStatus(codes.Internal, "REPOSITORY_ERROR", "database operation failed")
+
+	default:
+		span.SetStatus(otelcodes.Error, "INTERNAL_ERROR")
+		logger.Error("unhandled error", append(logFields, zap.String("error_code", "INTERNAL_ERROR"))...)
+		return newStatus(codes.Internal, "INTERNAL_ERROR", "An internal error occurred")
+	}
+}
+
+func newStatus(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,282 @@
+package grpcserver
+
+import (
+	"bet/internal/auth"
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metadataRequestID     = "x-request-id"
+	metadataAuthorization = "authorization"
+	metadataForwardedFor  = "x-forwarded-for"
+	metadataDeviceID      = "x-device-id"
+)
+
+var publicMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func isPublicMethod(method string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	requestID := firstMetadata(ctx, metadataRequestID)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+	return middleware.WithRequestID(ctx, requestID)
+}
+
+func RequestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+	}
+}
+
+func logCall(ctx context.Context, logger *zap.Logger, method string, start time.Time, err error) {
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+
+	logger.Info("gRPC request",
+		zap.String("request_id", middleware.GetRequestID(ctx)),
+		zap.String("trace_id", middleware.GetTraceID(ctx)),
+		zap.String("grpc_method", method),
+		zap.String("grpc_code", status.Code(err).String()),
+		zap.Duration("duration", time.Since(start)),
+		zap.String("remote_addr", remoteAddr),
+		zap.String("user_agent", firstMetadata(ctx, "user-agent")),
+	)
+}
+
+func LoggingUnaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func LoggingStreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func recoverPanic(ctx context.Context, logger *zap.Logger, method string, err *error) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	metrics.PanicsRecovered.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(fmt.Errorf("panic: %v", rec))
+	span.SetStatus(otelcodes.Error, "panic recovered")
+
+	logger.Error("panic recovered",
+		zap.String("request_id", middleware.GetRequestID(ctx)),
+		zap.String("trace_id", middleware.GetTraceID(ctx)),
+		zap.String("grpc_method", method),
+		zap.Any("panic", rec),
+		zap.ByteString("stack", debug.Stack()),
+	)
+
+	*err = newStatus(codes.Internal, "INTERNAL_ERROR", "An internal error occurred")
+}
+
+func RecoveryUnaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recoverPanic(ctx, logger, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func RecoveryStreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(ss.Context(), logger, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+func withClientIP(ctx context.Context, resolver *middleware.ClientIPResolver) context.Context {
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+
+	var forwardedFor []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		forwardedFor = md.Get(metadataForwardedFor)
+	}
+	return middleware.WithClientIP(ctx, resolver.Resolve(remoteAddr, forwardedFor, ""))
+}
+
+func ClientIPUnaryInterceptor(resolver *middleware.ClientIPResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withClientIP(ctx, resolver), req)
+	}
+}
+
+func ClientIPStreamInterceptor(resolver *middleware.ClientIPResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: withClientIP(ss.Context(), resolver)})
+	}
+}
+
+func clientFrom(ctx context.Context) domain.Client {
+	return domain.Client{
+		IP:       middleware.GetClientIP(ctx),
+		DeviceID: firstMetadata(ctx, metadataDeviceID),
+	}
+}
+
+func actorFrom(ctx context.Context) domain.Actor {
+	return auth.ActorFrom(ctx, middleware.GetRequestID(ctx))
+}
+
+func checkRateLimit(ctx context.Context, limiter *middleware.RateLimiter, logger *zap.Logger, method string) error {
+	if isPublicMethod(method) {
+		return nil
+	}
+
+	ip := middleware.GetClientIP(ctx)
+	if limiter.Allow(ip) {
+		return nil
+	}
+
+	logger.Warn("rate limit exceeded",
+		zap.String("ip", ip),
+		zap.String("grpc_method", method),
+	)
+	grpc.SetHeader(ctx, metadata.Pairs("retry-after", "60"))
+	return newStatus(codes.ResourceExhausted, "RATE_LIMITED", "rate limit exceeded")
+}
+
+func RateLimitUnaryInterceptor(limiter *middleware.RateLimiter, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkRateLimit(ctx, limiter, logger, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func RateLimitStreamInterceptor(limiter *middleware.RateLimiter, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRateLimit(ss.Context(), limiter, logger, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authenticate(ctx context.Context, authenticator auth.Authenticator, scopes map[string]auth.Scope, open map[string]bool, logger *zap.Logger, method string) (context.Context, error) {
+	if isPublicMethod(method) {
+		return ctx, nil
+	}
+	if !authenticator.Enabled() {
+		if open[method] {
+			return auth.WithOpenAccess(ctx), nil
+		}
+		return nil, newStatus(codes.Unavailable, "AUTH_NOT_CONFIGURED", auth.ErrNotConfigured.Error())
+	}
+
+	principal, err := authenticator.Authenticate(ctx, auth.BearerToken(firstMetadata(ctx, metadataAuthorization)))
+	if err != nil {
+		logger.Warn("unauthenticated gRPC request",
+			zap.String("request_id", middleware.GetRequestID(ctx)),
+			zap.String("grpc_method", method),
+		)
+		return nil, newStatus(codes.Unauthenticated, "UNAUTHENTICATED", auth.ErrUnauthenticated.Error())
+	}
+
+	if scope, ok := scopes[method]; ok && !principal.HasScope(scope) {
+		logger.Warn("gRPC request denied",
+			zap.String("request_id", middleware.GetRequestID(ctx)),
+			zap.String("grpc_method", method),
+			zap.String("key_id", principal.KeyID),
+			zap.String("required_scope", string(scope)),
+		)
+		return nil, newStatus(codes.PermissionDenied, "PERMISSION_DENIED", auth.ErrPermissionDenied.Error())
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func AuthUnaryInterceptor(authenticator auth.Authenticator, scopes map[string]auth.Scope, open map[string]bool, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, scopes, open, logger, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamInterceptor(authenticator auth.Authenticator, scopes map[string]auth.Scope, open map[string]bool, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator, scopes, open, logger, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
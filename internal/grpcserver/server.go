@@ -0,0 +1,256 @@
+package grpcserver
+
+import (
+	"bet/internal/auth"
+	"bet/internal/domain"
+	betv1 "bet/internal/gen/bet/v1"
+	"bet/internal/middleware"
+	"bet/internal/service"
+	"bet/internal/sse"
+	"bet/internal/validator"
+	"context"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+var MethodScopes = map[string]auth.Scope{
+	betv1.BetService_CreateBet_FullMethodName: auth.ScopeBetsWrite,
+	betv1.BetService_GetBet_FullMethodName:    auth.ScopeBetsRead,
+	betv1.BetService_ListBets_FullMethodName:  auth.ScopeBetsRead,
+	betv1.BetService_WatchBets_FullMethodName: auth.ScopeBetsRead,
+}
+
+var OpenMethods = map[string]bool{
+	betv1.BetService_CreateBet_FullMethodName: true,
+}
+
+type Config struct {
+	Port          int
+	Reflection    bool
+	Authenticator auth.Authenticator
+	RateLimiter   *middleware.RateLimiter
+	ClientIPs     *middleware.ClientIPResolver
+	Logger        *zap.Logger
+}
+
+type Server struct {
+	config Config
+	server *grpc.Server
+	health *health.Server
+	logger *zap.Logger
+}
+
+func NewServer(config Config, bets service.BetServiceUseCase, validator validator.BetValidator, stream *sse.Broker) *Server {
+	logger := config.Logger
+
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			RequestIDUnaryInterceptor(),
+			ClientIPUnaryInterceptor(config.ClientIPs),
+			LoggingUnaryInterceptor(logger),
+			RecoveryUnaryInterceptor(logger),
+			RateLimitUnaryInterceptor(config.RateLimiter, logger),
+			AuthUnaryInterceptor(config.Authenticator, MethodScopes, OpenMethods, logger),
+		),
+		grpc.ChainStreamInterceptor(
+			RequestIDStreamInterceptor(),
+			ClientIPStreamInterceptor(config.ClientIPs),
+			LoggingStreamInterceptor(logger),
+			RecoveryStreamInterceptor(logger),
+			RateLimitStreamInterceptor(config.RateLimiter, logger),
+			AuthStreamInterceptor(config.Authenticator, MethodScopes, OpenMethods, logger),
+		),
+	)
+
+	betv1.RegisterBetServiceServer(server, &betServer{
+		service:   bets,
+		validator: validator,
+		stream:    stream,
+		logger:    logger,
+	})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	if config.Reflection {
+		reflection.Register(server)
+	}
+
+	return &Server{
+		config: config,
+		server: server,
+		health: healthServer,
+		logger: logger,
+	}
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on grpc port %d: %w", s.config.Port, err)
+	}
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	go func() {
+		s.logger.Info("starting gRPC server", zap.Int("port", s.config.Port))
+		if err := s.server.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+			s.logger.Error("gRPC server stopped", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Info("shutting down gRPC server...")
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("gRPC server shutdown complete")
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		s.logger.Warn("gRPC server shutdown timeout")
+		return ctx.Err()
+	}
+}
+
+type betServer struct {
+	betv1.UnimplementedBetServiceServer
+
+	service   service.BetServiceUseCase
+	validator validator.BetValidator
+	stream    *sse.Broker
+	logger    *zap.Logger
+}
+
+func (s *betServer) CreateBet(ctx context.Context, req *betv1.CreateBetRequest) (*betv1.CreateBetResponse, error) {
+	method := betv1.BetService_CreateBet_FullMethodName
+
+	if err := s.validator.ValidateNumber(req.GetAmount()); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+	if err := s.validator.ValidateNumber(req.GetCrashPoint()); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	if err := s.validator.ValidateCreateRequest(req.GetUserId(), req.GetAmount(), req.GetCrashPoint()); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	bet, err := s.service.CreateBet(ctx, domain.PlaceBetRequest{
+		UserID:     req.GetUserId(),
+		Amount:     req.GetAmount(),
+		CrashPoint: req.GetCrashPoint(),
+	}, clientFrom(ctx), actorFrom(ctx))
+	if err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	return &betv1.CreateBetResponse{Bet: betToProto(bet)}, nil
+}
+
+func (s *betServer) GetBet(ctx context.Context, req *betv1.GetBetRequest) (*betv1.GetBetResponse, error) {
+	method := betv1.BetService_GetBet_FullMethodName
+
+	if err := s.validator.ValidateBetID(req.GetId()); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	bet, err := s.service.GetBetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	return &betv1.GetBetResponse{Bet: betToProto(bet)}, nil
+}
+
+func (s *betServer) ListBets(ctx context.Context, req *betv1.ListBetsRequest) (*betv1.ListBetsResponse, error) {
+	method := betv1.BetService_ListBets_FullMethodName
+	listReq := listBetsRequestFromProto(req)
+
+	if err := s.validator.ValidatePagination(listReq.Pagination.Page, listReq.Pagination.Limit); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	if err := s.validator.ValidateSort(listReq.Sort.SortBy, listReq.Sort.Order); err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	response, err := s.service.ListBets(ctx, listReq)
+	if err != nil {
+		return nil, toStatus(ctx, method, err, s.logger)
+	}
+
+	bets := make([]*betv1.Bet, len(response.Bets))
+	for i := range response.Bets {
+		bets[i] = betToProto(&response.Bets[i])
+	}
+
+	return &betv1.ListBetsResponse{
+		Bets:  bets,
+		Total: int32(response.Total),
+		Page:  int32(response.Page),
+		Limit: int32(response.Limit),
+	}, nil
+}
+
+func (s *betServer) WatchBets(req *betv1.WatchBetsRequest, stream grpc.ServerStreamingServer[betv1.WatchBetsResponse]) error {
+	ctx := stream.Context()
+	filters := filtersFromProto(req.GetFilters())
+
+	sub, replay := s.stream.Subscribe(req.GetResumeToken())
+	defer s.stream.Unsubscribe(sub)
+
+	s.logger.Info("gRPC bet watch opened",
+		zap.String("request_id", middleware.GetRequestID(ctx)),
+		zap.String("resume_token", req.GetResumeToken()),
+		zap.Int("replayed", len(replay)),
+	)
+
+	send := func(ev sse.Event) error {
+		if !filters.Matches(ev.Bet) {
+			return nil
+		}
+		return stream.Send(&betv1.WatchBetsResponse{
+			EventId: ev.ID,
+			Bet:     betToProto(&ev.Bet),
+		})
+	}
+
+	for _, ev := range replay {
+		if err := send(ev); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Done():
+			return newStatus(codes.Unavailable, "STREAM_CLOSED", "bet stream closed, resume with the last event_id")
+		case ev := <-sub.C:
+			if err := send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type AdminHandler struct {
+	service   service.BetServiceUseCase
+	validator validator.BetValidator
+	logger    *zap.Logger
+}
+
+func NewAdminHandler(service service.BetServiceUseCase, validator validator.BetValidator, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *AdminHandler) VoidBet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "AdminHandler.VoidBet")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("bet.id", id))
+
+	if err := h.validator.ValidateBetID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	var req VoidBetRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	if err := h.validator.ValidateVoidRequest(req.Reason, req.Note); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("bet.void_reason", req.Reason))
+
+	actor := actorFrom(r)
+	bet, err := h.service.VoidBet(r.Context(), id, domain.VoidBetRequest{
+		Reason: domain.VoidReason(req.Reason),
+		Note:   req.Note,
+	}, actor)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	metrics.BetsVoided.Add(1)
+	h.logger.Info("bet voided",
+		zap.String("request_id", actor.RequestID),
+		zap.String("bet_id", bet.ID),
+		zap.String("actor", actor.ID),
+		zap.String("reason", req.Reason),
+	)
+
+	sendJSON(w, http.StatusOK, betResponse(versionOf(r), bet), h.logger)
+}
+
+func (h *AdminHandler) GetBetAudit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "AdminHandler.GetBetAudit")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("bet.id", id))
+
+	if err := h.validator.ValidateBetID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	entries, err := h.service.GetBetAudit(r.Context(), id)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, auditResponse(versionOf(r), entries), h.logger)
+}
+
+func (h *AdminHandler) ImportBets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "AdminHandler.ImportBets")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	bets, err := h.decodeImportBets(r)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("bets.count", len(bets)))
+
+	result, err := h.service.ImportBets(r.Context(), bets)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	metrics.BetsImported.Add(int64(result.Imported))
+	h.logger.Info("bets imported",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("actor", actorFrom(r).ID),
+		zap.Int("imported", result.Imported),
+		zap.Int("duplicates", len(result.Duplicates)),
+	)
+
+	sendJSON(w, http.StatusOK, ImportBetsResponseDTOFromDomain(result), h.logger)
+}
+
+func (h *AdminHandler) decodeImportBets(r *http.Request) ([]domain.Bet, error) {
+	var bets []domain.Bet
+	var errs []error
+
+	if versionOf(r).DecimalStrings {
+		var req ImportBetsV2Request
+		if err := decodeJSONBody(r, &req, h.logger); err != nil {
+			return nil, err
+		}
+		for _, dto := range req.Bets {
+			bet, err := betFromV2DTO(dto)
+			bets, errs = append(bets, bet), append(errs, err)
+		}
+	} else {
+		var req ImportBetsRequest
+		if err := decodeJSONBody(r, &req, h.logger); err != nil {
+			return nil, err
+		}
+		for _, dto := range req.Bets {
+			bet, err := betFromDTO(dto)
+			bets, errs = append(bets, bet), append(errs, err)
+		}
+	}
+
+	if err := h.validator.ValidateImportBatchSize(len(bets)); err != nil {
+		return nil, err
+	}
+
+	var violations domain.ValidationErrors
+	for i, bet := range bets {
+		err := errs[i]
+		if err == nil {
+			err = h.validator.ValidateImportedBet(bet)
+		}
+		if err != nil {
+			violations = append(violations, prefixViolations(fmt.Sprintf("bets[%d]", i), err)...)
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, violations
+	}
+
+	return bets, nil
+}
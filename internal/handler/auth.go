@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bet/internal/auth"
+	"bet/internal/domain"
+	"bet/internal/middleware"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+func RequireScope(authenticator auth.Authenticator, scope auth.Scope, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := middleware.GetRequestID(r.Context())
+
+			if authenticator == nil || !authenticator.Enabled() {
+				logger.Warn("request to a protected route without configured API keys",
+					zap.String("request_id", requestID),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+				)
+				writeErrorResponse(w, r, http.StatusServiceUnavailable, ErrorResponse{
+					Error: auth.ErrNotConfigured.Error(),
+					Code:  "AUTH_NOT_CONFIGURED",
+				}, logger)
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), auth.BearerToken(r.Header.Get("Authorization")))
+			if err != nil {
+				logger.Warn("unauthenticated request",
+					zap.String("request_id", requestID),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+				)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="bet-api"`)
+				writeErrorResponse(w, r, http.StatusUnauthorized, ErrorResponse{
+					Error: auth.ErrUnauthenticated.Error(),
+					Code:  "UNAUTHENTICATED",
+				}, logger)
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				logger.Warn("request denied",
+					zap.String("request_id", requestID),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("key_id", principal.KeyID),
+					zap.String("required_scope", string(scope)),
+				)
+				writeErrorResponse(w, r, http.StatusForbidden, ErrorResponse{
+					Error: auth.ErrPermissionDenied.Error(),
+					Code:  "PERMISSION_DENIED",
+				}, logger)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func OptionalScope(authenticator auth.Authenticator, scope auth.Scope, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		required := RequireScope(authenticator, scope, logger)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authenticator == nil || !authenticator.Enabled() {
+				next.ServeHTTP(w, r.WithContext(auth.WithOpenAccess(r.Context())))
+				return
+			}
+			required.ServeHTTP(w, r)
+		})
+	}
+}
+
+func RequireScopeWithQuery(param string, authenticator auth.Authenticator, scope auth.Scope, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		required := RequireScope(authenticator, scope, logger)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !r.URL.Query().Has(param) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			required.ServeHTTP(w, r)
+		})
+	}
+}
+
+func actorFrom(r *http.Request) domain.Actor {
+	return auth.ActorFrom(r.Context(), middleware.GetRequestID(r.Context()))
+}
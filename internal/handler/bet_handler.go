@@ -2,16 +2,26 @@ package handler
 
 import (
 	"bet/internal/domain"
+	"bet/internal/metrics"
 	"bet/internal/middleware"
 	"bet/internal/service"
+	"bet/internal/tracing"
 	"bet/internal/validator"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("bet/internal/handler")
+
 type BetHandler struct {
 	service   service.BetServiceUseCase
 	validator validator.BetValidator
@@ -27,53 +37,13 @@ func NewBetHandler(service service.BetServiceUseCase, validator validator.BetVal
 }
 
 func (h *BetHandler) CreateBet(w http.ResponseWriter, r *http.Request) {
-	requestID := middleware.GetRequestID(r.Context())
-
-	contentType := r.Header.Get("Content-Type")
-	if idx := strings.Index(contentType, ";"); idx != -1 {
-		contentType = contentType[:idx]
-	}
-	contentType = strings.TrimSpace(contentType)
-	if contentType != "application/json" {
-		h.logger.Warn("invalid content type",
-			zap.String("request_id", requestID),
-			zap.String("content_type", r.Header.Get("Content-Type")),
-		)
-		handleError(w, r, &domain.ValidationError{
-			Field:   "content-type",
-			Message: "content type must be application/json",
-		}, h.logger)
-		return
-	}
-
-	const maxBodySize = 1 << 20
-	if r.ContentLength > maxBodySize {
-		h.logger.Warn("request body too large",
-			zap.String("request_id", requestID),
-			zap.Int64("size", r.ContentLength),
-		)
-		handleError(w, r, &domain.ValidationError{
-			Field:   "body",
-			Message: "request body too large",
-		}, h.logger)
-		return
-	}
-
-	var req struct {
-		UserID     int64   `json:"user_id"`
-		Amount     float64 `json:"amount"`
-		CrashPoint float64 `json:"crash_point"`
-	}
+	ctx, span := tracer.Start(r.Context(), "BetHandler.CreateBet")
+	defer span.End()
+	r = r.WithContext(ctx)
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error("failed to decode request body",
-			zap.String("request_id", requestID),
-			zap.Error(err),
-		)
-		handleError(w, r, &domain.ValidationError{
-			Field:   "body",
-			Message: "invalid request body",
-		}, h.logger)
+	req, err := h.decodeCreateBet(r)
+	if err != nil {
+		handleError(w, r, err, h.logger)
 		return
 	}
 
@@ -86,23 +56,40 @@ func (h *BetHandler) CreateBet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	span.SetAttributes(
+		attribute.Int64("bet.user_id", req.UserID),
+		attribute.Float64("bet.amount", req.Amount),
+		attribute.Float64("bet.crash_point", req.CrashPoint),
+	)
+
 	if err := h.validator.ValidateCreateRequest(req.UserID, req.Amount, req.CrashPoint); err != nil {
 		handleError(w, r, err, h.logger)
 		return
 	}
 
-	bet, err := h.service.CreateBet(r.Context(), req.UserID, req.Amount, req.CrashPoint)
+	bet, err := h.service.CreateBet(r.Context(), domain.PlaceBetRequest{
+		UserID:     req.UserID,
+		Amount:     req.Amount,
+		CrashPoint: req.CrashPoint,
+		Currency:   req.Currency,
+	}, clientFrom(r), actorFrom(r))
 	if err != nil {
 		handleError(w, r, err, h.logger)
 		return
 	}
 
-	betDTO := BetDTOFromDomain(bet)
-	sendJSON(w, http.StatusCreated, betDTO, h.logger)
+	span.SetAttributes(attribute.String("bet.id", bet.ID))
+
+	sendJSON(w, http.StatusCreated, betResponse(versionOf(r), bet), h.logger)
 }
 
 func (h *BetHandler) GetBet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "BetHandler.GetBet")
+	defer span.End()
+	r = r.WithContext(ctx)
+
 	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("bet.id", id))
 
 	if err := h.validator.ValidateBetID(id); err != nil {
 		handleError(w, r, err, h.logger)
@@ -115,12 +102,40 @@ func (h *BetHandler) GetBet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	betDTO := BetDTOFromDomain(bet)
-	sendJSON(w, http.StatusOK, betDTO, h.logger)
+	sendJSON(w, http.StatusOK, betResponse(versionOf(r), bet), h.logger)
+}
+
+func (h *BetHandler) CancelBet(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "BetHandler.CancelBet")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("bet.id", id))
+
+	if err := h.validator.ValidateBetID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	bet, err := h.service.CancelBet(r.Context(), id, actorFrom(r))
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	metrics.BetsCancelled.Add(1)
+
+	sendJSON(w, http.StatusOK, betResponse(versionOf(r), bet), h.logger)
 }
 
 func (h *BetHandler) ListBets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "BetHandler.ListBets")
+	defer span.End()
+	r = r.WithContext(ctx)
+
 	listReq := ParseListBetsRequest(r)
+	span.SetAttributes(tracing.ListBetsAttributes(listReq)...)
 
 	if err := h.validator.ValidatePagination(listReq.Pagination.Page, listReq.Pagination.Limit); err != nil {
 		handleError(w, r, err, h.logger)
@@ -138,6 +153,287 @@ func (h *BetHandler) ListBets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseDTO := ListBetsResponseDTOFromDomain(response)
-	sendJSON(w, http.StatusOK, responseDTO, h.logger)
+	span.SetAttributes(
+		attribute.Int("bets.result_count", len(response.Bets)),
+		attribute.Int("bets.total", response.Total),
+	)
+
+	sendJSON(w, http.StatusOK, listBetsResponse(versionOf(r), response), h.logger)
+}
+
+func (h *BetHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "BetHandler.GetUserStats")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		handleError(w, r, &domain.ValidationError{Field: "id", Message: "id must be an integer"}, h.logger)
+		return
+	}
+	span.SetAttributes(attribute.Int64("bet.user_id", userID))
+
+	if err := h.validator.ValidateUserID(userID); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	from := sanitizeQueryParam(r.URL.Query().Get("from"))
+	to := sanitizeQueryParam(r.URL.Query().Get("to"))
+	if err := h.validator.ValidateStatsRange(from, to); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	req := domain.UserStatsRequest{UserID: userID}
+	if from != "" {
+		day, _ := time.Parse(time.DateOnly, from)
+		req.From = &day
+	}
+	if to != "" {
+		day, _ := time.Parse(time.DateOnly, to)
+		req.To = &day
+	}
+
+	stats, err := h.service.GetUserStats(r.Context(), req)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, userStatsResponse(versionOf(r), stats, req), h.logger)
+}
+
+func (h *BetHandler) CreateBets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "BetHandler.CreateBets")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	version := versionOf(r)
+
+	var req CreateBetsRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = BatchModeAllOrNothing
+	}
+
+	span.SetAttributes(
+		attribute.String("batch.mode", mode),
+		attribute.Int("batch.size", len(req.Bets)),
+	)
+
+	if err := h.validator.ValidateBatchSize(len(req.Bets)); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	results := make([]BatchItemResultDTO, len(req.Bets))
+	requests := make([]domain.PlaceBetRequest, 0, len(req.Bets))
+	indexes := make([]int, 0, len(req.Bets))
+	var violations domain.ValidationErrors
+	rejected := 0
+
+	for i, raw := range req.Bets {
+		item, err := h.parseBetRequest(version, raw)
+		if err != nil {
+			itemViolations := prefixViolations(fmt.Sprintf("bets[%d]", i), err)
+			violations = append(violations, itemViolations...)
+			rejected++
+			results[i] = BatchItemResultDTO{Index: i, Status: BatchItemRejected, Errors: fieldErrors(itemViolations)}
+			continue
+		}
+		requests = append(requests, item)
+		indexes = append(indexes, i)
+	}
+
+	if len(violations) > 0 && mode != BatchModePerItem {
+		handleError(w, r, violations, h.logger)
+		return
+	}
+
+	metrics.BetBatches.Add(1)
+
+	created := 0
+	if mode == BatchModePerItem {
+		for j, outcome := range h.service.CreateBetsPerItem(r.Context(), requests, clientFrom(r), actorFrom(r)) {
+			i := indexes[j]
+			if outcome.Err != nil {
+				results[i] = h.rejectedItem(r, version, i, outcome.Err)
+				rejected++
+				continue
+			}
+			results[i] = BatchItemResultDTO{Index: i, Status: BatchItemCreated, Bet: betResponse(version, outcome.Bet)}
+			created++
+		}
+	} else {
+		bets, err := h.service.CreateBets(r.Context(), requests, clientFrom(r), actorFrom(r))
+		if err != nil {
+			handleError(w, r, err, h.logger)
+			return
+		}
+
+		for j, bet := range bets {
+			i := indexes[j]
+			results[i] = BatchItemResultDTO{Index: i, Status: BatchItemCreated, Bet: betResponse(version, bet)}
+		}
+		created = len(bets)
+	}
+
+	metrics.BetBatchItemsRejected.Add(int64(rejected))
+	span.SetAttributes(
+		attribute.Int("batch.created", created),
+		attribute.Int("batch.rejected", rejected),
+	)
+
+	status := http.StatusCreated
+	if rejected > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	sendJSON(w, status, CreateBetsResponseDTO{
+		Mode:     mode,
+		Created:  created,
+		Rejected: rejected,
+		Results:  results,
+	}, h.logger)
+}
+
+func (h *BetHandler) rejectedItem(r *http.Request, version APIVersion, index int, err error) BatchItemResultDTO {
+	result := BatchItemResultDTO{Index: index, Status: BatchItemRejected}
+
+	response, ok := rejectionResponse(err)
+	if !ok {
+		h.logger.Error("failed to place batch item",
+			zap.String("request_id", middleware.GetRequestID(r.Context())),
+			zap.Int("index", index),
+			zap.Error(err),
+		)
+		response = ErrorResponse{Error: "An internal error occurred", Code: "INTERNAL_ERROR"}
+	}
+
+	result.Code = response.Code
+	result.Error = response.Error
+	switch {
+	case response.Limit != nil && version.DecimalStrings:
+		result.Limit = limitErrorV2DTO(response.Limit)
+	case response.Limit != nil:
+		result.Limit = response.Limit
+	}
+	switch {
+	case response.Risk != nil && version.DecimalStrings:
+		result.Risk = riskErrorV2DTO(response.Risk)
+	case response.Risk != nil:
+		result.Risk = response.Risk
+	}
+	return result
+}
+
+func (h *BetHandler) decodeCreateBet(r *http.Request) (CreateBetRequest, error) {
+	body, err := readJSONBody(r, h.logger)
+	if err != nil {
+		return CreateBetRequest{}, err
+	}
+
+	req, err := parseCreateBet(versionOf(r), body)
+	if err != nil {
+		h.logger.Warn("failed to decode request body",
+			zap.String("request_id", middleware.GetRequestID(r.Context())),
+			zap.Error(err),
+		)
+	}
+	return req, err
+}
+
+func (h *BetHandler) parseBetRequest(version APIVersion, data []byte) (domain.PlaceBetRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return domain.PlaceBetRequest{}, &domain.ValidationError{Field: "body", Message: "invalid request body"}
+	}
+
+	if err := h.validator.ValidatePayload(createBetSchemaName(version), payload); err != nil {
+		return domain.PlaceBetRequest{}, err
+	}
+
+	req, err := parseCreateBet(version, data)
+	if err != nil {
+		return domain.PlaceBetRequest{}, err
+	}
+
+	if err := h.validator.ValidateCreateRequest(req.UserID, req.Amount, req.CrashPoint); err != nil {
+		return domain.PlaceBetRequest{}, err
+	}
+
+	return domain.PlaceBetRequest{
+		UserID:     req.UserID,
+		Amount:     req.Amount,
+		CrashPoint: req.CrashPoint,
+		Currency:   req.Currency,
+	}, nil
+}
+
+func parseCreateBet(version APIVersion, data []byte) (CreateBetRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if version.StrictParams {
+		decoder.DisallowUnknownFields()
+	}
+
+	invalidBody := &domain.ValidationError{
+		Field:   "body",
+		Message: "invalid request body",
+	}
+
+	if !version.DecimalStrings {
+		var req CreateBetRequest
+		if err := decoder.Decode(&req); err != nil {
+			return CreateBetRequest{}, invalidBody
+		}
+		return req, nil
+	}
+
+	var req CreateBetV2Request
+	if err := decoder.Decode(&req); err != nil {
+		return CreateBetRequest{}, invalidBody
+	}
+
+	amount, err := parseDecimal("amount", req.Amount)
+	if err != nil {
+		return CreateBetRequest{}, err
+	}
+	crashPoint, err := parseDecimal("crash_point", req.CrashPoint)
+	if err != nil {
+		return CreateBetRequest{}, err
+	}
+
+	return CreateBetRequest{
+		UserID:     req.UserID,
+		Amount:     amount,
+		CrashPoint: crashPoint,
+		Currency:   req.Currency,
+	}, nil
+}
+
+func prefixViolations(prefix string, err error) domain.ValidationErrors {
+	violations := domain.FieldViolations(err)
+	if len(violations) == 0 {
+		return domain.ValidationErrors{{Field: prefix, Message: prefix + " is invalid"}}
+	}
+
+	prefixed := make(domain.ValidationErrors, len(violations))
+	for i, v := range violations {
+		if v.Field == "body" {
+			prefixed[i] = &domain.ValidationError{Field: prefix, Message: prefix + " must be a valid bet object"}
+			continue
+		}
+		field := prefix + "." + v.Field
+		prefixed[i] = &domain.ValidationError{Field: field, Message: field + strings.TrimPrefix(v.Message, v.Field)}
+	}
+	return prefixed
 }
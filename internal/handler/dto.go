@@ -1,25 +1,122 @@
 package handler
 
 import (
+	"bet/internal/auth"
 	"bet/internal/domain"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
 )
 
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModePerItem      = "per_item"
+
+	BatchItemCreated  = "created"
+	BatchItemRejected = "rejected"
+)
+
+type CreateBetRequest struct {
+	UserID     int64   `json:"user_id"`
+	Amount     float64 `json:"amount"`
+	CrashPoint float64 `json:"crash_point"`
+	Currency   string  `json:"currency,omitempty"`
+}
+
+type CreateBetV2Request struct {
+	UserID     int64  `json:"user_id"`
+	Amount     string `json:"amount"`
+	CrashPoint string `json:"crash_point"`
+	Currency   string `json:"currency,omitempty"`
+}
+
+type CreateBetsRequest struct {
+	Mode string            `json:"mode,omitempty"`
+	Bets []json.RawMessage `json:"bets"`
+}
+
+type VoidBetRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note,omitempty"`
+}
+
 type BetDTO struct {
 	ID         string  `json:"id"`
 	UserID     int64   `json:"user_id"`
 	Amount     float64 `json:"amount"`
 	CrashPoint float64 `json:"crash_point"`
+	Currency   string  `json:"currency,omitempty"`
+	Operator   string  `json:"operator,omitempty"`
+	RoundID    string  `json:"round_id,omitempty"`
+	Status     string  `json:"status"`
+	Payout     float64 `json:"payout"`
 	CreatedAt  string  `json:"created_at"`
+	SettledAt  string  `json:"settled_at,omitempty"`
+	VoidReason string  `json:"void_reason,omitempty"`
 }
 
 func BetDTOFromDomain(bet *domain.Bet) BetDTO {
-	return BetDTO{
+	dto := BetDTO{
 		ID:         bet.ID,
 		UserID:     bet.UserID,
 		Amount:     bet.Amount,
 		CrashPoint: bet.CrashPoint,
+		Currency:   bet.Currency,
+		Operator:   bet.Operator,
+		RoundID:    bet.RoundID,
+		Status:     string(bet.Status),
+		Payout:     bet.Payout,
 		CreatedAt:  bet.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		VoidReason: string(bet.VoidReason),
 	}
+
+	if bet.SettledAt != nil {
+		dto.SettledAt = bet.SettledAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return dto
+}
+
+type BetV2DTO struct {
+	ID         string `json:"id"`
+	UserID     int64  `json:"user_id"`
+	Amount     string `json:"amount"`
+	CrashPoint string `json:"crash_point"`
+	Currency   string `json:"currency,omitempty"`
+	Operator   string `json:"operator,omitempty"`
+	RoundID    string `json:"round_id,omitempty"`
+	Status     string `json:"status"`
+	Payout     string `json:"payout"`
+	CreatedAt  string `json:"created_at"`
+	SettledAt  string `json:"settled_at,omitempty"`
+	VoidReason string `json:"void_reason,omitempty"`
+}
+
+func BetV2DTOFromDomain(bet *domain.Bet) BetV2DTO {
+	dto := BetDTOFromDomain(bet)
+
+	return BetV2DTO{
+		ID:         dto.ID,
+		UserID:     dto.UserID,
+		Amount:     formatDecimal(dto.Amount),
+		CrashPoint: formatDecimal(dto.CrashPoint),
+		Currency:   dto.Currency,
+		Operator:   dto.Operator,
+		RoundID:    dto.RoundID,
+		Status:     dto.Status,
+		Payout:     formatDecimal(dto.Payout),
+		CreatedAt:  dto.CreatedAt,
+		SettledAt:  dto.SettledAt,
+		VoidReason: dto.VoidReason,
+	}
+}
+
+func betResponse(v APIVersion, bet *domain.Bet) interface{} {
+	if v.DecimalStrings {
+		return BetV2DTOFromDomain(bet)
+	}
+	return BetDTOFromDomain(bet)
 }
 
 type ListBetsResponseDTO struct {
@@ -42,3 +139,1300 @@ func ListBetsResponseDTOFromDomain(resp domain.ListBetsResponse) ListBetsRespons
 		Limit: resp.Limit,
 	}
 }
+
+type ListBetsResponseV2DTO struct {
+	Bets  []BetV2DTO `json:"bets"`
+	Total int        `json:"total"`
+	Page  int        `json:"page"`
+	Limit int        `json:"limit"`
+}
+
+func ListBetsResponseV2DTOFromDomain(resp domain.ListBetsResponse) ListBetsResponseV2DTO {
+	bets := make([]BetV2DTO, len(resp.Bets))
+	for i, bet := range resp.Bets {
+		bets[i] = BetV2DTOFromDomain(&bet)
+	}
+
+	return ListBetsResponseV2DTO{
+		Bets:  bets,
+		Total: resp.Total,
+		Page:  resp.Page,
+		Limit: resp.Limit,
+	}
+}
+
+func listBetsResponse(v APIVersion, resp domain.ListBetsResponse) interface{} {
+	if v.DecimalStrings {
+		return ListBetsResponseV2DTOFromDomain(resp)
+	}
+	return ListBetsResponseDTOFromDomain(resp)
+}
+
+type BatchItemResultDTO struct {
+	Index  int          `json:"index"`
+	Status string       `json:"status"`
+	Bet    interface{}  `json:"bet,omitempty"`
+	Errors []FieldError `json:"errors,omitempty"`
+	Code   string       `json:"code,omitempty"`
+	Error  string       `json:"error,omitempty"`
+	Limit  interface{}  `json:"limit,omitempty"`
+	Risk   interface{}  `json:"risk,omitempty"`
+}
+
+type CreateBetsResponseDTO struct {
+	Mode     string               `json:"mode"`
+	Created  int                  `json:"created"`
+	Rejected int                  `json:"rejected"`
+	Results  []BatchItemResultDTO `json:"results"`
+}
+
+type AuditEntryDTO struct {
+	ID         string  `json:"id"`
+	Action     string  `json:"action"`
+	Resource   string  `json:"resource"`
+	ResourceID string  `json:"resource_id"`
+	Actor      string  `json:"actor"`
+	RequestID  string  `json:"request_id,omitempty"`
+	FromStatus string  `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Reason     string  `json:"reason,omitempty"`
+	Note       string  `json:"note,omitempty"`
+	Refund     float64 `json:"refund"`
+	At         string  `json:"at"`
+}
+
+func AuditEntryDTOFromDomain(entry domain.AuditEntry) AuditEntryDTO {
+	return AuditEntryDTO{
+		ID:         entry.ID,
+		Action:     string(entry.Action),
+		Resource:   entry.Resource,
+		ResourceID: entry.ResourceID,
+		Actor:      entry.Actor.ID,
+		RequestID:  entry.Actor.RequestID,
+		FromStatus: entry.FromStatus,
+		ToStatus:   entry.ToStatus,
+		Reason:     entry.Reason,
+		Note:       entry.Note,
+		Refund:     entry.Refund,
+		At:         entry.At.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+type AuditEntryV2DTO struct {
+	ID         string `json:"id"`
+	Action     string `json:"action"`
+	Resource   string `json:"resource"`
+	ResourceID string `json:"resource_id"`
+	Actor      string `json:"actor"`
+	RequestID  string `json:"request_id,omitempty"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason,omitempty"`
+	Note       string `json:"note,omitempty"`
+	Refund     string `json:"refund"`
+	At         string `json:"at"`
+}
+
+func AuditEntryV2DTOFromDomain(entry domain.AuditEntry) AuditEntryV2DTO {
+	dto := AuditEntryDTOFromDomain(entry)
+
+	return AuditEntryV2DTO{
+		ID:         dto.ID,
+		Action:     dto.Action,
+		Resource:   dto.Resource,
+		ResourceID: dto.ResourceID,
+		Actor:      dto.Actor,
+		RequestID:  dto.RequestID,
+		FromStatus: dto.FromStatus,
+		ToStatus:   dto.ToStatus,
+		Reason:     dto.Reason,
+		Note:       dto.Note,
+		Refund:     formatDecimal(dto.Refund),
+		At:         dto.At,
+	}
+}
+
+type ListAuditEntriesResponseDTO struct {
+	Entries []AuditEntryDTO `json:"entries"`
+}
+
+type ListAuditEntriesResponseV2DTO struct {
+	Entries []AuditEntryV2DTO `json:"entries"`
+}
+
+func auditResponse(v APIVersion, entries []domain.AuditEntry) interface{} {
+	if v.DecimalStrings {
+		dtos := make([]AuditEntryV2DTO, len(entries))
+		for i, entry := range entries {
+			dtos[i] = AuditEntryV2DTOFromDomain(entry)
+		}
+		return ListAuditEntriesResponseV2DTO{Entries: dtos}
+	}
+
+	dtos := make([]AuditEntryDTO, len(entries))
+	for i, entry := range entries {
+		dtos[i] = AuditEntryDTOFromDomain(entry)
+	}
+	return ListAuditEntriesResponseDTO{Entries: dtos}
+}
+
+type ImportBetsRequest struct {
+	Bets []BetDTO `json:"bets"`
+}
+
+type ImportBetsV2Request struct {
+	Bets []BetV2DTO `json:"bets"`
+}
+
+type ImportBetsResponseDTO struct {
+	Imported   int      `json:"imported"`
+	Duplicates []string `json:"duplicates"`
+}
+
+func ImportBetsResponseDTOFromDomain(result domain.ImportResult) ImportBetsResponseDTO {
+	duplicates := result.Duplicates
+	if duplicates == nil {
+		duplicates = []string{}
+	}
+	return ImportBetsResponseDTO{
+		Imported:   result.Imported,
+		Duplicates: duplicates,
+	}
+}
+
+func betFromDTO(dto BetDTO) (domain.Bet, error) {
+	bet := domain.Bet{
+		ID:         dto.ID,
+		UserID:     dto.UserID,
+		Amount:     dto.Amount,
+		CrashPoint: dto.CrashPoint,
+		Currency:   dto.Currency,
+		Operator:   dto.Operator,
+		RoundID:    dto.RoundID,
+		Status:     domain.BetStatus(dto.Status),
+		Payout:     dto.Payout,
+		VoidReason: domain.VoidReason(dto.VoidReason),
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, dto.CreatedAt)
+	if err != nil {
+		return domain.Bet{}, &domain.ValidationError{Field: "created_at", Message: "created_at must be an RFC 3339 date-time"}
+	}
+	bet.CreatedAt = createdAt
+
+	if dto.SettledAt != "" {
+		settledAt, err := time.Parse(time.RFC3339Nano, dto.SettledAt)
+		if err != nil {
+			return domain.Bet{}, &domain.ValidationError{Field: "settled_at", Message: "settled_at must be an RFC 3339 date-time"}
+		}
+		bet.SettledAt = &settledAt
+	}
+
+	return bet, nil
+}
+
+func betFromV2DTO(dto BetV2DTO) (domain.Bet, error) {
+	var errs domain.ValidationErrors
+	decimal := func(field, value string) float64 {
+		parsed, err := parseDecimal(field, value)
+		if err != nil {
+			errs = append(errs, domain.FieldViolations(err)...)
+		}
+		return parsed
+	}
+
+	v1 := BetDTO{
+		ID:         dto.ID,
+		UserID:     dto.UserID,
+		Amount:     decimal("amount", dto.Amount),
+		CrashPoint: decimal("crash_point", dto.CrashPoint),
+		Currency:   dto.Currency,
+		Operator:   dto.Operator,
+		RoundID:    dto.RoundID,
+		Status:     dto.Status,
+		Payout:     decimal("payout", dto.Payout),
+		CreatedAt:  dto.CreatedAt,
+		SettledAt:  dto.SettledAt,
+		VoidReason: dto.VoidReason,
+	}
+	if len(errs) > 0 {
+		return domain.Bet{}, errs
+	}
+
+	return betFromDTO(v1)
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type WebhookDTO struct {
+	ID                  string   `json:"id"`
+	URL                 string   `json:"url"`
+	Events              []string `json:"events"`
+	Active              bool     `json:"active"`
+	ConsecutiveFailures int      `json:"consecutive_failures"`
+	CreatedAt           string   `json:"created_at"`
+	DisabledAt          string   `json:"disabled_at,omitempty"`
+}
+
+func WebhookDTOFromDomain(webhook *domain.Webhook) WebhookDTO {
+	dto := WebhookDTO{
+		ID:                  webhook.ID,
+		URL:                 webhook.URL,
+		Events:              webhook.Events,
+		Active:              webhook.Active,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if webhook.DisabledAt != nil {
+		dto.DisabledAt = webhook.DisabledAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return dto
+}
+
+type WebhookDeliveryDTO struct {
+	ID         string `json:"id"`
+	EventID    string `json:"event_id"`
+	EventType  string `json:"event_type"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ListWebhookDeliveriesResponseDTO struct {
+	WebhookID  string               `json:"webhook_id"`
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+}
+
+func ListWebhookDeliveriesResponseDTOFromDomain(webhookID string, deliveries []domain.WebhookDelivery) ListWebhookDeliveriesResponseDTO {
+	dtos := make([]WebhookDeliveryDTO, len(deliveries))
+	for i, d := range deliveries {
+		dtos[i] = WebhookDeliveryDTO{
+			ID:         d.ID,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Success:    d.Success,
+			Error:      d.Error,
+			DurationMs: d.Duration.Milliseconds(),
+			CreatedAt:  d.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	return ListWebhookDeliveriesResponseDTO{
+		WebhookID:  webhookID,
+		Deliveries: dtos,
+	}
+}
+
+type RoundDTO struct {
+	ID         string  `json:"id"`
+	Status     string  `json:"status"`
+	CrashPoint float64 `json:"crash_point,omitempty"`
+	Multiplier float64 `json:"multiplier"`
+	Bets       int     `json:"bets"`
+	Wagered    float64 `json:"wagered"`
+	PaidOut    float64 `json:"paid_out"`
+	OpenedAt   string  `json:"opened_at"`
+	StartedAt  string  `json:"started_at,omitempty"`
+	CrashedAt  string  `json:"crashed_at,omitempty"`
+}
+
+func RoundDTOFromDomain(summary domain.RoundSummary) RoundDTO {
+	round := summary.Round
+	dto := RoundDTO{
+		ID:         round.ID,
+		Status:     string(round.Status),
+		CrashPoint: round.CrashPoint,
+		Multiplier: summary.Multiplier,
+		Bets:       summary.Bets,
+		Wagered:    summary.Wagered,
+		PaidOut:    summary.PaidOut,
+		OpenedAt:   round.OpenedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if round.StartedAt != nil {
+		dto.StartedAt = round.StartedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if round.CrashedAt != nil {
+		dto.CrashedAt = round.CrashedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return dto
+}
+
+type RoundV2DTO struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	CrashPoint string `json:"crash_point,omitempty"`
+	Multiplier string `json:"multiplier"`
+	Bets       int    `json:"bets"`
+	Wagered    string `json:"wagered"`
+	PaidOut    string `json:"paid_out"`
+	OpenedAt   string `json:"opened_at"`
+	StartedAt  string `json:"started_at,omitempty"`
+	CrashedAt  string `json:"crashed_at,omitempty"`
+}
+
+func RoundV2DTOFromDomain(summary domain.RoundSummary) RoundV2DTO {
+	dto := RoundDTOFromDomain(summary)
+
+	v2 := RoundV2DTO{
+		ID:         dto.ID,
+		Status:     dto.Status,
+		Multiplier: formatDecimal(dto.Multiplier),
+		Bets:       dto.Bets,
+		Wagered:    formatDecimal(dto.Wagered),
+		PaidOut:    formatDecimal(dto.PaidOut),
+		OpenedAt:   dto.OpenedAt,
+		StartedAt:  dto.StartedAt,
+		CrashedAt:  dto.CrashedAt,
+	}
+	if dto.CrashPoint > 0 {
+		v2.CrashPoint = formatDecimal(dto.CrashPoint)
+	}
+
+	return v2
+}
+
+func roundResponse(v APIVersion, summary domain.RoundSummary) interface{} {
+	if v.DecimalStrings {
+		return RoundV2DTOFromDomain(summary)
+	}
+	return RoundDTOFromDomain(summary)
+}
+
+type ListRoundsResponseDTO struct {
+	Rounds []RoundDTO `json:"rounds"`
+}
+
+type ListRoundsResponseV2DTO struct {
+	Rounds []RoundV2DTO `json:"rounds"`
+}
+
+func listRoundsResponse(v APIVersion, summaries []domain.RoundSummary) interface{} {
+	if v.DecimalStrings {
+		dtos := make([]RoundV2DTO, len(summaries))
+		for i, summary := range summaries {
+			dtos[i] = RoundV2DTOFromDomain(summary)
+		}
+		return ListRoundsResponseV2DTO{Rounds: dtos}
+	}
+
+	dtos := make([]RoundDTO, len(summaries))
+	for i, summary := range summaries {
+		dtos[i] = RoundDTOFromDomain(summary)
+	}
+	return ListRoundsResponseDTO{Rounds: dtos}
+}
+
+type UserStatsDTO struct {
+	UserID        int64   `json:"user_id"`
+	From          string  `json:"from,omitempty"`
+	To            string  `json:"to,omitempty"`
+	Bets          int     `json:"bets"`
+	PendingBets   int     `json:"pending_bets"`
+	WonBets       int     `json:"won_bets"`
+	LostBets      int     `json:"lost_bets"`
+	RefundedBets  int     `json:"refunded_bets"`
+	TotalWagered  float64 `json:"total_wagered"`
+	TotalPaidOut  float64 `json:"total_paid_out"`
+	NetPnL        float64 `json:"net_pnl"`
+	WinRate       float64 `json:"win_rate"`
+	AvgCrashPoint float64 `json:"avg_crash_point"`
+	MaxCrashPoint float64 `json:"max_crash_point"`
+	BiggestWin    float64 `json:"biggest_win"`
+	FirstBetAt    string  `json:"first_bet_at,omitempty"`
+	LastBetAt     string  `json:"last_bet_at,omitempty"`
+}
+
+func UserStatsDTOFromDomain(stats domain.UserStats, req domain.UserStatsRequest) UserStatsDTO {
+	dto := UserStatsDTO{
+		UserID:        stats.UserID,
+		Bets:          stats.Bets,
+		PendingBets:   stats.Pending,
+		WonBets:       stats.Won,
+		LostBets:      stats.Lost,
+		RefundedBets:  stats.Refunded,
+		TotalWagered:  roundCents(stats.Wagered),
+		TotalPaidOut:  roundCents(stats.PaidOut),
+		NetPnL:        roundCents(stats.NetPnL()),
+		WinRate:       math.Round(stats.WinRate()*10000) / 10000,
+		AvgCrashPoint: roundCents(stats.AvgCrashPoint()),
+		MaxCrashPoint: stats.MaxCrashPoint,
+		BiggestWin:    stats.BiggestWin,
+	}
+
+	if req.From != nil {
+		dto.From = req.From.Format(time.DateOnly)
+	}
+	if req.To != nil {
+		dto.To = req.To.Format(time.DateOnly)
+	}
+	if stats.Bets > 0 {
+		dto.FirstBetAt = stats.FirstBetAt.Format("2006-01-02T15:04:05Z07:00")
+		dto.LastBetAt = stats.LastBetAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return dto
+}
+
+type UserStatsV2DTO struct {
+	UserID        int64   `json:"user_id"`
+	From          string  `json:"from,omitempty"`
+	To            string  `json:"to,omitempty"`
+	Bets          int     `json:"bets"`
+	PendingBets   int     `json:"pending_bets"`
+	WonBets       int     `json:"won_bets"`
+	LostBets      int     `json:"lost_bets"`
+	RefundedBets  int     `json:"refunded_bets"`
+	TotalWagered  string  `json:"total_wagered"`
+	TotalPaidOut  string  `json:"total_paid_out"`
+	NetPnL        string  `json:"net_pnl"`
+	WinRate       float64 `json:"win_rate"`
+	AvgCrashPoint string  `json:"avg_crash_point"`
+	MaxCrashPoint string  `json:"max_crash_point"`
+	BiggestWin    string  `json:"biggest_win"`
+	FirstBetAt    string  `json:"first_bet_at,omitempty"`
+	LastBetAt     string  `json:"last_bet_at,omitempty"`
+}
+
+func UserStatsV2DTOFromDomain(stats domain.UserStats, req domain.UserStatsRequest) UserStatsV2DTO {
+	dto := UserStatsDTOFromDomain(stats, req)
+
+	return UserStatsV2DTO{
+		UserID:        dto.UserID,
+		From:          dto.From,
+		To:            dto.To,
+		Bets:          dto.Bets,
+		PendingBets:   dto.PendingBets,
+		WonBets:       dto.WonBets,
+		LostBets:      dto.LostBets,
+		RefundedBets:  dto.RefundedBets,
+		TotalWagered:  formatDecimal(dto.TotalWagered),
+		TotalPaidOut:  formatDecimal(dto.TotalPaidOut),
+		NetPnL:        formatDecimal(dto.NetPnL),
+		WinRate:       dto.WinRate,
+		AvgCrashPoint: formatDecimal(dto.AvgCrashPoint),
+		MaxCrashPoint: formatDecimal(dto.MaxCrashPoint),
+		BiggestWin:    formatDecimal(dto.BiggestWin),
+		FirstBetAt:    dto.FirstBetAt,
+		LastBetAt:     dto.LastBetAt,
+	}
+}
+
+func userStatsResponse(v APIVersion, stats domain.UserStats, req domain.UserStatsRequest) interface{} {
+	if v.DecimalStrings {
+		return UserStatsV2DTOFromDomain(stats, req)
+	}
+	return UserStatsDTOFromDomain(stats, req)
+}
+
+type ReportTotalsDTO struct {
+	Bets            int      `json:"bets"`
+	Players         int      `json:"players"`
+	RefundedBets    int      `json:"refunded_bets"`
+	Turnover        float64  `json:"turnover"`
+	SettledTurnover float64  `json:"settled_turnover"`
+	Payouts         float64  `json:"payouts"`
+	Refunds         float64  `json:"refunds"`
+	GGR             float64  `json:"ggr"`
+	RTP             *float64 `json:"rtp,omitempty"`
+}
+
+func ReportTotalsDTOFromDomain(totals domain.ReportTotals) ReportTotalsDTO {
+	dto := ReportTotalsDTO{
+		Bets:            totals.Bets,
+		Players:         totals.Players,
+		RefundedBets:    totals.Refunded,
+		Turnover:        roundCents(totals.Turnover),
+		SettledTurnover: roundCents(totals.SettledTurnover),
+		Payouts:         roundCents(totals.Payouts),
+		Refunds:         roundCents(totals.Refunds),
+		GGR:             roundCents(totals.GGR()),
+	}
+	if rtp, ok := totals.RTP(); ok {
+		rtp = math.Round(rtp*10000) / 10000
+		dto.RTP = &rtp
+	}
+	return dto
+}
+
+type ReportTotalsV2DTO struct {
+	Bets            int      `json:"bets"`
+	Players         int      `json:"players"`
+	RefundedBets    int      `json:"refunded_bets"`
+	Turnover        string   `json:"turnover"`
+	SettledTurnover string   `json:"settled_turnover"`
+	Payouts         string   `json:"payouts"`
+	Refunds         string   `json:"refunds"`
+	GGR             string   `json:"ggr"`
+	RTP             *float64 `json:"rtp,omitempty"`
+}
+
+func ReportTotalsV2DTOFromDomain(totals domain.ReportTotals) ReportTotalsV2DTO {
+	dto := ReportTotalsDTOFromDomain(totals)
+	return ReportTotalsV2DTO{
+		Bets:            dto.Bets,
+		Players:         dto.Players,
+		RefundedBets:    dto.RefundedBets,
+		Turnover:        formatDecimal(dto.Turnover),
+		SettledTurnover: formatDecimal(dto.SettledTurnover),
+		Payouts:         formatDecimal(dto.Payouts),
+		Refunds:         formatDecimal(dto.Refunds),
+		GGR:             formatDecimal(dto.GGR),
+		RTP:             dto.RTP,
+	}
+}
+
+type ReportBucketDTO struct {
+	Start  string          `json:"start"`
+	End    string          `json:"end"`
+	Totals ReportTotalsDTO `json:"totals"`
+}
+
+type ReportBucketV2DTO struct {
+	Start  string            `json:"start"`
+	End    string            `json:"end"`
+	Totals ReportTotalsV2DTO `json:"totals"`
+}
+
+type ReportSummaryDTO struct {
+	From           string            `json:"from"`
+	To             string            `json:"to"`
+	Granularity    string            `json:"granularity"`
+	Currency       string            `json:"currency,omitempty"`
+	Operator       string            `json:"operator,omitempty"`
+	TheoreticalRTP float64           `json:"theoretical_rtp"`
+	Totals         ReportTotalsDTO   `json:"totals"`
+	Buckets        []ReportBucketDTO `json:"buckets"`
+}
+
+type ReportSummaryV2DTO struct {
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	Granularity    string              `json:"granularity"`
+	Currency       string              `json:"currency,omitempty"`
+	Operator       string              `json:"operator,omitempty"`
+	TheoreticalRTP float64             `json:"theoretical_rtp"`
+	Totals         ReportTotalsV2DTO   `json:"totals"`
+	Buckets        []ReportBucketV2DTO `json:"buckets"`
+}
+
+func reportSummaryResponse(v APIVersion, report domain.Report) interface{} {
+	from := report.From.UTC().Format("2006-01-02T15:04:05Z07:00")
+	to := report.To.UTC().Format("2006-01-02T15:04:05Z07:00")
+	granularity := string(report.Granularity)
+
+	if v.DecimalStrings {
+		buckets := make([]ReportBucketV2DTO, len(report.Buckets))
+		for i, bucket := range report.Buckets {
+			buckets[i] = ReportBucketV2DTO{
+				Start:  bucket.Start.Format("2006-01-02T15:04:05Z07:00"),
+				End:    bucket.End.Format("2006-01-02T15:04:05Z07:00"),
+				Totals: ReportTotalsV2DTOFromDomain(bucket.ReportTotals),
+			}
+		}
+		return ReportSummaryV2DTO{
+			From:           from,
+			To:             to,
+			Granularity:    granularity,
+			Currency:       report.Currency,
+			Operator:       report.Operator,
+			TheoreticalRTP: report.TheoreticalRTP,
+			Totals:         ReportTotalsV2DTOFromDomain(report.Totals),
+			Buckets:        buckets,
+		}
+	}
+
+	buckets := make([]ReportBucketDTO, len(report.Buckets))
+	for i, bucket := range report.Buckets {
+		buckets[i] = ReportBucketDTO{
+			Start:  bucket.Start.Format("2006-01-02T15:04:05Z07:00"),
+			End:    bucket.End.Format("2006-01-02T15:04:05Z07:00"),
+			Totals: ReportTotalsDTOFromDomain(bucket.ReportTotals),
+		}
+	}
+	return ReportSummaryDTO{
+		From:           from,
+		To:             to,
+		Granularity:    granularity,
+		Currency:       report.Currency,
+		Operator:       report.Operator,
+		TheoreticalRTP: report.TheoreticalRTP,
+		Totals:         ReportTotalsDTOFromDomain(report.Totals),
+		Buckets:        buckets,
+	}
+}
+
+type LeaderboardEntryDTO struct {
+	Rank       int     `json:"rank"`
+	User       string  `json:"user"`
+	Self       bool    `json:"self,omitempty"`
+	Amount     float64 `json:"amount"`
+	Payout     float64 `json:"payout"`
+	Multiplier float64 `json:"multiplier"`
+	SettledAt  string  `json:"settled_at"`
+}
+
+type LeaderboardEntryV2DTO struct {
+	Rank       int    `json:"rank"`
+	User       string `json:"user"`
+	Self       bool   `json:"self,omitempty"`
+	Amount     string `json:"amount"`
+	Payout     string `json:"payout"`
+	Multiplier string `json:"multiplier"`
+	SettledAt  string `json:"settled_at"`
+}
+
+type LeaderboardDTO struct {
+	Type    string                `json:"type"`
+	Window  string                `json:"window"`
+	Entries []LeaderboardEntryDTO `json:"entries"`
+}
+
+type LeaderboardV2DTO struct {
+	Type    string                  `json:"type"`
+	Window  string                  `json:"window"`
+	Entries []LeaderboardEntryV2DTO `json:"entries"`
+}
+
+func leaderboardResponse(v APIVersion, board domain.Leaderboard) interface{} {
+	if v.DecimalStrings {
+		entries := make([]LeaderboardEntryV2DTO, len(board.Entries))
+		for i, entry := range board.Entries {
+			entries[i] = LeaderboardEntryV2DTO{
+				Rank:       entry.Rank,
+				User:       entry.User,
+				Self:       entry.Self,
+				Amount:     formatDecimal(entry.Amount),
+				Payout:     formatDecimal(entry.Payout),
+				Multiplier: formatDecimal(entry.Multiplier),
+				SettledAt:  entry.SettledAt.Format("2006-01-02T15:04:05Z07:00"),
+			}
+		}
+		return LeaderboardV2DTO{Type: string(board.Type), Window: string(board.Window), Entries: entries}
+	}
+
+	entries := make([]LeaderboardEntryDTO, len(board.Entries))
+	for i, entry := range board.Entries {
+		entries[i] = LeaderboardEntryDTO{
+			Rank:       entry.Rank,
+			User:       entry.User,
+			Self:       entry.Self,
+			Amount:     entry.Amount,
+			Payout:     entry.Payout,
+			Multiplier: entry.Multiplier,
+			SettledAt:  entry.SettledAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	return LeaderboardDTO{Type: string(board.Type), Window: string(board.Window), Entries: entries}
+}
+
+type SetLimitRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type SetLimitV2Request struct {
+	Amount string `json:"amount"`
+}
+
+type DepositRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type DepositV2Request struct {
+	Amount string `json:"amount"`
+}
+
+type CoolOffRequest struct {
+	Days int `json:"days"`
+}
+
+type SelfExclusionRequest struct {
+	Duration string `json:"duration"`
+}
+
+type PendingLimitDTO struct {
+	Amount      *float64 `json:"amount"`
+	EffectiveAt string   `json:"effective_at"`
+}
+
+type PendingLimitV2DTO struct {
+	Amount      *string `json:"amount"`
+	EffectiveAt string  `json:"effective_at"`
+}
+
+type LimitDTO struct {
+	Type      string           `json:"type"`
+	Period    string           `json:"period"`
+	Amount    float64          `json:"amount"`
+	Used      *float64         `json:"used,omitempty"`
+	Remaining *float64         `json:"remaining,omitempty"`
+	ResetsAt  string           `json:"resets_at"`
+	Pending   *PendingLimitDTO `json:"pending,omitempty"`
+}
+
+type LimitV2DTO struct {
+	Type      string             `json:"type"`
+	Period    string             `json:"period"`
+	Amount    string             `json:"amount"`
+	Used      *string            `json:"used,omitempty"`
+	Remaining *string            `json:"remaining,omitempty"`
+	ResetsAt  string             `json:"resets_at"`
+	Pending   *PendingLimitV2DTO `json:"pending,omitempty"`
+}
+
+type RestrictionDTO struct {
+	Since     string `json:"since"`
+	Until     string `json:"until,omitempty"`
+	Permanent bool   `json:"permanent"`
+}
+
+type LimitsDTO struct {
+	UserID        int64           `json:"user_id"`
+	Limits        []LimitDTO      `json:"limits"`
+	CoolOff       *RestrictionDTO `json:"cool_off,omitempty"`
+	SelfExclusion *RestrictionDTO `json:"self_exclusion,omitempty"`
+}
+
+type LimitsV2DTO struct {
+	UserID        int64           `json:"user_id"`
+	Limits        []LimitV2DTO    `json:"limits"`
+	CoolOff       *RestrictionDTO `json:"cool_off,omitempty"`
+	SelfExclusion *RestrictionDTO `json:"self_exclusion,omitempty"`
+}
+
+func LimitsDTOFromDomain(status domain.LimitsStatus) LimitsDTO {
+	dto := LimitsDTO{
+		UserID:        status.UserID,
+		Limits:        make([]LimitDTO, len(status.Limits)),
+		CoolOff:       restrictionDTO(status.CoolOff),
+		SelfExclusion: restrictionDTO(status.SelfExclusion),
+	}
+
+	for i, usage := range status.Limits {
+		limit := LimitDTO{
+			Type:     string(usage.Type),
+			Period:   string(usage.Period),
+			ResetsAt: usage.ResetsAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if usage.Amount != nil {
+			limit.Amount = *usage.Amount
+		}
+		if usage.Tracked {
+			used, remaining := roundCents(usage.Used), roundCents(usage.Remaining)
+			limit.Used, limit.Remaining = &used, &remaining
+		}
+		if usage.Pending != nil {
+			limit.Pending = &PendingLimitDTO{
+				Amount:      usage.Pending.Amount,
+				EffectiveAt: usage.Pending.EffectiveAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+			}
+		}
+		dto.Limits[i] = limit
+	}
+
+	return dto
+}
+
+func LimitsV2DTOFromDomain(status domain.LimitsStatus) LimitsV2DTO {
+	dto := LimitsDTOFromDomain(status)
+	decimal := func(v *float64) *string {
+		if v == nil {
+			return nil
+		}
+		s := formatDecimal(*v)
+		return &s
+	}
+
+	limits := make([]LimitV2DTO, len(dto.Limits))
+	for i, limit := range dto.Limits {
+		limits[i] = LimitV2DTO{
+			Type:      limit.Type,
+			Period:    limit.Period,
+			Amount:    formatDecimal(limit.Amount),
+			Used:      decimal(limit.Used),
+			Remaining: decimal(limit.Remaining),
+			ResetsAt:  limit.ResetsAt,
+		}
+		if limit.Pending != nil {
+			limits[i].Pending = &PendingLimitV2DTO{
+				Amount:      decimal(limit.Pending.Amount),
+				EffectiveAt: limit.Pending.EffectiveAt,
+			}
+		}
+	}
+
+	return LimitsV2DTO{
+		UserID:        dto.UserID,
+		Limits:        limits,
+		CoolOff:       dto.CoolOff,
+		SelfExclusion: dto.SelfExclusion,
+	}
+}
+
+func limitsResponse(v APIVersion, status domain.LimitsStatus) interface{} {
+	if v.DecimalStrings {
+		return LimitsV2DTOFromDomain(status)
+	}
+	return LimitsDTOFromDomain(status)
+}
+
+func restrictionDTO(restriction *domain.Restriction) *RestrictionDTO {
+	if restriction == nil {
+		return nil
+	}
+	dto := &RestrictionDTO{
+		Since:     restriction.Since.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		Permanent: restriction.Until == nil,
+	}
+	if restriction.Until != nil {
+		dto.Until = restriction.Until.UTC().Format("2006-01-02T15:04:05Z07:00")
+	}
+	return dto
+}
+
+type RoundExposureDTO struct {
+	RoundID       string   `json:"round_id"`
+	Status        string   `json:"status"`
+	Bets          int      `json:"bets"`
+	Stakes        float64  `json:"stakes"`
+	Exposure      float64  `json:"exposure"`
+	Remaining     *float64 `json:"remaining,omitempty"`
+	MaxCrashPoint float64  `json:"max_crash_point"`
+	ThrottledAt   string   `json:"throttled_at,omitempty"`
+}
+
+type RoundExposureV2DTO struct {
+	RoundID       string  `json:"round_id"`
+	Status        string  `json:"status"`
+	Bets          int     `json:"bets"`
+	Stakes        string  `json:"stakes"`
+	Exposure      string  `json:"exposure"`
+	Remaining     *string `json:"remaining,omitempty"`
+	MaxCrashPoint string  `json:"max_crash_point"`
+	ThrottledAt   string  `json:"throttled_at,omitempty"`
+}
+
+type UserExposureDTO struct {
+	UserID    int64    `json:"user_id"`
+	Bets      int      `json:"bets"`
+	Exposure  float64  `json:"exposure"`
+	Remaining *float64 `json:"remaining,omitempty"`
+}
+
+type UserExposureV2DTO struct {
+	UserID    int64   `json:"user_id"`
+	Bets      int     `json:"bets"`
+	Exposure  string  `json:"exposure"`
+	Remaining *string `json:"remaining,omitempty"`
+}
+
+type ExposureDTO struct {
+	Mode                string             `json:"mode"`
+	RoundLiability      float64            `json:"round_liability"`
+	UserLiability       float64            `json:"user_liability"`
+	ThrottleRatio       float64            `json:"throttle_ratio"`
+	ThrottledCrashPoint float64            `json:"throttled_crash_point"`
+	Exposure            float64            `json:"exposure"`
+	Rounds              []RoundExposureDTO `json:"rounds"`
+	TopUsers            []UserExposureDTO  `json:"top_users"`
+}
+
+type ExposureV2DTO struct {
+	Mode                string               `json:"mode"`
+	RoundLiability      string               `json:"round_liability"`
+	UserLiability       string               `json:"user_liability"`
+	ThrottleRatio       float64              `json:"throttle_ratio"`
+	ThrottledCrashPoint string               `json:"throttled_crash_point"`
+	Exposure            string               `json:"exposure"`
+	Rounds              []RoundExposureV2DTO `json:"rounds"`
+	TopUsers            []UserExposureV2DTO  `json:"top_users"`
+}
+
+func ExposureDTOFromDomain(report domain.ExposureReport) ExposureDTO {
+	remaining := func(limit, exposure float64) *float64 {
+		if limit <= 0 {
+			return nil
+		}
+		left := roundCents(max(limit-exposure, 0))
+		return &left
+	}
+
+	dto := ExposureDTO{
+		Mode:                string(report.Mode),
+		RoundLiability:      report.RoundLiability,
+		UserLiability:       report.UserLiability,
+		ThrottleRatio:       report.ThrottleRatio,
+		ThrottledCrashPoint: report.ThrottledCrashPoint,
+		Exposure:            roundCents(report.Exposure),
+		Rounds:              make([]RoundExposureDTO, len(report.Rounds)),
+		TopUsers:            make([]UserExposureDTO, len(report.TopUsers)),
+	}
+	for i, round := range report.Rounds {
+		dto.Rounds[i] = RoundExposureDTO{
+			RoundID:       round.RoundID,
+			Status:        string(round.Status),
+			Bets:          round.Bets,
+			Stakes:        roundCents(round.Stakes),
+			Exposure:      roundCents(round.Exposure),
+			Remaining:     remaining(report.RoundLiability, round.Exposure),
+			MaxCrashPoint: round.MaxCrashPoint,
+		}
+		if round.ThrottledAt != nil {
+			dto.Rounds[i].ThrottledAt = round.ThrottledAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+		}
+	}
+	for i, user := range report.TopUsers {
+		dto.TopUsers[i] = UserExposureDTO{
+			UserID:    user.UserID,
+			Bets:      user.Bets,
+			Exposure:  roundCents(user.Exposure),
+			Remaining: remaining(report.UserLiability, user.Exposure),
+		}
+	}
+
+	return dto
+}
+
+func ExposureV2DTOFromDomain(report domain.ExposureReport) ExposureV2DTO {
+	dto := ExposureDTOFromDomain(report)
+	decimal := func(v *float64) *string {
+		if v == nil {
+			return nil
+		}
+		s := formatDecimal(*v)
+		return &s
+	}
+
+	rounds := make([]RoundExposureV2DTO, len(dto.Rounds))
+	for i, round := range dto.Rounds {
+		rounds[i] = RoundExposureV2DTO{
+			RoundID:       round.RoundID,
+			Status:        round.Status,
+			Bets:          round.Bets,
+			Stakes:        formatDecimal(round.Stakes),
+			Exposure:      formatDecimal(round.Exposure),
+			Remaining:     decimal(round.Remaining),
+			MaxCrashPoint: formatDecimal(round.MaxCrashPoint),
+			ThrottledAt:   round.ThrottledAt,
+		}
+	}
+	users := make([]UserExposureV2DTO, len(dto.TopUsers))
+	for i, user := range dto.TopUsers {
+		users[i] = UserExposureV2DTO{
+			UserID:    user.UserID,
+			Bets:      user.Bets,
+			Exposure:  formatDecimal(user.Exposure),
+			Remaining: decimal(user.Remaining),
+		}
+	}
+
+	return ExposureV2DTO{
+		Mode:                dto.Mode,
+		RoundLiability:      formatDecimal(dto.RoundLiability),
+		UserLiability:       formatDecimal(dto.UserLiability),
+		ThrottleRatio:       dto.ThrottleRatio,
+		ThrottledCrashPoint: formatDecimal(dto.ThrottledCrashPoint),
+		Exposure:            formatDecimal(dto.Exposure),
+		Rounds:              rounds,
+		TopUsers:            users,
+	}
+}
+
+func exposureResponse(v APIVersion, report domain.ExposureReport) interface{} {
+	if v.DecimalStrings {
+		return ExposureV2DTOFromDomain(report)
+	}
+	return ExposureDTOFromDomain(report)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+type ReviewFraudFlagRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
+
+type RuleHitDTO struct {
+	Rule   string  `json:"rule"`
+	Type   string  `json:"type"`
+	Score  float64 `json:"score"`
+	Action string  `json:"action"`
+	Reason string  `json:"reason"`
+}
+
+type FraudFlagDTO struct {
+	ID         string       `json:"id"`
+	UserID     int64        `json:"user_id"`
+	BetID      string       `json:"bet_id,omitempty"`
+	Amount     float64      `json:"amount"`
+	CrashPoint float64      `json:"crash_point"`
+	IP         string       `json:"ip,omitempty"`
+	DeviceID   string       `json:"device_id,omitempty"`
+	Score      float64      `json:"score"`
+	Action     string       `json:"action"`
+	Hits       []RuleHitDTO `json:"hits"`
+	Status     string       `json:"status"`
+	CreatedAt  string       `json:"created_at"`
+	ReviewedAt string       `json:"reviewed_at,omitempty"`
+	ReviewedBy string       `json:"reviewed_by,omitempty"`
+	Note       string       `json:"note,omitempty"`
+}
+
+type FraudFlagV2DTO struct {
+	ID         string       `json:"id"`
+	UserID     int64        `json:"user_id"`
+	BetID      string       `json:"bet_id,omitempty"`
+	Amount     string       `json:"amount"`
+	CrashPoint string       `json:"crash_point"`
+	IP         string       `json:"ip,omitempty"`
+	DeviceID   string       `json:"device_id,omitempty"`
+	Score      float64      `json:"score"`
+	Action     string       `json:"action"`
+	Hits       []RuleHitDTO `json:"hits"`
+	Status     string       `json:"status"`
+	CreatedAt  string       `json:"created_at"`
+	ReviewedAt string       `json:"reviewed_at,omitempty"`
+	ReviewedBy string       `json:"reviewed_by,omitempty"`
+	Note       string       `json:"note,omitempty"`
+}
+
+type ListFraudFlagsResponseDTO struct {
+	Flags []FraudFlagDTO `json:"flags"`
+}
+
+type ListFraudFlagsResponseV2DTO struct {
+	Flags []FraudFlagV2DTO `json:"flags"`
+}
+
+func FraudFlagDTOFromDomain(flag domain.FraudFlag) FraudFlagDTO {
+	dto := FraudFlagDTO{
+		ID:         flag.ID,
+		UserID:     flag.UserID,
+		BetID:      flag.BetID,
+		Amount:     flag.Amount,
+		CrashPoint: flag.CrashPoint,
+		IP:         flag.Client.IP,
+		DeviceID:   flag.Client.DeviceID,
+		Score:      flag.Score,
+		Action:     string(flag.Action),
+		Hits:       make([]RuleHitDTO, len(flag.Hits)),
+		Status:     string(flag.Status),
+		CreatedAt:  flag.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		ReviewedBy: flag.ReviewedBy,
+		Note:       flag.Note,
+	}
+	for i, hit := range flag.Hits {
+		dto.Hits[i] = RuleHitDTO{
+			Rule:   hit.Rule,
+			Type:   hit.Type,
+			Score:  hit.Score,
+			Action: string(hit.Action),
+			Reason: hit.Reason,
+		}
+	}
+	if flag.ReviewedAt != nil {
+		dto.ReviewedAt = flag.ReviewedAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+	}
+	return dto
+}
+
+func FraudFlagV2DTOFromDomain(flag domain.FraudFlag) FraudFlagV2DTO {
+	dto := FraudFlagDTOFromDomain(flag)
+	return FraudFlagV2DTO{
+		ID:         dto.ID,
+		UserID:     dto.UserID,
+		BetID:      dto.BetID,
+		Amount:     formatDecimal(dto.Amount),
+		CrashPoint: formatDecimal(dto.CrashPoint),
+		IP:         dto.IP,
+		DeviceID:   dto.DeviceID,
+		Score:      dto.Score,
+		Action:     dto.Action,
+		Hits:       dto.Hits,
+		Status:     dto.Status,
+		CreatedAt:  dto.CreatedAt,
+		ReviewedAt: dto.ReviewedAt,
+		ReviewedBy: dto.ReviewedBy,
+		Note:       dto.Note,
+	}
+}
+
+func fraudFlagResponse(v APIVersion, flag domain.FraudFlag) interface{} {
+	if v.DecimalStrings {
+		return FraudFlagV2DTOFromDomain(flag)
+	}
+	return FraudFlagDTOFromDomain(flag)
+}
+
+func listFraudFlagsResponse(v APIVersion, flags []domain.FraudFlag) interface{} {
+	if v.DecimalStrings {
+		response := ListFraudFlagsResponseV2DTO{Flags: make([]FraudFlagV2DTO, len(flags))}
+		for i, flag := range flags {
+			response.Flags[i] = FraudFlagV2DTOFromDomain(flag)
+		}
+		return response
+	}
+
+	response := ListFraudFlagsResponseDTO{Flags: make([]FraudFlagDTO, len(flags))}
+	for i, flag := range flags {
+		response.Flags[i] = FraudFlagDTOFromDomain(flag)
+	}
+	return response
+}
+
+type FraudThresholdsDTO struct {
+	Flag   float64 `json:"flag"`
+	Hold   float64 `json:"hold"`
+	Reject float64 `json:"reject"`
+}
+
+type FraudRuleDTO struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Score     float64 `json:"score"`
+	Action    string  `json:"action"`
+	Window    string  `json:"window,omitempty"`
+	MaxBets   int     `json:"max_bets,omitempty"`
+	MaxUsers  int     `json:"max_users,omitempty"`
+	MinUsers  int     `json:"min_users,omitempty"`
+	Samples   int     `json:"samples,omitempty"`
+	MaxJitter float64 `json:"max_jitter,omitempty"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+}
+
+type FraudRuleSetDTO struct {
+	Source     string             `json:"source"`
+	Checksum   string             `json:"checksum"`
+	LoadedAt   string             `json:"loaded_at"`
+	Thresholds FraudThresholdsDTO `json:"thresholds"`
+	Rules      []FraudRuleDTO     `json:"rules"`
+}
+
+func FraudRuleSetDTOFromDomain(set domain.FraudRuleSet) FraudRuleSetDTO {
+	dto := FraudRuleSetDTO{
+		Source:     set.Source,
+		Checksum:   set.Checksum,
+		LoadedAt:   set.LoadedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		Thresholds: FraudThresholdsDTO(set.Thresholds),
+		Rules:      make([]FraudRuleDTO, len(set.Rules)),
+	}
+	for i, rule := range set.Rules {
+		dto.Rules[i] = FraudRuleDTO{
+			Name:      rule.Name,
+			Type:      rule.Type,
+			Score:     rule.Score,
+			Action:    string(rule.Action),
+			MaxBets:   rule.MaxBets,
+			MaxUsers:  rule.MaxUsers,
+			MinUsers:  rule.MinUsers,
+			Samples:   rule.Samples,
+			MaxJitter: rule.MaxJitter,
+			Tolerance: rule.Tolerance,
+		}
+		if rule.Window > 0 {
+			dto.Rules[i].Window = rule.Window.String()
+		}
+	}
+	return dto
+}
+
+type CreateAPIKeyRequest struct {
+	Label        string   `json:"label,omitempty"`
+	Scopes       []string `json:"scopes"`
+	UserID       int64    `json:"user_id,omitempty"`
+	Operator     bool     `json:"operator,omitempty"`
+	OperatorName string   `json:"operator_name,omitempty"`
+}
+
+type APIKeyDTO struct {
+	ID           string   `json:"id"`
+	Label        string   `json:"label,omitempty"`
+	Scopes       []string `json:"scopes"`
+	UserID       int64    `json:"user_id,omitempty"`
+	Operator     bool     `json:"operator,omitempty"`
+	OperatorName string   `json:"operator_name,omitempty"`
+	Source       string   `json:"source"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+func APIKeyDTOFromDomain(key auth.APIKey) APIKeyDTO {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return APIKeyDTO{
+		ID:           key.ID,
+		Label:        key.Label,
+		Scopes:       scopes,
+		UserID:       key.UserID,
+		Operator:     key.Operator,
+		OperatorName: key.OperatorName,
+		Source:       string(key.Source),
+		CreatedAt:    key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+type ListAPIKeysResponseDTO struct {
+	Keys []APIKeyDTO `json:"keys"`
+}
+
+type CreateAPIKeyResponseDTO struct {
+	Key    APIKeyDTO `json:"key"`
+	Secret string    `json:"secret"`
+}
+
+type SnapshotDTO struct {
+	ID        string `json:"id"`
+	Bets      int    `json:"bets"`
+	Bytes     int64  `json:"bytes"`
+	Checksum  string `json:"checksum"`
+	Limits    int    `json:"limits"`
+	CreatedAt string `json:"created_at"`
+}
+
+func SnapshotDTOFromDomain(snapshot domain.Snapshot) SnapshotDTO {
+	return SnapshotDTO{
+		ID:        snapshot.ID,
+		Bets:      snapshot.Bets,
+		Bytes:     snapshot.Bytes,
+		Checksum:  snapshot.Checksum,
+		Limits:    snapshot.Limits,
+		CreatedAt: snapshot.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func snapshotDTOs(snapshots []domain.Snapshot) []SnapshotDTO {
+	dtos := make([]SnapshotDTO, len(snapshots))
+	for i, snapshot := range snapshots {
+		dtos[i] = SnapshotDTOFromDomain(snapshot)
+	}
+	return dtos
+}
+
+type ListSnapshotsResponseDTO struct {
+	Snapshots []SnapshotDTO `json:"snapshots"`
+}
+
+type CompactSnapshotsResponseDTO struct {
+	Removed []SnapshotDTO `json:"removed"`
+	Kept    int           `json:"kept"`
+}
@@ -2,39 +2,102 @@ package handler
 
 import (
 	"bet/internal/domain"
+	"bet/internal/metrics"
 	"bet/internal/middleware"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const (
+	StatusClientClosedRequest = 499
+	timeoutRetryAfterSeconds  = "1"
+)
+
 type ErrorResponse struct {
-	Error string `json:"error"`
-	Code  string `json:"code,omitempty"`
+	Error  string         `json:"error"`
+	Code   string         `json:"code,omitempty"`
+	Fields []FieldError   `json:"fields,omitempty"`
+	Limit  *LimitErrorDTO `json:"limit,omitempty"`
+	Risk   *RiskErrorDTO  `json:"risk,omitempty"`
+}
+
+type Problem struct {
+	Type      string           `json:"type"`
+	Title     string           `json:"title"`
+	Status    int              `json:"status"`
+	Detail    string           `json:"detail,omitempty"`
+	Instance  string           `json:"instance,omitempty"`
+	Code      string           `json:"code,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+	Errors    []FieldError     `json:"errors,omitempty"`
+	Limit     *LimitErrorV2DTO `json:"limit,omitempty"`
+	Risk      *RiskErrorV2DTO  `json:"risk,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type LimitErrorDTO struct {
+	Type      string  `json:"type,omitempty"`
+	Period    string  `json:"period,omitempty"`
+	Amount    float64 `json:"amount,omitempty"`
+	Remaining float64 `json:"remaining"`
+	Until     string  `json:"until,omitempty"`
+}
+
+type LimitErrorV2DTO struct {
+	Type      string `json:"type,omitempty"`
+	Period    string `json:"period,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	Remaining string `json:"remaining"`
+	Until     string `json:"until,omitempty"`
+}
+
+type RiskErrorDTO struct {
+	Limit         float64  `json:"limit,omitempty"`
+	Remaining     *float64 `json:"remaining,omitempty"`
+	MaxCrashPoint float64  `json:"max_crash_point,omitempty"`
+}
+
+type RiskErrorV2DTO struct {
+	Limit         string `json:"limit,omitempty"`
+	Remaining     string `json:"remaining,omitempty"`
+	MaxCrashPoint string `json:"max_crash_point,omitempty"`
 }
 
 func handleError(w http.ResponseWriter, r *http.Request, err error, logger *zap.Logger) {
 	requestID := middleware.GetRequestID(r.Context())
 
-	if r.Context().Err() != nil {
-		logger.Warn("request cancelled or timeout",
-			zap.String("request_id", requestID),
-			zap.Error(r.Context().Err()),
-			zap.String("method", r.Method),
-			zap.String("path", r.URL.Path),
-		)
-		sendErrorResponse(w, http.StatusRequestTimeout, "REQUEST_TIMEOUT", "Request cancelled or timeout", logger)
+	span := trace.SpanFromContext(r.Context())
+	span.RecordError(err)
+
+	ctxErr := r.Context().Err()
+	if ctxErr == nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+		ctxErr = err
+	}
+	if ctxErr != nil {
+		handleContextError(w, r, ctxErr, logger)
 		return
 	}
 
 	var statusCode int
 	var errorCode string
 	var message string
+	var fields []FieldError
+	var limit *LimitErrorDTO
+	var risk *RiskErrorDTO
 
 	logFields := []zap.Field{
 		zap.String("request_id", requestID),
+		zap.String("trace_id", middleware.GetTraceID(r.Context())),
 		zap.Error(err),
 		zap.String("method", r.Method),
 		zap.String("path", r.URL.Path),
@@ -42,21 +105,63 @@ func handleError(w http.ResponseWriter, r *http.Request, err error, logger *zap.
 
 	switch {
 	case domain.IsValidationError(err):
-		var validationErr *domain.ValidationError
-		errors.As(err, &validationErr)
+		violations := domain.FieldViolations(err)
 		statusCode = http.StatusBadRequest
 		errorCode = "VALIDATION_ERROR"
-		message = validationErr.Error()
+		message = domain.ValidationErrors(violations).Error()
+		fields = fieldErrors(violations)
 		logger.Warn("validation error", append(logFields, zap.String("error_code", errorCode))...)
 
 	case domain.IsNotFoundError(err):
 		var notFoundErr *domain.NotFoundError
 		errors.As(err, &notFoundErr)
 		statusCode = http.StatusNotFound
-		errorCode = "BET_NOT_FOUND"
+		errorCode = notFoundErr.Code()
 		message = notFoundErr.Error()
 		logger.Info("resource not found", append(logFields, zap.String("error_code", errorCode))...)
 
+	case domain.IsConflictError(err):
+		var conflictErr *domain.ConflictError
+		errors.As(err, &conflictErr)
+		statusCode = http.StatusConflict
+		errorCode = conflictErr.Code()
+		message = conflictErr.Error()
+		logger.Info("state conflict", append(logFields, zap.String("error_code", errorCode))...)
+
+	case domain.IsForbiddenError(err):
+		var forbiddenErr *domain.ForbiddenError
+		errors.As(err, &forbiddenErr)
+		statusCode = http.StatusForbidden
+		errorCode = forbiddenErr.Code()
+		message = forbiddenErr.Error()
+		logger.Warn("request forbidden", append(logFields, zap.String("error_code", errorCode))...)
+
+	case domain.IsLimitError(err):
+		var limitErr *domain.LimitError
+		errors.As(err, &limitErr)
+		statusCode = http.StatusUnprocessableEntity
+		errorCode = limitErr.Code()
+		message = limitErr.Error()
+		limit = limitErrorDTO(limitErr)
+		logger.Info("responsible gambling limit", append(logFields, zap.String("error_code", errorCode))...)
+
+	case domain.IsRiskError(err):
+		var riskErr *domain.RiskError
+		errors.As(err, &riskErr)
+		statusCode = http.StatusUnprocessableEntity
+		errorCode = riskErr.Code()
+		message = riskErr.Error()
+		risk = riskErrorDTO(riskErr)
+		logger.Info("liability cap", append(logFields, zap.String("error_code", errorCode))...)
+
+	case domain.IsFraudError(err):
+		var fraudErr *domain.FraudError
+		errors.As(err, &fraudErr)
+		statusCode = http.StatusUnprocessableEntity
+		errorCode = fraudErr.Code()
+		message = fraudErr.Error()
+		logger.Info("fraud check", append(logFields, zap.String("error_code", errorCode))...)
+
 	case domain.IsInvalidInputError(err):
 		var invalidInputErr *domain.InvalidInputError
 		errors.As(err, &invalidInputErr)
@@ -68,10 +173,11 @@ func handleError(w http.ResponseWriter, r *http.Request, err error, logger *zap.
 	case domain.IsRepositoryError(err):
 		var repoErr *domain.RepositoryError
 		errors.As(err, &repoErr)
-		if domain.IsNotFoundError(repoErr.Unwrap()) {
+		var notFoundErr *domain.NotFoundError
+		if errors.As(repoErr.Unwrap(), &notFoundErr) {
 			statusCode = http.StatusNotFound
-			errorCode = "BET_NOT_FOUND"
-			message = "bet not found"
+			errorCode = notFoundErr.Code()
+			message = notFoundErr.Error()
 			logger.Info("resource not found", append(logFields, zap.String("error_code", errorCode))...)
 		} else {
 			statusCode = http.StatusInternalServerError
@@ -87,19 +193,174 @@ func handleError(w http.ResponseWriter, r *http.Request, err error, logger *zap.
 		logger.Error("unhandled error", append(logFields, zap.String("error_code", errorCode))...)
 	}
 
-	sendErrorResponse(w, statusCode, errorCode, message, logger)
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, errorCode)
+	}
+
+	writeErrorResponse(w, r, statusCode, ErrorResponse{
+		Error:  message,
+		Code:   errorCode,
+		Fields: fields,
+		Limit:  limit,
+		Risk:   risk,
+	}, logger)
 }
 
-func sendErrorResponse(w http.ResponseWriter, status int, code, message string, logger *zap.Logger) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+func rejectionResponse(err error) (ErrorResponse, bool) {
+	var (
+		forbiddenErr *domain.ForbiddenError
+		limitErr     *domain.LimitError
+		riskErr      *domain.RiskError
+		fraudErr     *domain.FraudError
+	)
+
+	switch {
+	case errors.As(err, &forbiddenErr):
+		return ErrorResponse{Error: forbiddenErr.Error(), Code: forbiddenErr.Code()}, true
+	case errors.As(err, &limitErr):
+		return ErrorResponse{Error: limitErr.Error(), Code: limitErr.Code(), Limit: limitErrorDTO(limitErr)}, true
+	case errors.As(err, &riskErr):
+		return ErrorResponse{Error: riskErr.Error(), Code: riskErr.Code(), Risk: riskErrorDTO(riskErr)}, true
+	case errors.As(err, &fraudErr):
+		return ErrorResponse{Error: fraudErr.Error(), Code: fraudErr.Code()}, true
+	default:
+		return ErrorResponse{}, false
+	}
+}
 
-	response := ErrorResponse{
+func fieldErrors(violations []*domain.ValidationError) []FieldError {
+	fields := make([]FieldError, len(violations))
+	for i, v := range violations {
+		fields[i] = FieldError{Field: v.Field, Message: v.Message}
+	}
+	return fields
+}
+
+func limitErrorDTO(err *domain.LimitError) *LimitErrorDTO {
+	dto := &LimitErrorDTO{
+		Type:      string(err.Type),
+		Period:    string(err.Period),
+		Amount:    roundCents(err.Limit),
+		Remaining: roundCents(err.Remaining),
+	}
+	if err.Until != nil {
+		dto.Until = err.Until.UTC().Format("2006-01-02T15:04:05Z07:00")
+	}
+	return dto
+}
+
+func limitErrorV2DTO(limit *LimitErrorDTO) *LimitErrorV2DTO {
+	dto := &LimitErrorV2DTO{
+		Type:      limit.Type,
+		Period:    limit.Period,
+		Remaining: formatDecimal(limit.Remaining),
+		Until:     limit.Until,
+	}
+	if limit.Amount != 0 {
+		dto.Amount = formatDecimal(limit.Amount)
+	}
+	return dto
+}
+
+func riskErrorV2DTO(risk *RiskErrorDTO) *RiskErrorV2DTO {
+	dto := &RiskErrorV2DTO{}
+	if risk.Limit != 0 {
+		dto.Limit = formatDecimal(risk.Limit)
+	}
+	if risk.Remaining != nil {
+		dto.Remaining = formatDecimal(*risk.Remaining)
+	}
+	if risk.MaxCrashPoint != 0 {
+		dto.MaxCrashPoint = formatDecimal(risk.MaxCrashPoint)
+	}
+	return dto
+}
+
+func riskErrorDTO(err *domain.RiskError) *RiskErrorDTO {
+	dto := &RiskErrorDTO{
+		Limit:         roundCents(err.Limit),
+		MaxCrashPoint: err.MaxCrashPoint,
+	}
+	if err.Limit > 0 {
+		remaining := roundCents(err.Remaining)
+		dto.Remaining = &remaining
+	}
+	return dto
+}
+
+func handleContextError(w http.ResponseWriter, r *http.Request, err error, logger *zap.Logger) {
+	logFields := []zap.Field{
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("trace_id", middleware.GetTraceID(r.Context())),
+		zap.Error(err),
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		metrics.RequestTimeouts.Add(1)
+		trace.SpanFromContext(r.Context()).SetStatus(codes.Error, "DEADLINE_EXCEEDED")
+		logger.Warn("request deadline exceeded", append(logFields, zap.String("error_code", "DEADLINE_EXCEEDED"))...)
+
+		w.Header().Set("Retry-After", timeoutRetryAfterSeconds)
+		sendErrorResponse(w, r, http.StatusGatewayTimeout, "DEADLINE_EXCEEDED", "Request timed out", logger)
+		return
+	}
+
+	metrics.ClientDisconnects.Add(1)
+	logger.Info("client closed request", append(logFields, zap.String("error_code", "CLIENT_CLOSED_REQUEST"))...)
+
+	sendErrorResponse(w, r, StatusClientClosedRequest, "CLIENT_CLOSED_REQUEST", "Client closed request", logger)
+}
+
+func sendErrorResponse(w http.ResponseWriter, r *http.Request, status int, code, message string, logger *zap.Logger) {
+	writeErrorResponse(w, r, status, ErrorResponse{
 		Error: message,
 		Code:  code,
+	}, logger)
+}
+
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, status int, response ErrorResponse, logger *zap.Logger) {
+	if versionOf(r).ProblemJSON {
+		writeProblem(w, r, status, response, logger)
+		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.Error("failed to encode error response", zap.Error(err))
 	}
 }
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, response ErrorResponse, logger *zap.Logger) {
+	title := http.StatusText(status)
+	if status == StatusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     title,
+		Status:    status,
+		Detail:    response.Error,
+		Instance:  r.URL.Path,
+		Code:      response.Code,
+		RequestID: middleware.GetRequestID(r.Context()),
+		Errors:    response.Fields,
+	}
+	if response.Limit != nil {
+		problem.Limit = limitErrorV2DTO(response.Limit)
+	}
+	if response.Risk != nil {
+		problem.Risk = riskErrorV2DTO(response.Risk)
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		logger.Error("failed to encode problem response", zap.Error(err))
+	}
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/export"
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"bet/internal/service"
+	"bet/internal/tracing"
+	"bet/internal/validator"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultExportFormat      = string(export.FormatCSV)
+	defaultExportCompression = string(export.CompressionNone)
+	defaultExportOrder       = "asc"
+
+	exportFlushRows = 500
+	exportWriteWait = 30 * time.Second
+
+	headerChecksum   = "X-Checksum-SHA256"
+	headerExportRows = "X-Export-Rows"
+)
+
+type ExportHandler struct {
+	service      service.BetServiceUseCase
+	validator    validator.BetValidator
+	rowGroupSize int
+	logger       *zap.Logger
+}
+
+func NewExportHandler(service service.BetServiceUseCase, validator validator.BetValidator, rowGroupSize int, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		service:      service,
+		validator:    validator,
+		rowGroupSize: rowGroupSize,
+		logger:       logger,
+	}
+}
+
+func (h *ExportHandler) ExportBets(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "ExportHandler.ExportBets")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	format := queryOrDefault(r, "format", defaultExportFormat)
+	compression := queryOrDefault(r, "compression", defaultExportCompression)
+	req := domain.ExportBetsRequest{
+		Filters: ParseBetFilters(r),
+		Sort: domain.SortParams{
+			SortBy: queryOrDefault(r, "sort_by", defaultSortBy),
+			Order:  queryOrDefault(r, "order", defaultExportOrder),
+		},
+	}
+
+	span.SetAttributes(tracing.ExportBetsAttributes(req)...)
+	span.SetAttributes(
+		attribute.String("export.format", format),
+		attribute.String("export.compression", compression),
+	)
+
+	if err := h.validator.ValidateExport(format, compression); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	if err := h.validator.ValidateSort(req.Sort.SortBy, req.Sort.Order); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	config := export.Config{
+		Format:       export.Format(format),
+		Compression:  export.Compression(compression),
+		RowGroupSize: h.rowGroupSize,
+	}
+
+	rc := http.NewResponseController(w)
+	started := time.Now()
+	committed := false
+	var writer *export.Writer
+
+	commit := func() error {
+		var err error
+		if writer, err = export.NewWriter(w, config); err != nil {
+			return err
+		}
+
+		header := w.Header()
+		header.Set("Content-Type", export.ContentType(config.Format, config.Compression))
+		header.Set("Content-Disposition", `attachment; filename="`+export.FileName(config.Format, config.Compression, started)+`"`)
+		header.Set("Cache-Control", "no-store")
+		header.Set("X-Accel-Buffering", "no")
+		header.Add("Trailer", headerChecksum)
+		header.Add("Trailer", headerExportRows)
+		w.WriteHeader(http.StatusOK)
+		committed = true
+		return nil
+	}
+
+	abort := func(err error) {
+		span.RecordError(err)
+		if !committed {
+			handleError(w, r, err, h.logger)
+			return
+		}
+
+		metrics.BetExportsAborted.Add(1)
+		h.logger.Warn("bet export aborted",
+			zap.String("request_id", middleware.GetRequestID(r.Context())),
+			zap.String("format", format),
+			zap.Int("rows", writer.Rows()),
+			zap.Error(err),
+		)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	rc.SetWriteDeadline(time.Now().Add(exportWriteWait))
+
+	for bet, err := range h.service.ExportBets(r.Context(), req) {
+		if err != nil {
+			abort(err)
+			return
+		}
+
+		if !committed {
+			if err := commit(); err != nil {
+				abort(err)
+				return
+			}
+		}
+
+		if err := writer.Write(bet); err != nil {
+			abort(err)
+			return
+		}
+
+		if writer.Rows()%exportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				abort(err)
+				return
+			}
+			rc.Flush()
+			rc.SetWriteDeadline(time.Now().Add(exportWriteWait))
+		}
+	}
+
+	if !committed {
+		if err := commit(); err != nil {
+			abort(err)
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		abort(err)
+		return
+	}
+
+	w.Header().Set(headerChecksum, writer.Checksum())
+	w.Header().Set(headerExportRows, strconv.Itoa(writer.Rows()))
+
+	metrics.BetExports.Add(1)
+	metrics.BetExportRows.Add(int64(writer.Rows()))
+	span.SetAttributes(
+		attribute.Int("export.rows", writer.Rows()),
+		attribute.Int64("export.bytes", writer.Bytes()),
+	)
+
+	h.logger.Info("bet export completed",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("format", format),
+		zap.String("compression", compression),
+		zap.Int("rows", writer.Rows()),
+		zap.Int64("bytes", writer.Bytes()),
+		zap.String("sha256", writer.Checksum()),
+		zap.Duration("duration", time.Since(started)),
+	)
+}
+
+func queryOrDefault(r *http.Request, name, fallback string) string {
+	if value := sanitizeQueryParam(r.URL.Query().Get(name)); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type exportService struct {
+	service.BetServiceUseCase
+	bets []domain.Bet
+}
+
+func (s *exportService) ExportBets(ctx context.Context, req domain.ExportBetsRequest) iter.Seq2[domain.Bet, error] {
+	return func(yield func(domain.Bet, error) bool) {
+		for _, bet := range s.bets {
+			if !yield(bet, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestExportTrailersMatchPayload(t *testing.T) {
+	var bets []domain.Bet
+	for i := 0; i < exportFlushRows+20; i++ {
+		bets = append(bets, *domain.NewBet(int64(i%7+1), 10, 2))
+	}
+
+	spec := OpenAPISpec(SpecOptions{})
+	h := NewExportHandler(&exportService{bets: bets}, validator.NewBetValidator(spec), 0, zap.NewNop())
+	server := httptest.NewServer(http.HandlerFunc(h.ExportBets))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		lines       int
+	}{
+		{name: "csv", query: "format=csv", contentType: "text/csv; charset=utf-8", lines: len(bets) + 1},
+		{name: "csv gzip", query: "format=csv&compression=gzip", contentType: "application/gzip", lines: len(bets) + 1},
+		{name: "ndjson", query: "format=ndjson", contentType: "application/x-ndjson", lines: len(bets)},
+		{name: "ndjson gzip", query: "format=ndjson&compression=gzip", contentType: "application/gzip", lines: len(bets)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/bets/export?"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept-Encoding", "identity")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", resp.StatusCode)
+			}
+			if got := resp.Header.Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if _, ok := resp.Trailer[http.CanonicalHeaderKey(headerChecksum)]; !ok {
+				t.Errorf("Trailer does not declare %s: %v", headerChecksum, resp.Trailer)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+
+			sum := sha256.Sum256(body)
+			if got, want := resp.Trailer.Get(headerChecksum), hex.EncodeToString(sum[:]); got != want {
+				t.Errorf("%s = %q, want %q", headerChecksum, got, want)
+			}
+			if got, want := resp.Trailer.Get(headerExportRows), strconv.Itoa(len(bets)); got != want {
+				t.Errorf("%s = %q, want %q", headerExportRows, got, want)
+			}
+
+			payload := body
+			if strings.Contains(tt.query, "gzip") {
+				zr, err := gzip.NewReader(bytes.NewReader(body))
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				if payload, err = io.ReadAll(zr); err != nil {
+					t.Fatalf("read gzip payload: %v", err)
+				}
+			}
+			if lines := strings.Count(string(payload), "\n"); lines != tt.lines {
+				t.Errorf("payload has %d lines, want %d", lines, tt.lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/feed"
+	"bet/internal/middleware"
+	"bet/internal/validator"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+type FeedHandler struct {
+	hub       *feed.Hub
+	validator validator.BetValidator
+	logger    *zap.Logger
+}
+
+func NewFeedHandler(hub *feed.Hub, validator validator.BetValidator, logger *zap.Logger) *FeedHandler {
+	return &FeedHandler{
+		hub:       hub,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *FeedHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	var filter feed.Filter
+
+	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(sanitizeQueryParam(userIDStr), 10, 64)
+		if err != nil {
+			handleError(w, r, &domain.ValidationError{
+				Field:   "user_id",
+				Message: "user_id must be an integer",
+			}, h.logger)
+			return
+		}
+		if err := h.validator.ValidateUserID(userID); err != nil {
+			handleError(w, r, err, h.logger)
+			return
+		}
+		filter.UserID = &userID
+	}
+
+	if err := h.hub.Serve(w, r, filter); err != nil {
+		if errors.Is(err, feed.ErrHubClosed) {
+			sendErrorResponse(w, r, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Live feed is shutting down", h.logger)
+			return
+		}
+		h.logger.Warn("websocket upgrade failed",
+			zap.String("request_id", middleware.GetRequestID(r.Context())),
+			zap.Error(err),
+		)
+	}
+}
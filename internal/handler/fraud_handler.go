@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type FraudHandler struct {
+	service   service.FraudServiceUseCase
+	validator validator.FraudValidator
+	logger    *zap.Logger
+}
+
+func NewFraudHandler(service service.FraudServiceUseCase, validator validator.FraudValidator, logger *zap.Logger) *FraudHandler {
+	return &FraudHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *FraudHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "FraudHandler.ListFlags")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	query := r.URL.Query()
+	filter := domain.FraudFlagFilter{
+		Status: domain.FlagStatus(sanitizeQueryParam(query.Get("status"))),
+		Limit:  domain.DefaultFraudFlagLimit,
+	}
+	if userIDStr := query.Get("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(sanitizeQueryParam(userIDStr), 10, 64)
+		if err != nil {
+			userID = 0
+		}
+		filter.UserID = &userID
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(sanitizeQueryParam(limitStr))
+		if err != nil {
+			parsed = 0
+		}
+		filter.Limit = parsed
+	}
+
+	if err := h.validator.ValidateFlagFilter(string(filter.Status), filter.UserID, filter.Limit); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	flags, err := h.service.ListFlags(r.Context(), filter)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("fraud.result_count", len(flags)))
+
+	sendJSON(w, http.StatusOK, listFraudFlagsResponse(versionOf(r), flags), h.logger)
+}
+
+func (h *FraudHandler) ReviewFlag(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "FraudHandler.ReviewFlag")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("fraud.flag_id", id))
+
+	if err := h.validator.ValidateFlagID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	var req ReviewFraudFlagRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	if err := h.validator.ValidateReview(req.Status, req.Note); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	actor := actorFrom(r)
+	flag, err := h.service.ReviewFlag(r.Context(), id, domain.FlagReview{
+		Status: domain.FlagStatus(req.Status),
+		Note:   req.Note,
+		Actor:  actor,
+	})
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	h.logger.Info("fraud flag reviewed",
+		zap.String("request_id", actor.RequestID),
+		zap.String("flag_id", flag.ID),
+		zap.Int64("user_id", flag.UserID),
+		zap.String("actor", actor.ID),
+		zap.String("status", req.Status),
+	)
+
+	sendJSON(w, http.StatusOK, fraudFlagResponse(versionOf(r), flag), h.logger)
+}
+
+func (h *FraudHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "FraudHandler.GetRules")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	rules, err := h.service.GetRules(r.Context())
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, FraudRuleSetDTOFromDomain(rules), h.logger)
+}
+
+func (h *FraudHandler) ReloadRules(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "FraudHandler.ReloadRules")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	rules, err := h.service.ReloadRules(r.Context())
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("fraud.checksum", rules.Checksum))
+
+	sendJSON(w, http.StatusOK, FraudRuleSetDTOFromDomain(rules), h.logger)
+}
@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"bet/internal/domain"
+	"bet/internal/middleware"
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 )
 
+const maxBodySize = 1 << 20
+
 func sendJSON(w http.ResponseWriter, status int, data interface{}, logger *zap.Logger) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -15,3 +22,92 @@ func sendJSON(w http.ResponseWriter, status int, data interface{}, logger *zap.L
 		logger.Error("failed to encode response", zap.Error(err))
 	}
 }
+
+func decodeJSONBody(r *http.Request, dst interface{}, logger *zap.Logger) error {
+	body, err := readJSONBody(r, logger)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		logger.Error("failed to decode request body",
+			zap.String("request_id", middleware.GetRequestID(r.Context())),
+			zap.Error(err),
+		)
+		return &domain.ValidationError{
+			Field:   "body",
+			Message: "invalid request body",
+		}
+	}
+
+	return nil
+}
+
+func parseDecimal(field, value string) (float64, error) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, &domain.ValidationError{
+			Field:   field,
+			Message: field + " must be a decimal string",
+		}
+	}
+	return parsed, nil
+}
+
+func clientFrom(r *http.Request) domain.Client {
+	return domain.Client{
+		IP:       middleware.GetClientIP(r.Context()),
+		DeviceID: r.Header.Get("X-Device-ID"),
+	}
+}
+
+func readJSONBody(r *http.Request, logger *zap.Logger) ([]byte, error) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	contentType := r.Header.Get("Content-Type")
+	if idx := strings.Index(contentType, ";"); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	contentType = strings.TrimSpace(contentType)
+	if contentType != "application/json" {
+		logger.Warn("invalid content type",
+			zap.String("request_id", requestID),
+			zap.String("content_type", r.Header.Get("Content-Type")),
+		)
+		return nil, &domain.ValidationError{
+			Field:   "content-type",
+			Message: "content type must be application/json",
+		}
+	}
+
+	if r.ContentLength > maxBodySize {
+		logger.Warn("request body too large",
+			zap.String("request_id", requestID),
+			zap.Int64("size", r.ContentLength),
+		)
+		return nil, &domain.ValidationError{
+			Field:   "body",
+			Message: "request body too large",
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		logger.Error("failed to read request body",
+			zap.String("request_id", requestID),
+			zap.Error(err),
+		)
+		return nil, &domain.ValidationError{
+			Field:   "body",
+			Message: "invalid request body",
+		}
+	}
+	if len(body) > maxBodySize {
+		return nil, &domain.ValidationError{
+			Field:   "body",
+			Message: "request body too large",
+		}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bet/internal/auth"
+	"bet/internal/middleware"
+	"bet/internal/validator"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type KeyHandler struct {
+	keys      *auth.KeyStore
+	validator validator.AdminValidator
+	logger    *zap.Logger
+}
+
+func NewKeyHandler(keys *auth.KeyStore, validator validator.AdminValidator, logger *zap.Logger) *KeyHandler {
+	return &KeyHandler{
+		keys:      keys,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *KeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	_, span := tracer.Start(r.Context(), "KeyHandler.ListKeys")
+	defer span.End()
+
+	keys := h.keys.List()
+	span.SetAttributes(attribute.Int("api_keys.count", len(keys)))
+
+	dtos := make([]APIKeyDTO, len(keys))
+	for i, key := range keys {
+		dtos[i] = APIKeyDTOFromDomain(key)
+	}
+
+	sendJSON(w, http.StatusOK, ListAPIKeysResponseDTO{Keys: dtos}, h.logger)
+}
+
+func (h *KeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "KeyHandler.CreateKey")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	var req CreateAPIKeyRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	if err := h.validator.ValidateCreateAPIKey(req.Label, req.Scopes, req.UserID, req.Operator, req.OperatorName); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	grant := auth.Grant{
+		Scopes:       make([]auth.Scope, len(req.Scopes)),
+		UserID:       req.UserID,
+		Operator:     req.Operator,
+		OperatorName: req.OperatorName,
+	}
+	for i, scope := range req.Scopes {
+		grant.Scopes[i] = auth.Scope(scope)
+	}
+
+	key, secret, err := h.keys.Create(req.Label, grant)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("api_key.id", key.ID))
+	h.logger.Info("api key created",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("actor", actorFrom(r).ID),
+		zap.String("key_id", key.ID),
+		zap.Strings("scopes", req.Scopes),
+		zap.Int64("user_id", req.UserID),
+		zap.Bool("operator", req.Operator),
+		zap.String("operator_name", req.OperatorName),
+	)
+
+	sendJSON(w, http.StatusCreated, CreateAPIKeyResponseDTO{
+		Key:    APIKeyDTOFromDomain(key),
+		Secret: secret,
+	}, h.logger)
+}
+
+func (h *KeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "KeyHandler.RevokeKey")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("api_key.id", id))
+
+	if err := h.validator.ValidateAPIKeyID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	key, err := h.keys.Revoke(id)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	h.logger.Info("api key revoked",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("actor", actorFrom(r).ID),
+		zap.String("key_id", key.ID),
+	)
+
+	sendJSON(w, http.StatusOK, APIKeyDTOFromDomain(key), h.logger)
+}
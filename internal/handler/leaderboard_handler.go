@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type LeaderboardHandler struct {
+	service   service.LeaderboardServiceUseCase
+	validator validator.LeaderboardValidator
+	logger    *zap.Logger
+}
+
+func NewLeaderboardHandler(service service.LeaderboardServiceUseCase, validator validator.LeaderboardValidator, logger *zap.Logger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LeaderboardHandler.GetLeaderboard")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	query := r.URL.Query()
+	boardType := r.PathValue("type")
+	window := string(domain.LeaderboardDay)
+	if value := query.Get("window"); value != "" {
+		window = sanitizeQueryParam(value)
+	}
+	limit := domain.DefaultLeaderboardLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(sanitizeQueryParam(limitStr))
+		if err != nil {
+			parsed = 0
+		}
+		limit = parsed
+	}
+	var aroundUser *int64
+	if userIDStr := query.Get("around_user"); userIDStr != "" {
+		userID, err := strconv.ParseInt(sanitizeQueryParam(userIDStr), 10, 64)
+		if err != nil {
+			userID = 0
+		}
+		aroundUser = &userID
+		span.SetAttributes(attribute.Int64("leaderboard.around_user", userID))
+	}
+
+	span.SetAttributes(
+		attribute.String("leaderboard.type", boardType),
+		attribute.String("leaderboard.window", window),
+		attribute.Int("leaderboard.limit", limit),
+	)
+
+	if err := h.validator.ValidateLeaderboard(boardType, window, limit, aroundUser); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	req := domain.LeaderboardRequest{
+		Type:   domain.LeaderboardType(boardType),
+		Window: domain.LeaderboardWindow(window),
+		Limit:  limit,
+	}
+	if aroundUser != nil {
+		if !actorFrom(r).ActsFor(*aroundUser) {
+			handleError(w, r, domain.ErrNotUsersKey, h.logger)
+			return
+		}
+		req.AroundUser = *aroundUser
+	}
+
+	board, err := h.service.GetLeaderboard(r.Context(), req)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, leaderboardResponse(versionOf(r), board), h.logger)
+}
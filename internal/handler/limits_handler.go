@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type LimitsHandler struct {
+	service   service.LimitsServiceUseCase
+	validator validator.LimitsValidator
+	logger    *zap.Logger
+}
+
+func NewLimitsHandler(service service.LimitsServiceUseCase, validator validator.LimitsValidator, logger *zap.Logger) *LimitsHandler {
+	return &LimitsHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *LimitsHandler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.GetLimits")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, err := h.userID(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	status, err := h.service.GetLimits(r.Context(), userID)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) SetLimit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.SetLimit")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, limitType, period, err := h.limitKey(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	amount, err := h.decodeAmount(r)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+	if err := h.validator.ValidateLimitAmount(amount); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Float64("limits.amount", amount))
+
+	status, err := h.service.SetLimit(r.Context(), userID, limitType, period, &amount)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) RemoveLimit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.RemoveLimit")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, limitType, period, err := h.limitKey(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	status, err := h.service.SetLimit(r.Context(), userID, limitType, period, nil)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) RecordDeposit(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.RecordDeposit")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, err := h.userID(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	amount, err := h.decodeAmount(r)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+	if err := h.validator.ValidateDepositAmount(amount); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Float64("deposit.amount", amount))
+
+	status, err := h.service.RecordDeposit(r.Context(), userID, amount)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) StartCoolOff(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.StartCoolOff")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, err := h.userID(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	var req CoolOffRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+	if err := h.validator.ValidateCoolOff(req.Days); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("limits.cool_off_days", req.Days))
+
+	status, err := h.service.StartCoolOff(r.Context(), userID, req.Days)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) SelfExclude(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "LimitsHandler.SelfExclude")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	userID, err := h.userID(r, span)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	var req SelfExclusionRequest
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+	if err := h.validator.ValidateSelfExclusion(req.Duration); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("limits.exclusion", req.Duration))
+
+	status, err := h.service.SelfExclude(r.Context(), userID, domain.ExclusionDuration(req.Duration))
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, limitsResponse(versionOf(r), status), h.logger)
+}
+
+func (h *LimitsHandler) userID(r *http.Request, span trace.Span) (int64, error) {
+	userID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, &domain.ValidationError{Field: "id", Message: "id must be an integer"}
+	}
+	span.SetAttributes(attribute.Int64("limits.user_id", userID))
+
+	if err := h.validator.ValidateUserID(userID); err != nil {
+		return 0, err
+	}
+	if !actorFrom(r).ActsFor(userID) {
+		return 0, domain.ErrNotUsersKey
+	}
+	return userID, nil
+}
+
+func (h *LimitsHandler) limitKey(r *http.Request, span trace.Span) (int64, domain.LimitType, domain.LimitPeriod, error) {
+	userID, err := h.userID(r, span)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	limitType, period := r.PathValue("type"), r.PathValue("period")
+	span.SetAttributes(
+		attribute.String("limits.type", limitType),
+		attribute.String("limits.period", period),
+	)
+	if err := h.validator.ValidateLimit(limitType, period); err != nil {
+		return 0, "", "", err
+	}
+
+	return userID, domain.LimitType(limitType), domain.LimitPeriod(period), nil
+}
+
+func (h *LimitsHandler) decodeAmount(r *http.Request) (float64, error) {
+	if !versionOf(r).DecimalStrings {
+		var req SetLimitRequest
+		if err := decodeJSONBody(r, &req, h.logger); err != nil {
+			return 0, err
+		}
+		return req.Amount, nil
+	}
+
+	var req SetLimitV2Request
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		return 0, err
+	}
+	return parseDecimal("amount", req.Amount)
+}
@@ -0,0 +1,1668 @@
+package handler
+
+import (
+	"bet/internal/auth"
+	"bet/internal/domain"
+	"bet/internal/export"
+	"bet/internal/fraud"
+	"bet/internal/openapi"
+	"bet/internal/webhook"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPage          = 1
+	defaultPageLimit     = 10
+	defaultSortBy        = "created_at"
+	defaultOrder         = "desc"
+	defaultDeliveryLimit = 50
+
+	bearerAuth = "bearerAuth"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+//go:embed swaggerui/swagger-ui.css swaggerui/swagger-ui-bundle.js
+var docsAssets embed.FS
+
+var docsAssetNames = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+type SpecOptions struct {
+	DocsEnabled   bool
+	MaxBatchBets  int
+	MaxImportBets int
+}
+
+type OpenAPIHandler struct {
+	spec   []byte
+	logger *zap.Logger
+}
+
+func NewOpenAPIHandler(doc *openapi.Document, logger *zap.Logger) (*OpenAPIHandler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAPIHandler{
+		spec:   spec,
+		logger: logger,
+	}, nil
+}
+
+func (h *OpenAPIHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Content-Length", strconv.Itoa(len(h.spec)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(h.spec); err != nil {
+		h.logger.Debug("failed to write openapi spec", zap.Error(err))
+	}
+}
+
+func (h *OpenAPIHandler) Docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(docsPage); err != nil {
+		h.logger.Debug("failed to write docs page", zap.Error(err))
+	}
+}
+
+func (h *OpenAPIHandler) DocsAsset(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeFileFS(w, r, docsAssets, "swaggerui/"+r.PathValue("asset"))
+}
+
+type versionSchemas struct {
+	bet           *openapi.Schema
+	listBets      *openapi.Schema
+	createBet     *openapi.Schema
+	createWebhook *openapi.Schema
+	createBets    *openapi.Schema
+	batchResult   *openapi.Schema
+	voidBet       *openapi.Schema
+	audit         *openapi.Schema
+	importBets    *openapi.Schema
+	importResult  *openapi.Schema
+	round         *openapi.Schema
+	listRounds    *openapi.Schema
+	userStats     *openapi.Schema
+	reportSummary *openapi.Schema
+	leaderboard   *openapi.Schema
+	setLimit      *openapi.Schema
+	deposit       *openapi.Schema
+	coolOff       *openapi.Schema
+	selfExclusion *openapi.Schema
+	limits        *openapi.Schema
+	exposure      *openapi.Schema
+	fraudFlag     *openapi.Schema
+	fraudFlags    *openapi.Schema
+	reviewFlag    *openapi.Schema
+	errorMedia    string
+	errorSchema   *openapi.Schema
+}
+
+func OpenAPISpec(opts SpecOptions) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Bet API",
+		Version:     "2.0.0",
+		Description: "Crash game bet placement, history and live streams. Resource routes are served under `/v1` and `/v2`; unversioned paths alias `/v1` and are deprecated.",
+	})
+	doc.Tags = []openapi.Tag{
+		{Name: "bets", Description: "Bet placement and history"},
+		{Name: "streams", Description: "Realtime bet and round streams"},
+		{Name: "webhooks", Description: "Outbound webhook subscriptions"},
+		{Name: "admin", Description: "Operator actions; require an API key with the `admin` scope"},
+		{Name: "limits", Description: "Responsible gambling limits, cool-off and self-exclusion"},
+		{Name: "reports", Description: "House-level reporting; requires an API key with the `admin` scope"},
+		{Name: "system", Description: "Health, metrics and API description"},
+	}
+
+	doc.Components.SecuritySchemes = map[string]*openapi.SecurityScheme{
+		bearerAuth: {
+			Type:        "http",
+			Scheme:      "bearer",
+			Description: "API key configured in `AUTH_API_KEYS` or created with `createAPIKey`. While `AUTH_API_KEYS` is empty, secured operations respond `503`.",
+		},
+	}
+
+	for _, v := range APIVersions {
+		addVersionOperations(doc, opts, v, v.Prefix(), v.Name, false)
+	}
+	addVersionOperations(doc, opts, V1, "", "", true)
+	addSystemOperations(doc, opts)
+
+	return doc
+}
+
+func registerVersionSchemas(doc *openapi.Document, opts SpecOptions, v APIVersion) versionSchemas {
+	if v.DecimalStrings {
+		betSchema := doc.RegisterSchema("BetV2DTO", BetV2DTO{})
+		decorateBetSchema(doc.Schema("BetV2DTO"), v)
+
+		doc.RegisterSchema("ListBetsResponseV2DTO", ListBetsResponseV2DTO{})
+		decorateListBetsSchema(doc.Schema("ListBetsResponseV2DTO"), betSchema)
+
+		createBetSchema := doc.RegisterSchema(createBetSchemaName(v), CreateBetV2Request{})
+		decorateCreateBetSchema(doc.Schema(createBetSchemaName(v)), v)
+
+		createWebhookSchema := doc.RegisterSchema("CreateWebhookV2Request", CreateWebhookRequest{})
+		decorateCreateWebhookSchema(doc.Schema("CreateWebhookV2Request"), v)
+
+		createBetsSchema := doc.RegisterSchema("CreateBetsV2Request", CreateBetsRequest{})
+		decorateCreateBetsSchema(doc.Schema("CreateBetsV2Request"), opts, v)
+
+		doc.RegisterSchema("CreateBetsResponseV2DTO", CreateBetsResponseDTO{})
+		decorateCreateBetsResponseSchema(doc.Schema("CreateBetsResponseV2DTO"), betSchema, v)
+
+		voidBetSchema := doc.RegisterSchema("VoidBetV2Request", VoidBetRequest{})
+		decorateVoidBetSchema(doc.Schema("VoidBetV2Request"), v)
+
+		doc.RegisterSchema("ListAuditEntriesResponseV2DTO", ListAuditEntriesResponseV2DTO{})
+		decorateAuditSchema(doc.Schema("ListAuditEntriesResponseV2DTO"), v)
+
+		importBetsSchema := doc.RegisterSchema("ImportBetsV2Request", ImportBetsV2Request{})
+		decorateImportBetsSchema(doc.Schema("ImportBetsV2Request"), opts, betSchema, v)
+
+		roundSchema := doc.RegisterSchema("RoundV2DTO", RoundV2DTO{})
+		decorateRoundSchema(doc.Schema("RoundV2DTO"), v)
+
+		doc.RegisterSchema("ListRoundsResponseV2DTO", ListRoundsResponseV2DTO{})
+		doc.Schema("ListRoundsResponseV2DTO").Properties["rounds"].Items = roundSchema
+
+		userStatsSchema := doc.RegisterSchema("UserStatsV2DTO", UserStatsV2DTO{})
+		decorateUserStatsSchema(doc.Schema("UserStatsV2DTO"), v)
+
+		reportTotalsSchema := doc.RegisterSchema("ReportTotalsV2DTO", ReportTotalsV2DTO{})
+		decorateReportTotalsSchema(doc.Schema("ReportTotalsV2DTO"), v)
+
+		doc.RegisterSchema("ReportBucketV2DTO", ReportBucketV2DTO{})
+		decorateReportBucketSchema(doc.Schema("ReportBucketV2DTO"), reportTotalsSchema)
+
+		doc.RegisterSchema("ReportSummaryV2DTO", ReportSummaryV2DTO{})
+		decorateReportSummarySchema(doc.Schema("ReportSummaryV2DTO"), reportTotalsSchema, openapi.Ref("ReportBucketV2DTO"))
+
+		leaderboardEntrySchema := doc.RegisterSchema("LeaderboardEntryV2DTO", LeaderboardEntryV2DTO{})
+		decorateLeaderboardEntrySchema(doc.Schema("LeaderboardEntryV2DTO"), v)
+
+		doc.RegisterSchema("LeaderboardV2DTO", LeaderboardV2DTO{})
+		decorateLeaderboardSchema(doc.Schema("LeaderboardV2DTO"), leaderboardEntrySchema)
+
+		setLimitSchema := doc.RegisterSchema("SetLimitV2Request", SetLimitV2Request{})
+		decorateSetLimitSchema(doc.Schema("SetLimitV2Request"), v)
+
+		depositSchema := doc.RegisterSchema("DepositV2Request", DepositV2Request{})
+		decorateDepositSchema(doc.Schema("DepositV2Request"), v)
+
+		coolOffSchema := doc.RegisterSchema("CoolOffV2Request", CoolOffRequest{})
+		decorateCoolOffSchema(doc.Schema("CoolOffV2Request"), v)
+
+		selfExclusionSchema := doc.RegisterSchema("SelfExclusionV2Request", SelfExclusionRequest{})
+		decorateSelfExclusionSchema(doc.Schema("SelfExclusionV2Request"), v)
+
+		doc.RegisterSchema("LimitsV2DTO", LimitsV2DTO{})
+		decorateLimitsSchema(doc.Schema("LimitsV2DTO"), v)
+
+		doc.RegisterSchema("ExposureV2DTO", ExposureV2DTO{})
+		decorateExposureSchema(doc.Schema("ExposureV2DTO"), v)
+
+		fraudFlagSchema := doc.RegisterSchema("FraudFlagV2DTO", FraudFlagV2DTO{})
+		decorateFraudFlagSchema(doc.Schema("FraudFlagV2DTO"), v)
+
+		doc.RegisterSchema("ListFraudFlagsResponseV2DTO", ListFraudFlagsResponseV2DTO{})
+		doc.Schema("ListFraudFlagsResponseV2DTO").Properties["flags"].Items = fraudFlagSchema
+
+		reviewFlagSchema := doc.RegisterSchema("ReviewFraudFlagV2Request", ReviewFraudFlagRequest{})
+		decorateReviewFraudFlagSchema(doc.Schema("ReviewFraudFlagV2Request"), v)
+
+		return versionSchemas{
+			bet:           betSchema,
+			listBets:      openapi.Ref("ListBetsResponseV2DTO"),
+			createBet:     createBetSchema,
+			createWebhook: createWebhookSchema,
+			createBets:    createBetsSchema,
+			batchResult:   openapi.Ref("CreateBetsResponseV2DTO"),
+			voidBet:       voidBetSchema,
+			audit:         openapi.Ref("ListAuditEntriesResponseV2DTO"),
+			importBets:    importBetsSchema,
+			importResult:  doc.RegisterSchema("ImportBetsResponseDTO", ImportBetsResponseDTO{}),
+			round:         roundSchema,
+			listRounds:    openapi.Ref("ListRoundsResponseV2DTO"),
+			userStats:     userStatsSchema,
+			reportSummary: openapi.Ref("ReportSummaryV2DTO"),
+			leaderboard:   openapi.Ref("LeaderboardV2DTO"),
+			setLimit:      setLimitSchema,
+			deposit:       depositSchema,
+			coolOff:       coolOffSchema,
+			selfExclusion: selfExclusionSchema,
+			limits:        openapi.Ref("LimitsV2DTO"),
+			exposure:      openapi.Ref("ExposureV2DTO"),
+			fraudFlag:     fraudFlagSchema,
+			fraudFlags:    openapi.Ref("ListFraudFlagsResponseV2DTO"),
+			reviewFlag:    reviewFlagSchema,
+			errorMedia:    "application/problem+json",
+			errorSchema:   doc.RegisterSchema("Problem", Problem{}),
+		}
+	}
+
+	betSchema := doc.RegisterSchema("BetDTO", BetDTO{})
+	decorateBetSchema(doc.Schema("BetDTO"), v)
+
+	doc.RegisterSchema("ListBetsResponseDTO", ListBetsResponseDTO{})
+	decorateListBetsSchema(doc.Schema("ListBetsResponseDTO"), betSchema)
+
+	createBetSchema := doc.RegisterSchema(createBetSchemaName(v), CreateBetRequest{})
+	decorateCreateBetSchema(doc.Schema(createBetSchemaName(v)), v)
+
+	createWebhookSchema := doc.RegisterSchema("CreateWebhookRequest", CreateWebhookRequest{})
+	decorateCreateWebhookSchema(doc.Schema("CreateWebhookRequest"), v)
+
+	createBetsSchema := doc.RegisterSchema("CreateBetsRequest", CreateBetsRequest{})
+	decorateCreateBetsSchema(doc.Schema("CreateBetsRequest"), opts, v)
+
+	doc.RegisterSchema("CreateBetsResponseDTO", CreateBetsResponseDTO{})
+	decorateCreateBetsResponseSchema(doc.Schema("CreateBetsResponseDTO"), betSchema, v)
+
+	voidBetSchema := doc.RegisterSchema("VoidBetRequest", VoidBetRequest{})
+	decorateVoidBetSchema(doc.Schema("VoidBetRequest"), v)
+
+	doc.RegisterSchema("ListAuditEntriesResponseDTO", ListAuditEntriesResponseDTO{})
+	decorateAuditSchema(doc.Schema("ListAuditEntriesResponseDTO"), v)
+
+	importBetsSchema := doc.RegisterSchema("ImportBetsRequest", ImportBetsRequest{})
+	decorateImportBetsSchema(doc.Schema("ImportBetsRequest"), opts, betSchema, v)
+
+	roundSchema := doc.RegisterSchema("RoundDTO", RoundDTO{})
+	decorateRoundSchema(doc.Schema("RoundDTO"), v)
+
+	doc.RegisterSchema("ListRoundsResponseDTO", ListRoundsResponseDTO{})
+	doc.Schema("ListRoundsResponseDTO").Properties["rounds"].Items = roundSchema
+
+	userStatsSchema := doc.RegisterSchema("UserStatsDTO", UserStatsDTO{})
+	decorateUserStatsSchema(doc.Schema("UserStatsDTO"), v)
+
+	reportTotalsSchema := doc.RegisterSchema("ReportTotalsDTO", ReportTotalsDTO{})
+	decorateReportTotalsSchema(doc.Schema("ReportTotalsDTO"), v)
+
+	doc.RegisterSchema("ReportBucketDTO", ReportBucketDTO{})
+	decorateReportBucketSchema(doc.Schema("ReportBucketDTO"), reportTotalsSchema)
+
+	doc.RegisterSchema("ReportSummaryDTO", ReportSummaryDTO{})
+	decorateReportSummarySchema(doc.Schema("ReportSummaryDTO"), reportTotalsSchema, openapi.Ref("ReportBucketDTO"))
+
+	leaderboardEntrySchema := doc.RegisterSchema("LeaderboardEntryDTO", LeaderboardEntryDTO{})
+	decorateLeaderboardEntrySchema(doc.Schema("LeaderboardEntryDTO"), v)
+
+	doc.RegisterSchema("LeaderboardDTO", LeaderboardDTO{})
+	decorateLeaderboardSchema(doc.Schema("LeaderboardDTO"), leaderboardEntrySchema)
+
+	setLimitSchema := doc.RegisterSchema("SetLimitRequest", SetLimitRequest{})
+	decorateSetLimitSchema(doc.Schema("SetLimitRequest"), v)
+
+	depositSchema := doc.RegisterSchema("DepositRequest", DepositRequest{})
+	decorateDepositSchema(doc.Schema("DepositRequest"), v)
+
+	coolOffSchema := doc.RegisterSchema("CoolOffRequest", CoolOffRequest{})
+	decorateCoolOffSchema(doc.Schema("CoolOffRequest"), v)
+
+	selfExclusionSchema := doc.RegisterSchema("SelfExclusionRequest", SelfExclusionRequest{})
+	decorateSelfExclusionSchema(doc.Schema("SelfExclusionRequest"), v)
+
+	doc.RegisterSchema("LimitsDTO", LimitsDTO{})
+	decorateLimitsSchema(doc.Schema("LimitsDTO"), v)
+
+	doc.RegisterSchema("ExposureDTO", ExposureDTO{})
+	decorateExposureSchema(doc.Schema("ExposureDTO"), v)
+
+	fraudFlagSchema := doc.RegisterSchema("FraudFlagDTO", FraudFlagDTO{})
+	decorateFraudFlagSchema(doc.Schema("FraudFlagDTO"), v)
+
+	doc.RegisterSchema("ListFraudFlagsResponseDTO", ListFraudFlagsResponseDTO{})
+	doc.Schema("ListFraudFlagsResponseDTO").Properties["flags"].Items = fraudFlagSchema
+
+	reviewFlagSchema := doc.RegisterSchema("ReviewFraudFlagRequest", ReviewFraudFlagRequest{})
+	decorateReviewFraudFlagSchema(doc.Schema("ReviewFraudFlagRequest"), v)
+
+	return versionSchemas{
+		bet:           betSchema,
+		listBets:      openapi.Ref("ListBetsResponseDTO"),
+		createBet:     createBetSchema,
+		createWebhook: createWebhookSchema,
+		createBets:    createBetsSchema,
+		batchResult:   openapi.Ref("CreateBetsResponseDTO"),
+		voidBet:       voidBetSchema,
+		audit:         openapi.Ref("ListAuditEntriesResponseDTO"),
+		importBets:    importBetsSchema,
+		importResult:  doc.RegisterSchema("ImportBetsResponseDTO", ImportBetsResponseDTO{}),
+		round:         roundSchema,
+		listRounds:    openapi.Ref("ListRoundsResponseDTO"),
+		userStats:     userStatsSchema,
+		reportSummary: openapi.Ref("ReportSummaryDTO"),
+		leaderboard:   openapi.Ref("LeaderboardDTO"),
+		setLimit:      setLimitSchema,
+		deposit:       depositSchema,
+		coolOff:       coolOffSchema,
+		selfExclusion: selfExclusionSchema,
+		limits:        openapi.Ref("LimitsDTO"),
+		exposure:      openapi.Ref("ExposureDTO"),
+		fraudFlag:     fraudFlagSchema,
+		fraudFlags:    openapi.Ref("ListFraudFlagsResponseDTO"),
+		reviewFlag:    reviewFlagSchema,
+		errorMedia:    "application/json",
+		errorSchema:   doc.RegisterSchema("ErrorResponse", ErrorResponse{}),
+	}
+}
+
+func addVersionOperations(doc *openapi.Document, opts SpecOptions, v APIVersion, prefix, idPrefix string, deprecated bool) {
+	schemas := registerVersionSchemas(doc, opts, v)
+
+	webhookSchema := doc.RegisterSchema("WebhookDTO", WebhookDTO{})
+	doc.Schema("WebhookDTO").Properties["id"].Format = "uuid"
+	doc.Schema("WebhookDTO").Properties["url"].Format = "uri"
+	doc.Schema("WebhookDTO").Properties["created_at"].Format = "date-time"
+	doc.Schema("WebhookDTO").Properties["disabled_at"].Format = "date-time"
+
+	apiKeySchema := doc.RegisterSchema("APIKeyDTO", APIKeyDTO{})
+	decorateAPIKeySchema(doc.Schema("APIKeyDTO"))
+	createAPIKeySchema := doc.RegisterSchema("CreateAPIKeyRequest", CreateAPIKeyRequest{})
+	decorateCreateAPIKeySchema(doc.Schema("CreateAPIKeyRequest"))
+	doc.RegisterSchema("ListAPIKeysResponseDTO", ListAPIKeysResponseDTO{})
+	doc.Schema("ListAPIKeysResponseDTO").Properties["keys"].Items = apiKeySchema
+	doc.RegisterSchema("CreateAPIKeyResponseDTO", CreateAPIKeyResponseDTO{})
+	doc.Schema("CreateAPIKeyResponseDTO").Properties["key"] = apiKeySchema
+	doc.Schema("CreateAPIKeyResponseDTO").Properties["secret"].Description = "The key itself. It is only returned here; store it now."
+
+	snapshotSchema := doc.RegisterSchema("SnapshotDTO", SnapshotDTO{})
+	decorateSnapshotSchema(doc.Schema("SnapshotDTO"))
+	doc.RegisterSchema("ListSnapshotsResponseDTO", ListSnapshotsResponseDTO{})
+	doc.Schema("ListSnapshotsResponseDTO").Properties["snapshots"].Items = snapshotSchema
+	doc.RegisterSchema("CompactSnapshotsResponseDTO", CompactSnapshotsResponseDTO{})
+	doc.Schema("CompactSnapshotsResponseDTO").Properties["removed"].Items = snapshotSchema
+
+	fraudRulesSchema := doc.RegisterSchema("FraudRuleSetDTO", FraudRuleSetDTO{})
+	decorateFraudRuleSetSchema(doc.Schema("FraudRuleSetDTO"))
+
+	deliverySchema := doc.RegisterSchema("WebhookDeliveryDTO", WebhookDeliveryDTO{})
+	doc.RegisterSchema("ListWebhookDeliveriesResponseDTO", ListWebhookDeliveriesResponseDTO{})
+	doc.Schema("ListWebhookDeliveriesResponseDTO").Properties["deliveries"].Items = deliverySchema
+
+	errorRes := func(description string) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Headers:     requestIDHeader(),
+			Content:     map[string]openapi.MediaType{schemas.errorMedia: {Schema: schemas.errorSchema}},
+		}
+	}
+	okRes := func(description string, schema *openapi.Schema) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Headers:     requestIDHeader(),
+			Content:     openapi.JSONContent(schema),
+		}
+	}
+	withErrors := func(responses map[string]*openapi.Response, codes ...string) map[string]*openapi.Response {
+		descriptions := map[string]string{
+			"400": "Validation error",
+			"401": "Missing or invalid API key",
+			"403": "API key lacks the required scope",
+			"404": "Resource not found",
+			"409": "The bet's current state does not allow this change",
+			"422": "Blocked by a responsible gambling limit, cool-off, self-exclusion, house liability cap or fraud rule",
+			"429": "Rate limit exceeded",
+			"499": "Client closed the request",
+			"500": "Internal error",
+			"503": "Service unavailable",
+			"504": "Request deadline exceeded",
+		}
+		for _, code := range codes {
+			responses[code] = errorRes(descriptions[code])
+		}
+		return responses
+	}
+	withConflict := func(responses map[string]*openapi.Response, description string) map[string]*openapi.Response {
+		responses["409"] = errorRes(description)
+		return responses
+	}
+	add := func(method, path string, op *openapi.Operation) {
+		op.OperationID = operationID(idPrefix, op.OperationID)
+		if op.RequiresAuth() {
+			op.Responses["503"] = errorRes("No API keys are configured (`AUTH_API_KEYS` is empty), so the route is closed")
+		}
+		op.Deprecated = deprecated
+		if deprecated {
+			op.Description = strings.TrimSpace(op.Description + " Deprecated alias of `" + V1.Prefix() + path + "`; responses carry `Deprecation` and `Sunset` headers.")
+		}
+		doc.AddOperation(method, prefix+path, op)
+	}
+	addVersioned := func(method, path string, op *openapi.Operation) {
+		if deprecated {
+			return
+		}
+		add(method, path, op)
+	}
+
+	add(http.MethodPost, "/bets", &openapi.Operation{
+		OperationID: "createBet",
+		Summary:     "Place a bet",
+		Description: "Places a bet on the current round, or on the next round if betting is closed. `crash_point` is the auto cash-out target. Once API keys are configured this requires the `bets:write` scope and a key that acts for `user_id`; a key bound to another user gets `403 NOT_USERS_KEY`. While `AUTH_API_KEYS` is empty, bets are placed anonymously. Bets that would take the round or the user over a house liability cap respond `422` in `RISK_MODE=reject`; in `RISK_MODE=cap` their `crash_point` is lowered to fit. When fraud rules are configured, bets that score `hold` or `reject` respond `422 ACCOUNT_ON_HOLD` or `422 BET_DECLINED`, and a user with an open or confirmed hold cannot bet until it is dismissed.",
+		Tags:        []string{"bets"},
+		Parameters:  []*openapi.Parameter{deviceIDHeader()},
+		Security:    optionalScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.createBet),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"201": okRes("Bet created", schemas.bet),
+		}, "400", "401", "403", "422", "429", "499", "500", "504"),
+	})
+
+	add(http.MethodPost, "/bets/batch", &openapi.Operation{
+		OperationID: "createBets",
+		Summary:     "Place several bets",
+		Description: "Once API keys are configured this requires the `bets:write` scope and a key that acts for every item's `user_id`; while `AUTH_API_KEYS` is empty, bets are placed anonymously. In `all_or_nothing` mode every bet is placed on the same round under a single repository write: any invalid item rejects the whole batch with a 400 listing every violation, responsible gambling limits are checked against each user's total in the batch, house liability caps are applied to the bets in order, fraud rules score every bet, and a breach, cap rejection, `hold` or `reject` on any item rejects the whole batch with a 422. In `per_item` mode each valid item is screened and placed on its own, in order, exactly as `createBet` would place it, so limits and caps see the items placed before it. An item that fails validation, ownership, a limit, a liability cap or a fraud rule is reported in its result with the error `code`, `error` and any `limit` or `risk` details, the other items are still placed, and the response is 207 if any item was rejected.",
+		Tags:        []string{"bets"},
+		Parameters:  []*openapi.Parameter{deviceIDHeader()},
+		Security:    optionalScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.createBets),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"201": okRes("All bets created", schemas.batchResult),
+			"207": okRes("Some bets were rejected", schemas.batchResult),
+		}, "400", "401", "403", "422", "429", "499", "500", "504"),
+	})
+
+	add(http.MethodGet, "/bets", &openapi.Operation{
+		OperationID: "listBets",
+		Summary:     "List bets",
+		Tags:        []string{"bets"},
+		Parameters:  append(paginationParameters(), betFilterParameters()...),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("A page of bets", schemas.listBets),
+		}, "400", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/bets/export", &openapi.Operation{
+		OperationID: "exportBets",
+		Summary:     "Export bets",
+		Description: "Streams every bet matching the `listBets` filters as CSV, NDJSON or Parquet, oldest first by default. The body is written as rows are read, so a failure after the first row aborts the response instead of returning an error document. The `" + headerChecksum + "` and `" + headerExportRows + "` trailers carry the SHA-256 and row count of the body as sent, before any `Content-Encoding`. With `compression=gzip`, CSV and NDJSON are gzipped files and Parquet uses gzip page compression (the default is Snappy). Requires the `bets:read` scope.",
+		Tags:        []string{"bets"},
+		Parameters:  exportParameters(),
+		Security:    requireScope(auth.ScopeBetsRead),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": {
+				Description: "Export file",
+				Headers:     exportHeaders(),
+				Content: map[string]openapi.MediaType{
+					export.ContentType(export.FormatCSV, export.CompressionNone):     {Schema: &openapi.Schema{Type: "string"}},
+					export.ContentType(export.FormatNDJSON, export.CompressionNone):  {Schema: &openapi.Schema{Type: "string"}},
+					export.ContentType(export.FormatParquet, export.CompressionNone): {Schema: &openapi.Schema{Type: "string", Format: "binary"}},
+					export.ContentType(export.FormatCSV, export.CompressionGzip):     {Schema: &openapi.Schema{Type: "string", Format: "binary"}},
+				},
+			},
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	add(http.MethodGet, "/bets/{id}", &openapi.Operation{
+		OperationID: "getBet",
+		Summary:     "Get a bet by ID",
+		Tags:        []string{"bets"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Bet ID")},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The bet", schemas.bet),
+		}, "400", "404", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/users/{id}/stats", &openapi.Operation{
+		OperationID: "getUserStats",
+		Summary:     "Get a user's bet statistics",
+		Description: "Aggregates over the user's bets, kept up to date as bets are placed and settled. Wagered, paid-out, P&L, win rate and crash point figures cover won and lost bets only; pending bets are counted until they settle, and cancelled or voided bets are refunded and only counted. `from` and `to` limit the result to bets placed on those UTC days, inclusive. A user without bets gets zero counts.",
+		Tags:        []string{"bets"},
+		Parameters: []*openapi.Parameter{
+			userIDPathParameter(),
+			{Name: "from", In: "query", Description: "First UTC day to include", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+			{Name: "to", In: "query", Description: "Last UTC day to include; must not be before `from`", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's statistics", schemas.userStats),
+		}, "400", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/users/{id}/limits", &openapi.Operation{
+		OperationID: "getLimits",
+		Summary:     "Get a user's responsible gambling limits",
+		Description: "Active limits with their usage in the current UTC period, pending increases, and any cool-off or self-exclusion. Periods are calendar days, ISO weeks starting on Monday, and calendar months. Wager usage is the stakes of bets placed in the period that were not refunded; loss usage is those stakes minus payouts, with pending stakes counted as lost. Deposit usage is the deposits recorded with `recordDeposit` in the period. Requires the `bets:read` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  []*openapi.Parameter{userIDPathParameter()},
+		Security:    requireScope(auth.ScopeBetsRead),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits", schemas.limits),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPut, "/users/{id}/limits/{type}/{period}", &openapi.Operation{
+		OperationID: "setLimit",
+		Summary:     "Set a responsible gambling limit",
+		Description: "Sets a deposit, loss or wager limit for a period. Deposit limits are checked by `recordDeposit`; loss and wager limits are checked when a bet is placed. A new or lower limit applies immediately and cancels any pending increase. A higher limit becomes `pending` and applies after `LIMITS_INCREASE_DELAY` hours. Requires the `bets:write` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  limitPathParameters(),
+		Security:    requireScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.setLimit),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits after the change", schemas.limits),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodDelete, "/users/{id}/limits/{type}/{period}", &openapi.Operation{
+		OperationID: "removeLimit",
+		Summary:     "Remove a responsible gambling limit",
+		Description: "Removing a limit loosens it, so the removal becomes `pending` with a null `amount` and applies after `LIMITS_INCREASE_DELAY` hours. Requires the `bets:write` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  limitPathParameters(),
+		Security:    requireScope(auth.ScopeBetsWrite),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits after the change", schemas.limits),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/users/{id}/deposits", &openapi.Operation{
+		OperationID: "recordDeposit",
+		Summary:     "Record a deposit against the deposit limits",
+		Description: "The wallet calls this before it credits a deposit. The deposit is checked against the user's active deposit limits and recorded if it fits; a deposit over a limit responds `422 DEPOSIT_LIMIT_EXCEEDED` with the `remaining` allowance and is not recorded, and a user in a cool-off or self-exclusion gets `422 COOL_OFF_ACTIVE` or `422 SELF_EXCLUDED`. Requires the `bets:write` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  []*openapi.Parameter{userIDPathParameter()},
+		Security:    requireScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.deposit),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits after the deposit", schemas.limits),
+		}, "400", "401", "403", "422", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/users/{id}/cool-off", &openapi.Operation{
+		OperationID: "startCoolOff",
+		Summary:     "Start a cool-off period",
+		Description: "Blocks new bets for the given number of days. A cool-off cannot be shortened or lifted; a longer one replaces the current end. Requires the `bets:write` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  []*openapi.Parameter{userIDPathParameter()},
+		Security:    requireScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.coolOff),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits after the change", schemas.limits),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/users/{id}/self-exclusion", &openapi.Operation{
+		OperationID: "selfExclude",
+		Summary:     "Self-exclude from betting",
+		Description: "Blocks new bets for the chosen duration or permanently. A self-exclusion cannot be shortened or lifted through the API; a longer one replaces the current end. Requires the `bets:write` scope and a key that acts for the user; a key bound to another user, or not bound to any, gets `403 NOT_USERS_KEY`.",
+		Tags:        []string{"limits"},
+		Parameters:  []*openapi.Parameter{userIDPathParameter()},
+		Security:    requireScope(auth.ScopeBetsWrite),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.selfExclusion),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The user's limits after the change", schemas.limits),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/leaderboards/{type}", &openapi.Operation{
+		OperationID: "getLeaderboard",
+		Summary:     "Get a leaderboard",
+		Description: "Top won bets by payout (`wins`) or by auto cash-out multiplier (`multipliers`). `day` and `week` are rolling windows over the settlement time; `all` covers every bet since the server started, seeded from the restored history. Ties rank the earlier settlement first. Voided bets drop off. Users are masked according to `LEADERBOARD_USER_MASK`. With `around_user`, the board is centred on that user's best bet instead of starting at rank 1, and the user's own entries are marked `self`; this needs a key with the `bets:read` scope that acts for the user.",
+		Tags:        []string{"bets"},
+		Security:    optionalScope(auth.ScopeBetsRead),
+		Parameters: []*openapi.Parameter{
+			{Name: "type", In: "path", Description: "Leaderboard to read", Required: true, Schema: &openapi.Schema{Type: "string", Enum: leaderboardTypeEnum()}},
+			{Name: "window", In: "query", Schema: &openapi.Schema{Type: "string", Enum: leaderboardWindowEnum(), Default: string(domain.LeaderboardDay)}},
+			{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(domain.MaxLeaderboardLimit), Default: domain.DefaultLeaderboardLimit}},
+			{Name: "around_user", In: "query", Description: "Return the entries ranked around this user's best bet", Schema: userIDSchema()},
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Ranked entries, best first; empty when `around_user` has no entry on the board", schemas.leaderboard),
+			"503": errorRes("`around_user` was given but no API keys are configured (`AUTH_API_KEYS` is empty)"),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/reports/summary", &openapi.Operation{
+		OperationID: "getReportSummary",
+		Summary:     "Get house revenue by period",
+		Description: fmt.Sprintf("Turnover, payouts, gross gaming revenue (`ggr`), realized RTP and unique players for bets placed in `[from, to)`, in UTC buckets of the given granularity. `from` is rounded down to the hour. Buckets at the edges of the period only count the hours inside it, and at most %d buckets are returned. The figures come from hourly rollups kept up to date as bets are placed and settled. `ggr` and `rtp` cover won and lost bets only; `turnover` also includes pending stakes. `theoretical_rtp` is `1 - GAME_HOUSE_EDGE`. Rollups are kept per hour, currency and operator, so `currency` and `operator` narrow the report to bets in that currency or placed through that operator's keys. Without `currency`, money totals add stakes of every currency as they are, without conversion.", domain.MaxReportBuckets),
+		Tags:        []string{"reports"},
+		Parameters: []*openapi.Parameter{
+			{Name: "from", In: "query", Description: "Start of the period (inclusive)", Required: true, Schema: &openapi.Schema{Type: "string", Format: "date-time", MinLength: openapi.Int(1)}},
+			{Name: "to", In: "query", Description: "End of the period (exclusive); must be after `from`", Required: true, Schema: &openapi.Schema{Type: "string", Format: "date-time", MinLength: openapi.Int(1)}},
+			{Name: "granularity", In: "query", Schema: &openapi.Schema{Type: "string", Enum: granularityEnum(), Default: string(defaultGranularity)}},
+			{Name: "currency", In: "query", Description: "Only count bets placed in this currency", Schema: currencySchema()},
+			{Name: "operator", In: "query", Description: "Only count bets placed with keys named for this operator", Schema: operatorNameSchema()},
+		},
+		Security: requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Totals for the period and one entry per bucket", schemas.reportSummary),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodDelete, "/bets/{id}", &openapi.Operation{
+		OperationID: "cancelBet",
+		Summary:     "Cancel a bet",
+		Description: "Cancels a pending bet and refunds the stake as its `payout`. Only allowed while the bet's round is still taking bets; otherwise responds `409 BET_NOT_CANCELLABLE`. Requires the `bets:write` scope and a key that acts for the bet's user: a key bound to another user gets `403 NOT_USERS_KEY`. The cancellation and its audit entry are stored together.",
+		Tags:        []string{"bets"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Bet ID")},
+		Security:    requireScope(auth.ScopeBetsWrite),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The cancelled bet", schemas.bet),
+		}, "400", "401", "403", "404", "409", "429", "499", "500", "504"),
+	})
+
+	add(http.MethodGet, "/bets/stream", &openapi.Operation{
+		OperationID: "streamBets",
+		Summary:     "Stream newly created bets",
+		Description: "Server-Sent Events stream. Each `bet.created` event carries a bet in this version's representation as `data`. Send `Last-Event-ID` to resume.",
+		Tags:        []string{"streams"},
+		Parameters: append(betFilterParameters(),
+			&openapi.Parameter{Name: "last_event_id", In: "query", Description: "Resume after this event ID (alternative to the Last-Event-ID header)", Schema: &openapi.Schema{Type: "string"}},
+			&openapi.Parameter{Name: "Last-Event-ID", In: "header", Description: "Resume after this event ID", Schema: &openapi.Schema{Type: "string"}},
+		),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": {
+				Description: "Event stream of bet payloads",
+				Content:     map[string]openapi.MediaType{"text/event-stream": {Schema: &openapi.Schema{Type: "string"}}},
+			},
+		}, "400", "429"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/bets/{id}/void", &openapi.Operation{
+		OperationID: "voidBet",
+		Summary:     "Void a bet",
+		Description: "Voids a pending or settled bet and refunds the stake as its `payout`, recording the reason in the audit trail. A pending bet is removed from its round before settlement. Cancelled or already voided bets respond `409 BET_NOT_VOIDABLE`.",
+		Tags:        []string{"admin"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Bet ID")},
+		Security:    requireScope(auth.ScopeAdmin),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.voidBet),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The voided bet", schemas.bet),
+		}, "400", "401", "403", "404", "409", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/bets/{id}/audit", &openapi.Operation{
+		OperationID: "getBetAudit",
+		Summary:     "Get a bet's audit trail",
+		Description: "State changes made through cancellation or voiding, oldest first.",
+		Tags:        []string{"admin"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Bet ID")},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Audit entries", schemas.audit),
+		}, "400", "401", "403", "404", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/bets/import", &openapi.Operation{
+		OperationID: "importBets",
+		Summary:     "Import historical bets",
+		Description: "Loads settled bets from another platform, keeping their IDs and timestamps. Imported bets do not join a round and emit no events. The batch is validated as a whole: any invalid item fails the request with a 400 listing every violation. Bets whose ID already exists are skipped and listed in `duplicates`, so a batch can be retried safely.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.importBets),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Import result", schemas.importResult),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/rounds", &openapi.Operation{
+		OperationID: "listRounds",
+		Summary:     "List recent rounds",
+		Description: "The round taking bets next, the round in play and up to 100 crashed rounds, newest first. `crash_point` is only shown once a round has crashed.",
+		Tags:        []string{"admin"},
+		Parameters: []*openapi.Parameter{
+			{
+				Name:   "limit",
+				In:     "query",
+				Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(100), Default: defaultRoundLimit},
+			},
+		},
+		Security: requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Rounds, newest first", schemas.listRounds),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/rounds/{id}", &openapi.Operation{
+		OperationID: "getRound",
+		Summary:     "Get a round",
+		Description: "Only rounds still held by the game engine can be inspected; older rounds respond `404 ROUND_NOT_FOUND`.",
+		Tags:        []string{"admin"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Round ID")},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The round", schemas.round),
+		}, "400", "401", "403", "404", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/risk/exposure", &openapi.Operation{
+		OperationID: "getExposure",
+		Summary:     "Get house exposure",
+		Description: "Potential payout (`amount * crash_point`) of the bets on the rounds still taking bets or in play, with the users holding the most exposure across them. Once a round's exposure reaches `RISK_THROTTLE_RATIO` of `RISK_ROUND_LIABILITY`, new bets on it are limited to `RISK_THROTTLED_CRASH_POINT` until it crashes. Liability caps of 0 are disabled.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Current exposure", schemas.exposure),
+		}, "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/fraud/flags", &openapi.Operation{
+		OperationID: "listFraudFlags",
+		Summary:     "List fraud flags",
+		Description: "Bets scored `flag`, `hold` or `reject` by the fraud rules, newest first. Flagged bets are placed and carry `bet_id`; held and rejected attempts are not placed. A `hold` blocks the user's bets until the flag is dismissed.",
+		Tags:        []string{"admin"},
+		Parameters: []*openapi.Parameter{
+			{Name: "status", In: "query", Schema: &openapi.Schema{Type: "string", Enum: flagStatusEnum()}},
+			{Name: "user_id", In: "query", Description: "Only include flags raised for this user", Schema: userIDSchema()},
+			{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(domain.MaxFraudFlagLimit), Default: domain.DefaultFraudFlagLimit}},
+		},
+		Security: requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Flags, newest first", schemas.fraudFlags),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/fraud/flags/{id}/review", &openapi.Operation{
+		OperationID: "reviewFraudFlag",
+		Summary:     "Review a fraud flag",
+		Description: "Marks a flag `confirmed` or `dismissed`, recording the reviewer and an optional note. Dismissing a `hold` flag lets the user bet again; a flag can be reviewed again to change its outcome.",
+		Tags:        []string{"admin"},
+		Parameters:  []*openapi.Parameter{uuidPathParameter("Flag ID")},
+		Security:    requireScope(auth.ScopeAdmin),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.reviewFlag),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("The reviewed flag", schemas.fraudFlag),
+		}, "400", "401", "403", "404", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/fraud/rules", &openapi.Operation{
+		OperationID: "getFraudRules",
+		Summary:     "Get the fraud rules",
+		Description: "The rules loaded from `FRAUD_RULES_FILE`. Responds `409 FRAUD_RULES_DISABLED` when `FRAUD_RULES_FILE` is unset.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"200": okRes("The loaded rules", fraudRulesSchema),
+		}, "401", "403", "429", "499", "500", "504"), "Fraud rules are disabled"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/fraud/rules/reload", &openapi.Operation{
+		OperationID: "reloadFraudRules",
+		Summary:     "Reload the fraud rules",
+		Description: "Re-reads `FRAUD_RULES_FILE` now instead of waiting for the next `FRAUD_RELOAD_INTERVAL` poll. An invalid file responds `409 FRAUD_RULES_INVALID` and the previous rules stay in force.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"200": okRes("The loaded rules", fraudRulesSchema),
+		}, "401", "403", "429", "499", "500", "504"), "Fraud rules are disabled or the file is invalid"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/api-keys", &openapi.Operation{
+		OperationID: "listAPIKeys",
+		Summary:     "List API keys",
+		Description: "Keys from `AUTH_API_KEYS` have source `config`; keys created through the API have source `api`. Secrets are never returned.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("API keys, oldest first", openapi.Ref("ListAPIKeysResponseDTO")),
+		}, "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/api-keys", &openapi.Operation{
+		OperationID: "createAPIKey",
+		Summary:     "Create an API key",
+		Description: "Generates a key with the given scopes and returns its secret once. Keys created at runtime are held in memory and do not survive a restart. Creating a key enables authentication if no keys were configured.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(createAPIKeySchema),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"201": okRes("Key created", openapi.Ref("CreateAPIKeyResponseDTO")),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodDelete, "/admin/api-keys/{id}", &openapi.Operation{
+		OperationID: "revokeAPIKey",
+		Summary:     "Revoke an API key",
+		Description: "Revokes a key created through the API. Keys from `AUTH_API_KEYS` respond `409 API_KEY_READ_ONLY`; remove them from the configuration instead.",
+		Tags:        []string{"admin"},
+		Parameters:  []*openapi.Parameter{apiKeyIDPathParameter()},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"200": okRes("The revoked key", apiKeySchema),
+		}, "400", "401", "403", "404", "429", "499", "500", "504"), "The key is configured in `AUTH_API_KEYS`"),
+	})
+
+	addVersioned(http.MethodGet, "/admin/snapshots", &openapi.Operation{
+		OperationID: "listSnapshots",
+		Summary:     "List snapshots",
+		Description: "Snapshots in `SNAPSHOT_DIR`, newest first. Responds `409 SNAPSHOTS_DISABLED` when `SNAPSHOT_DIR` is unset.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"200": okRes("Snapshots, newest first", openapi.Ref("ListSnapshotsResponseDTO")),
+		}, "401", "403", "429", "499", "500", "504"), "Snapshots are disabled"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/snapshots", &openapi.Operation{
+		OperationID: "createSnapshot",
+		Summary:     "Take a snapshot",
+		Description: "Writes every bet to a gzipped NDJSON file in `SNAPSHOT_DIR` with a manifest holding its row count and SHA-256. On startup the newest intact snapshot is restored; bets that were pending when it was taken are restored voided with reason `technical_error`.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"201": okRes("Snapshot created", snapshotSchema),
+		}, "401", "403", "429", "499", "500", "504"), "Snapshots are disabled"),
+	})
+
+	addVersioned(http.MethodPost, "/admin/snapshots/compact", &openapi.Operation{
+		OperationID: "compactSnapshots",
+		Summary:     "Compact snapshots",
+		Description: "Deletes all but the newest `SNAPSHOT_RETAIN` snapshots, along with files left behind by interrupted snapshots.",
+		Tags:        []string{"admin"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: withConflict(withErrors(map[string]*openapi.Response{
+			"200": okRes("Compaction result", openapi.Ref("CompactSnapshotsResponseDTO")),
+		}, "401", "403", "429", "499", "500", "504"), "Snapshots are disabled"),
+	})
+
+	addVersioned(http.MethodPost, "/webhooks", &openapi.Operation{
+		OperationID: "createWebhook",
+		Summary:     "Register a webhook",
+		Description: "Deliveries are signed with `X-Webhook-Signature: sha256=HMAC(secret, timestamp + \".\" + body)`. The secret is never returned. The webhook belongs to the calling key and only receives events for bets of users that key acts for. URLs whose host resolves to a loopback, link-local or private address are rejected with a 400, and deliveries never connect to such addresses.",
+		Tags:        []string{"webhooks"},
+		Security:    requireScope(auth.ScopeWebhooks),
+		RequestBody: &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSONContent(schemas.createWebhook),
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"201": okRes("Webhook created", webhookSchema),
+		}, "400", "401", "403", "429", "499", "500", "504"),
+	})
+
+	addVersioned(http.MethodGet, "/webhooks/{id}/deliveries", &openapi.Operation{
+		OperationID: "listWebhookDeliveries",
+		Summary:     "List recent webhook deliveries",
+		Description: "Only the key that registered the webhook, or an `admin` key, can see its deliveries; other keys get a 404.",
+		Tags:        []string{"webhooks"},
+		Security:    requireScope(auth.ScopeWebhooks),
+		Parameters: []*openapi.Parameter{
+			uuidPathParameter("Webhook ID"),
+			{
+				Name:   "limit",
+				In:     "query",
+				Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(100), Default: defaultDeliveryLimit},
+			},
+		},
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": okRes("Most recent deliveries first", openapi.Ref("ListWebhookDeliveriesResponseDTO")),
+		}, "400", "401", "403", "404", "429", "499", "500", "504"),
+	})
+}
+
+func addSystemOperations(doc *openapi.Document, opts SpecOptions) {
+	errorRef := doc.RegisterSchema("ErrorResponse", ErrorResponse{})
+	healthSchema := doc.RegisterSchema("HealthResponse", HealthResponse{})
+	statusSchema := &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"status": {Type: "string"}, "error": {Type: "string"}},
+		Required:   []string{"status"},
+	}
+	okRes := func(description string, schema *openapi.Schema) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Headers:     requestIDHeader(),
+			Content:     openapi.JSONContent(schema),
+		}
+	}
+
+	doc.AddOperation(http.MethodGet, "/feed", &openapi.Operation{
+		OperationID: "liveFeed",
+		Summary:     "Live game feed (WebSocket)",
+		Description: "Upgrades to a WebSocket streaming round and bet events. Send `{\"action\":\"subscribe\",\"user_id\":123}` to change the user filter. The feed has its own message protocol and is not versioned.",
+		Tags:        []string{"streams"},
+		Parameters:  []*openapi.Parameter{userIDQueryParameter()},
+		Responses: map[string]*openapi.Response{
+			"101": {Description: "Switching to the WebSocket protocol"},
+			"400": {Description: "Validation error", Content: openapi.JSONContent(errorRef)},
+			"429": {Description: "Rate limit exceeded", Content: openapi.JSONContent(errorRef)},
+			"503": {Description: "Service unavailable", Content: openapi.JSONContent(errorRef)},
+		},
+	})
+
+	doc.AddOperation(http.MethodGet, "/health", &openapi.Operation{
+		OperationID: "health",
+		Summary:     "Health check",
+		Tags:        []string{"system"},
+		Responses:   map[string]*openapi.Response{"200": okRes("Service is healthy", healthSchema)},
+	})
+
+	doc.AddOperation(http.MethodGet, "/ready", &openapi.Operation{
+		OperationID: "ready",
+		Summary:     "Readiness check",
+		Tags:        []string{"system"},
+		Responses: map[string]*openapi.Response{
+			"200": okRes("Ready to serve traffic", statusSchema),
+			"503": okRes("Repository unavailable", statusSchema),
+		},
+	})
+
+	doc.AddOperation(http.MethodGet, "/live", &openapi.Operation{
+		OperationID: "live",
+		Summary:     "Liveness check",
+		Tags:        []string{"system"},
+		Responses:   map[string]*openapi.Response{"200": okRes("Process is alive", statusSchema)},
+	})
+
+	doc.AddOperation(http.MethodGet, "/debug/vars", &openapi.Operation{
+		OperationID: "debugVars",
+		Summary:     "Process metrics (expvar)",
+		Description: "Exposes the process command line and memory statistics, so it requires an `admin` key.",
+		Tags:        []string{"system"},
+		Security:    requireScope(auth.ScopeAdmin),
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "expvar counters", Content: openapi.JSONContent(&openapi.Schema{Type: "object"})},
+			"401": {Description: "Missing or invalid API key", Content: openapi.JSONContent(errorRef)},
+			"403": {Description: "API key lacks the required scope", Content: openapi.JSONContent(errorRef)},
+			"503": {Description: "No API keys are configured (`AUTH_API_KEYS` is empty), so the route is closed", Content: openapi.JSONContent(errorRef)},
+		},
+	})
+
+	doc.AddOperation(http.MethodGet, "/openapi.json", &openapi.Operation{
+		OperationID: "getOpenAPISpec",
+		Summary:     "This OpenAPI document",
+		Tags:        []string{"system"},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "OpenAPI 3.1 document", Content: openapi.JSONContent(&openapi.Schema{Type: "object"})},
+		},
+	})
+
+	if opts.DocsEnabled {
+		doc.AddOperation(http.MethodGet, "/docs", &openapi.Operation{
+			OperationID: "docs",
+			Summary:     "Interactive API documentation",
+			Tags:        []string{"system"},
+			Responses: map[string]*openapi.Response{
+				"200": {Description: "HTML page", Content: map[string]openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}}},
+			},
+		})
+
+		doc.AddOperation(http.MethodGet, "/docs/assets/{asset}", &openapi.Operation{
+			OperationID: "docsAsset",
+			Summary:     "Swagger UI asset embedded in the binary",
+			Tags:        []string{"system"},
+			Parameters: []*openapi.Parameter{{
+				Name:     "asset",
+				In:       "path",
+				Required: true,
+				Schema:   &openapi.Schema{Type: "string", Enum: stringsToEnum(docsAssetNames)},
+			}},
+			Responses: map[string]*openapi.Response{
+				"200": {Description: "Stylesheet or script", Content: map[string]openapi.MediaType{
+					"text/css":        {Schema: &openapi.Schema{Type: "string"}},
+					"text/javascript": {Schema: &openapi.Schema{Type: "string"}},
+				}},
+				"400": {Description: "Unknown asset", Content: openapi.JSONContent(errorRef)},
+			},
+		})
+	}
+}
+
+func createBetSchemaName(v APIVersion) string {
+	if v.DecimalStrings {
+		return "CreateBetV2Request"
+	}
+	return "CreateBetRequest"
+}
+
+func operationID(prefix, id string) string {
+	if prefix == "" {
+		return id
+	}
+	return prefix + strings.ToUpper(id[:1]) + id[1:]
+}
+
+func userIDSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "integer", Format: "int64", Minimum: openapi.Float(1), Maximum: openapi.Float(999999999)}
+}
+
+func currencySchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Pattern: domain.CurrencyPattern, Description: "ISO 4217 currency code"}
+}
+
+func operatorNameSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "string", Pattern: domain.OperatorNamePattern}
+}
+
+func amountSchema() *openapi.Schema {
+	return &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(1), Maximum: openapi.Float(100000)}
+}
+
+func crashPointSchema() *openapi.Schema {
+	return &openapi.Schema{
+		Type:        "number",
+		Format:      "double",
+		Description: "Auto cash-out multiplier target",
+		Minimum:     openapi.Float(1),
+		Maximum:     openapi.Float(domain.MaxBetCrashPoint),
+	}
+}
+
+func decimalSchema(numeric *openapi.Schema) *openapi.Schema {
+	s := &openapi.Schema{
+		Type:        "string",
+		Format:      "decimal",
+		Pattern:     `^[0-9]+(\.[0-9]{1,2})?$`,
+		Description: "Decimal string with up to two fraction digits",
+	}
+	if numeric.Minimum != nil && numeric.Maximum != nil {
+		s.Description = fmt.Sprintf("%s, between %.2f and %.2f", s.Description, *numeric.Minimum, *numeric.Maximum)
+	}
+	if numeric.Description != "" {
+		s.Description = numeric.Description + ". " + s.Description
+	}
+	return s
+}
+
+func moneySchema(v APIVersion, numeric *openapi.Schema) *openapi.Schema {
+	if v.DecimalStrings {
+		return decimalSchema(numeric)
+	}
+	return numeric
+}
+
+func decorateBetSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["id"].Format = "uuid"
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["amount"] = moneySchema(v, amountSchema())
+	s.Properties["crash_point"] = moneySchema(v, crashPointSchema())
+	s.Properties["currency"] = currencySchema()
+	s.Properties["operator"] = operatorNameSchema()
+	s.Properties["operator"].Description = "Operator named by the key that placed the bet; omitted for bets placed without one"
+	s.Properties["round_id"].Format = "uuid"
+	s.Properties["status"].Enum = []interface{}{"pending", "won", "lost", "cancelled", "voided"}
+	s.Properties["payout"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(0)})
+	s.Properties["void_reason"] = voidReasonSchema()
+	s.Properties["created_at"].Format = "date-time"
+	s.Properties["settled_at"].Format = "date-time"
+}
+
+func decorateListBetsSchema(s *openapi.Schema, betSchema *openapi.Schema) {
+	s.Properties["bets"].Items = betSchema
+	s.Properties["page"].Minimum = openapi.Float(1)
+	s.Properties["limit"] = paginationParameters()[1].Schema
+}
+
+func decorateCreateBetSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["amount"] = moneySchema(v, amountSchema())
+	s.Properties["crash_point"] = moneySchema(v, crashPointSchema())
+	s.Properties["currency"] = currencySchema()
+	s.Properties["currency"].Description = "ISO 4217 currency code of the stake; defaults to the server's `BET_DEFAULT_CURRENCY`"
+	s.Closed = v.StrictParams
+}
+
+func decorateCreateBetsSchema(s *openapi.Schema, opts SpecOptions, v APIVersion) {
+	s.Properties["mode"].Enum = []interface{}{BatchModeAllOrNothing, BatchModePerItem}
+	s.Properties["mode"].Default = BatchModeAllOrNothing
+	s.Properties["bets"].MinItems = openapi.Int(1)
+	if opts.MaxBatchBets > 0 {
+		s.Properties["bets"].MaxItems = openapi.Int(opts.MaxBatchBets)
+	}
+	s.Properties["bets"].Items = &openapi.Schema{
+		Description: "Validated individually against `" + createBetSchemaName(v) + "`; in `per_item` mode an invalid item is reported in its result instead of failing the request",
+	}
+	s.Required = []string{"bets"}
+	s.Closed = v.StrictParams
+}
+
+func decorateCreateBetsResponseSchema(s *openapi.Schema, betSchema *openapi.Schema, v APIVersion) {
+	s.Properties["mode"].Enum = []interface{}{BatchModeAllOrNothing, BatchModePerItem}
+	result := s.Properties["results"].Items
+	result.Properties["status"].Enum = []interface{}{BatchItemCreated, BatchItemRejected}
+	result.Properties["bet"] = betSchema
+	result.Properties["errors"].Description = "Validation failures of a rejected item"
+	result.Properties["code"].Description = "Error code of an item rejected by ownership, a limit, a liability cap or a fraud rule"
+	if v.DecimalStrings {
+		result.Properties["limit"] = openapi.SchemaOf(reflect.TypeOf(LimitErrorV2DTO{}))
+		result.Properties["risk"] = openapi.SchemaOf(reflect.TypeOf(RiskErrorV2DTO{}))
+	} else {
+		result.Properties["limit"] = openapi.SchemaOf(reflect.TypeOf(LimitErrorDTO{}))
+		result.Properties["risk"] = openapi.SchemaOf(reflect.TypeOf(RiskErrorDTO{}))
+	}
+}
+
+func decorateImportBetsSchema(s *openapi.Schema, opts SpecOptions, betSchema *openapi.Schema, v APIVersion) {
+	s.Properties["bets"].MinItems = openapi.Int(1)
+	if opts.MaxImportBets > 0 {
+		s.Properties["bets"].MaxItems = openapi.Int(opts.MaxImportBets)
+	}
+	s.Properties["bets"].Items = betSchema
+	s.Required = []string{"bets"}
+	s.Closed = v.StrictParams
+}
+
+func decorateVoidBetSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["reason"] = voidReasonSchema()
+	s.Properties["note"].MaxLength = openapi.Int(500)
+	s.Closed = v.StrictParams
+}
+
+func decorateAuditSchema(s *openapi.Schema, v APIVersion) {
+	entry := s.Properties["entries"].Items
+	entry.Properties["id"].Format = "uuid"
+	entry.Properties["action"].Enum = []interface{}{string(domain.AuditActionBetCancelled), string(domain.AuditActionBetVoided)}
+	entry.Properties["actor"].Description = "`key:<key id>` for authenticated callers, otherwise `anonymous`"
+	entry.Properties["refund"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(0)})
+	entry.Properties["at"].Format = "date-time"
+}
+
+func decorateRoundSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["id"].Format = "uuid"
+	s.Properties["status"].Enum = []interface{}{string(domain.RoundStatusBetting), string(domain.RoundStatusRunning), string(domain.RoundStatusCrashed)}
+	s.Properties["crash_point"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: "Multiplier the round crashed at; omitted until the round has crashed", Minimum: openapi.Float(1)})
+	s.Properties["multiplier"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: "Current multiplier, or the final one once crashed", Minimum: openapi.Float(0)})
+	s.Properties["wagered"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: "Sum of stakes placed on the round", Minimum: openapi.Float(0)})
+	s.Properties["paid_out"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: "Sum of payouts to bets that cashed out", Minimum: openapi.Float(0)})
+	s.Properties["opened_at"].Format = "date-time"
+	s.Properties["started_at"].Format = "date-time"
+	s.Properties["crashed_at"].Format = "date-time"
+}
+
+func decorateReportBucketSchema(s *openapi.Schema, totalsSchema *openapi.Schema) {
+	s.Properties["start"].Format = "date-time"
+	s.Properties["end"].Format = "date-time"
+	s.Properties["totals"] = totalsSchema
+}
+
+func decorateReportSummarySchema(s *openapi.Schema, totalsSchema, bucketSchema *openapi.Schema) {
+	s.Properties["from"].Format = "date-time"
+	s.Properties["to"].Format = "date-time"
+	s.Properties["granularity"].Enum = granularityEnum()
+	s.Properties["currency"] = currencySchema()
+	s.Properties["currency"].Description = "Currency filter the report was built with"
+	s.Properties["operator"] = operatorNameSchema()
+	s.Properties["operator"].Description = "Operator filter the report was built with"
+	s.Properties["theoretical_rtp"].Minimum = openapi.Float(0)
+	s.Properties["theoretical_rtp"].Maximum = openapi.Float(1)
+	s.Properties["totals"] = totalsSchema
+	s.Properties["buckets"].Items = bucketSchema
+}
+
+func decorateReportTotalsSchema(s *openapi.Schema, v APIVersion) {
+	money := func(description string) *openapi.Schema {
+		return moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: description, Minimum: openapi.Float(0)})
+	}
+
+	s.Properties["bets"].Description = "Bets placed, whatever their status"
+	s.Properties["players"].Description = "Distinct users who placed a bet"
+	s.Properties["refunded_bets"].Description = "Bets cancelled or voided"
+	s.Properties["turnover"] = money("Stakes of pending, won and lost bets")
+	s.Properties["settled_turnover"] = money("Stakes of won and lost bets")
+	s.Properties["payouts"] = money("Payouts of won bets")
+	s.Properties["refunds"] = money("Stakes returned by cancellation or voiding")
+	s.Properties["ggr"] = signedMoneySchema(v, "Gross gaming revenue: settled turnover minus payouts")
+	s.Properties["rtp"].Description = "Realized return to player: payouts divided by settled turnover; omitted without settled bets"
+	s.Properties["rtp"].Minimum = openapi.Float(0)
+	for _, name := range []string{"bets", "players", "refunded_bets"} {
+		s.Properties[name].Minimum = openapi.Float(0)
+	}
+}
+
+func decorateLeaderboardSchema(s *openapi.Schema, entrySchema *openapi.Schema) {
+	s.Properties["type"].Enum = leaderboardTypeEnum()
+	s.Properties["window"].Enum = leaderboardWindowEnum()
+	s.Properties["entries"].Items = entrySchema
+}
+
+func decorateLeaderboardEntrySchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["rank"].Minimum = openapi.Float(1)
+	s.Properties["user"].Description = "User ID, masked according to `LEADERBOARD_USER_MASK`"
+	s.Properties["self"].Description = "Set on the `around_user` user's own entries"
+	s.Properties["amount"] = moneySchema(v, amountSchema())
+	s.Properties["payout"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(0)})
+	multiplier := crashPointSchema()
+	multiplier.Description = "The bet's auto cash-out target"
+	s.Properties["multiplier"] = moneySchema(v, multiplier)
+	s.Properties["settled_at"].Format = "date-time"
+}
+
+func decorateSetLimitSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["amount"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(1), Maximum: openapi.Float(10000000)})
+	s.Closed = v.StrictParams
+}
+
+func decorateDepositSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["amount"] = moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Minimum: openapi.Float(0.01), Maximum: openapi.Float(10000000)})
+	s.Closed = v.StrictParams
+}
+
+func decorateCoolOffSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["days"].Minimum = openapi.Float(1)
+	s.Properties["days"].Maximum = openapi.Float(domain.MaxCoolOffDays)
+	s.Closed = v.StrictParams
+}
+
+func decorateSelfExclusionSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["duration"].Enum = exclusionDurationEnum()
+	s.Closed = v.StrictParams
+}
+
+func decorateLimitsSchema(s *openapi.Schema, v APIVersion) {
+	money := func(description string) *openapi.Schema {
+		return moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: description, Minimum: openapi.Float(0)})
+	}
+
+	s.Properties["user_id"] = userIDSchema()
+	limit := s.Properties["limits"].Items
+	limit.Properties["type"].Enum = limitTypeEnum()
+	limit.Properties["period"].Enum = limitPeriodEnum()
+	limit.Properties["amount"] = money("The limit in force")
+	limit.Properties["used"] = money("Usage in the current period")
+	limit.Properties["remaining"] = money("Amount left before the limit is reached")
+	limit.Properties["resets_at"].Format = "date-time"
+	pending := limit.Properties["pending"]
+	pending.Description = "An increase or removal waiting for `LIMITS_INCREASE_DELAY`"
+	pending.Properties["amount"] = money("The new limit, or null when the limit is being removed")
+	pending.Properties["effective_at"].Format = "date-time"
+	for _, name := range []string{"cool_off", "self_exclusion"} {
+		s.Properties[name].Properties["since"].Format = "date-time"
+		s.Properties[name].Properties["until"].Format = "date-time"
+		s.Properties[name].Properties["until"].Description = "Omitted when permanent"
+	}
+}
+
+func decorateExposureSchema(s *openapi.Schema, v APIVersion) {
+	money := func(description string) *openapi.Schema {
+		return moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: description, Minimum: openapi.Float(0)})
+	}
+	maxCrashPoint := func(description string) *openapi.Schema {
+		schema := crashPointSchema()
+		schema.Description = description
+		return moneySchema(v, schema)
+	}
+
+	s.Properties["mode"].Enum = []interface{}{string(domain.RiskModeReject), string(domain.RiskModeCap)}
+	s.Properties["round_liability"] = money("`RISK_ROUND_LIABILITY`; 0 when disabled")
+	s.Properties["user_liability"] = money("`RISK_USER_LIABILITY`; 0 when disabled")
+	s.Properties["throttle_ratio"].Minimum = openapi.Float(0)
+	s.Properties["throttle_ratio"].Maximum = openapi.Float(1)
+	s.Properties["throttled_crash_point"] = maxCrashPoint("`RISK_THROTTLED_CRASH_POINT`")
+	s.Properties["exposure"] = money("Potential payout across the listed rounds")
+
+	round := s.Properties["rounds"].Items
+	round.Properties["round_id"].Format = "uuid"
+	round.Properties["status"].Enum = []interface{}{string(domain.RoundStatusBetting), string(domain.RoundStatusRunning)}
+	round.Properties["stakes"] = money("Sum of stakes on the round")
+	round.Properties["exposure"] = money("Potential payout of the round's bets")
+	round.Properties["remaining"] = money("Exposure left before `round_liability`; omitted when disabled")
+	round.Properties["max_crash_point"] = maxCrashPoint("Highest `crash_point` accepted for new bets on the round")
+	round.Properties["throttled_at"].Format = "date-time"
+
+	user := s.Properties["top_users"].Items
+	user.Properties["user_id"] = userIDSchema()
+	user.Properties["exposure"] = money("Potential payout of the user's bets across the listed rounds")
+	user.Properties["remaining"] = money("Exposure left before `user_liability`; omitted when disabled")
+}
+
+func decorateFraudFlagSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["id"].Format = "uuid"
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["bet_id"].Format = "uuid"
+	s.Properties["bet_id"].Description = "The placed bet; omitted for held and rejected attempts"
+	s.Properties["amount"] = moneySchema(v, amountSchema())
+	s.Properties["crash_point"] = moneySchema(v, crashPointSchema())
+	s.Properties["device_id"].Description = "`X-Device-ID` sent with the bet"
+	s.Properties["score"].Minimum = openapi.Float(0)
+	s.Properties["score"].Description = "Sum of the scores of the rules hit"
+	s.Properties["action"].Enum = fraudActionEnum()
+	s.Properties["status"].Enum = flagStatusEnum()
+	s.Properties["created_at"].Format = "date-time"
+	s.Properties["reviewed_at"].Format = "date-time"
+	s.Properties["reviewed_by"].Description = "`key:<key id>` for authenticated callers, otherwise `anonymous`"
+
+	hit := s.Properties["hits"].Items
+	hit.Properties["type"].Enum = stringsToEnum(fraud.RuleTypes())
+	hit.Properties["score"].Minimum = openapi.Float(0)
+	hit.Properties["action"].Enum = fraudActionEnum()
+}
+
+func decorateReviewFraudFlagSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["status"].Enum = []interface{}{string(domain.FlagConfirmed), string(domain.FlagDismissed)}
+	s.Properties["note"].MaxLength = openapi.Int(500)
+	s.Closed = v.StrictParams
+}
+
+func decorateFraudRuleSetSchema(s *openapi.Schema) {
+	s.Properties["source"].Description = "`FRAUD_RULES_FILE`"
+	s.Properties["checksum"].Pattern = "^[0-9a-f]{64}$"
+	s.Properties["checksum"].Description = "Hex SHA-256 of the rules file"
+	s.Properties["loaded_at"].Format = "date-time"
+	for _, name := range []string{"flag", "hold", "reject"} {
+		s.Properties["thresholds"].Properties[name].Minimum = openapi.Float(0)
+		s.Properties["thresholds"].Properties[name].Description = "Total score at which a bet is given this action; 0 when unset"
+	}
+
+	rule := s.Properties["rules"].Items
+	rule.Properties["type"].Enum = stringsToEnum(fraud.RuleTypes())
+	rule.Properties["score"].Minimum = openapi.Float(0)
+	rule.Properties["action"].Enum = fraudActionEnum()
+	rule.Properties["window"].Description = "Go duration, such as `30s` or `5m`"
+}
+
+func fraudActionEnum() []interface{} {
+	actions := make([]interface{}, len(domain.FraudActions))
+	for i, action := range domain.FraudActions {
+		actions[i] = string(action)
+	}
+	return actions
+}
+
+func flagStatusEnum() []interface{} {
+	statuses := make([]interface{}, len(domain.FlagStatuses))
+	for i, status := range domain.FlagStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
+
+func limitPathParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		userIDPathParameter(),
+		{Name: "type", In: "path", Required: true, Schema: &openapi.Schema{Type: "string", Enum: limitTypeEnum()}},
+		{Name: "period", In: "path", Required: true, Schema: &openapi.Schema{Type: "string", Enum: limitPeriodEnum()}},
+	}
+}
+
+func limitTypeEnum() []interface{} {
+	values := make([]interface{}, len(domain.LimitTypes))
+	for i, t := range domain.LimitTypes {
+		values[i] = string(t)
+	}
+	return values
+}
+
+func limitPeriodEnum() []interface{} {
+	values := make([]interface{}, len(domain.LimitPeriods))
+	for i, p := range domain.LimitPeriods {
+		values[i] = string(p)
+	}
+	return values
+}
+
+func exclusionDurationEnum() []interface{} {
+	values := make([]interface{}, len(domain.ExclusionDurations))
+	for i, d := range domain.ExclusionDurations {
+		values[i] = string(d)
+	}
+	return values
+}
+
+func leaderboardTypeEnum() []interface{} {
+	values := make([]interface{}, len(domain.LeaderboardTypes))
+	for i, t := range domain.LeaderboardTypes {
+		values[i] = string(t)
+	}
+	return values
+}
+
+func leaderboardWindowEnum() []interface{} {
+	values := make([]interface{}, len(domain.LeaderboardWindows))
+	for i, w := range domain.LeaderboardWindows {
+		values[i] = string(w)
+	}
+	return values
+}
+
+func granularityEnum() []interface{} {
+	values := make([]interface{}, len(domain.ReportGranularities))
+	for i, g := range domain.ReportGranularities {
+		values[i] = string(g)
+	}
+	return values
+}
+
+func signedMoneySchema(v APIVersion, description string) *openapi.Schema {
+	if v.DecimalStrings {
+		return &openapi.Schema{Type: "string", Format: "decimal", Pattern: `^-?[0-9]+(\.[0-9]{1,2})?$`, Description: description + ", as a signed decimal string"}
+	}
+	return &openapi.Schema{Type: "number", Format: "double", Description: description}
+}
+
+func decorateUserStatsSchema(s *openapi.Schema, v APIVersion) {
+	money := func(description string) *openapi.Schema {
+		return moneySchema(v, &openapi.Schema{Type: "number", Format: "double", Description: description, Minimum: openapi.Float(0)})
+	}
+
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["from"].Format = "date"
+	s.Properties["to"].Format = "date"
+	s.Properties["total_wagered"] = money("Sum of stakes on won and lost bets")
+	s.Properties["total_paid_out"] = money("Sum of payouts on won bets")
+	s.Properties["net_pnl"] = signedMoneySchema(v, "Paid out minus wagered, from the player's side")
+	s.Properties["win_rate"].Minimum = openapi.Float(0)
+	s.Properties["win_rate"].Maximum = openapi.Float(1)
+	s.Properties["win_rate"].Description = "Won bets divided by won and lost bets"
+	s.Properties["avg_crash_point"] = money("Average auto cash-out target of won and lost bets")
+	s.Properties["max_crash_point"] = money("Highest auto cash-out target of won and lost bets")
+	s.Properties["biggest_win"] = money("Largest payout of a single won bet")
+	s.Properties["first_bet_at"].Format = "date-time"
+	s.Properties["last_bet_at"].Format = "date-time"
+	for _, name := range []string{"bets", "pending_bets", "won_bets", "lost_bets", "refunded_bets"} {
+		s.Properties[name].Minimum = openapi.Float(0)
+	}
+}
+
+func decorateAPIKeySchema(s *openapi.Schema) {
+	s.Properties["id"] = apiKeyIDPathParameter().Schema
+	s.Properties["scopes"].Items.Enum = scopeEnum()
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["user_id"].Description = "User the key acts for; omitted for operator and unbound keys"
+	s.Properties["operator"].Description = "Whether the key acts for every user"
+	s.Properties["operator_name"] = operatorNameSchema()
+	s.Properties["operator_name"].Description = "Operator the key places bets for; stamped on its bets and used by the `operator` report filter"
+	s.Properties["source"].Enum = []interface{}{string(auth.KeySourceConfig), string(auth.KeySourceAPI)}
+	s.Properties["created_at"].Format = "date-time"
+}
+
+func decorateCreateAPIKeySchema(s *openapi.Schema) {
+	s.Properties["label"].MaxLength = openapi.Int(64)
+	s.Properties["label"].Description = "Free-form note shown when listing keys"
+	s.Properties["scopes"].MinItems = openapi.Int(1)
+	s.Properties["scopes"].UniqueItems = true
+	s.Properties["scopes"].Items.Enum = scopeEnum()
+	s.Properties["user_id"] = userIDSchema()
+	s.Properties["user_id"].Description = "Binds the key to one user; it can then only act on that user's bets, limits and exclusions"
+	s.Properties["operator"].Description = "Lets the key act for every user. Keys with neither `user_id` nor `operator` cannot act on user resources unless they hold `admin`"
+	s.Properties["operator_name"] = operatorNameSchema()
+	s.Properties["operator_name"].Description = "Names the operator the key places bets for; requires `operator`. Bets placed with the key record it, and reports can be filtered by it"
+	s.Closed = true
+}
+
+func decorateSnapshotSchema(s *openapi.Schema) {
+	s.Properties["bets"].Minimum = openapi.Float(0)
+	s.Properties["bytes"].Minimum = openapi.Float(0)
+	s.Properties["checksum"].Pattern = "^[0-9a-f]{64}$"
+	s.Properties["checksum"].Description = "Hex SHA-256 of the snapshot file"
+	s.Properties["limits"].Minimum = openapi.Float(0)
+	s.Properties["limits"].Description = "Users whose responsible gambling limits, cool-off or self-exclusion are stored with the snapshot"
+	s.Properties["created_at"].Format = "date-time"
+}
+
+func scopeEnum() []interface{} {
+	scopes := make([]interface{}, len(auth.KnownScopes))
+	for i, scope := range auth.KnownScopes {
+		scopes[i] = string(scope)
+	}
+	return scopes
+}
+
+func decorateCreateWebhookSchema(s *openapi.Schema, v APIVersion) {
+	s.Properties["url"].Format = "uri"
+	s.Properties["url"].Pattern = "^https?://"
+	s.Properties["url"].MinLength = openapi.Int(1)
+	s.Properties["url"].MaxLength = openapi.Int(2048)
+	s.Properties["events"].MinItems = openapi.Int(1)
+	s.Properties["events"].Items.Enum = stringsToEnum(webhook.SupportedEvents)
+	s.Properties["secret"].MinLength = openapi.Int(16)
+	s.Properties["secret"].MaxLength = openapi.Int(256)
+	s.Closed = v.StrictParams
+}
+
+func voidReasonSchema() *openapi.Schema {
+	reasons := make([]interface{}, len(domain.VoidReasons))
+	for i, reason := range domain.VoidReasons {
+		reasons[i] = string(reason)
+	}
+	return &openapi.Schema{Type: "string", Enum: reasons}
+}
+
+func requireScope(scope auth.Scope) []map[string][]string {
+	return []map[string][]string{{bearerAuth: {string(scope)}}}
+}
+
+func optionalScope(scope auth.Scope) []map[string][]string {
+	return append([]map[string][]string{{}}, requireScope(scope)...)
+}
+
+func deviceIDHeader() *openapi.Parameter {
+	return &openapi.Parameter{
+		Name:        "X-Device-ID",
+		In:          "header",
+		Description: "Client device fingerprint, used by the `shared_device` fraud rule",
+		Schema:      &openapi.Schema{Type: "string", MaxLength: openapi.Int(128)},
+	}
+}
+
+func requestIDHeader() map[string]*openapi.Header {
+	return map[string]*openapi.Header{
+		"X-Request-ID": {Description: "Request ID, echoed from the request or generated", Schema: &openapi.Schema{Type: "string"}},
+	}
+}
+
+func userIDPathParameter() *openapi.Parameter {
+	return &openapi.Parameter{Name: "id", In: "path", Description: "User ID", Required: true, Schema: userIDSchema()}
+}
+
+func uuidPathParameter(description string) *openapi.Parameter {
+	return &openapi.Parameter{
+		Name:        "id",
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      &openapi.Schema{Type: "string", Format: "uuid", MinLength: openapi.Int(1), MaxLength: openapi.Int(36)},
+	}
+}
+
+func apiKeyIDPathParameter() *openapi.Parameter {
+	return &openapi.Parameter{
+		Name:        "id",
+		In:          "path",
+		Description: "API key ID: the first 8 hex characters of the key's SHA-256",
+		Required:    true,
+		Schema:      &openapi.Schema{Type: "string", Pattern: "^[0-9a-f]{8}$"},
+	}
+}
+
+func userIDQueryParameter() *openapi.Parameter {
+	return &openapi.Parameter{
+		Name:        "user_id",
+		In:          "query",
+		Description: "Only include bets placed by this user",
+		Schema:      userIDSchema(),
+	}
+}
+
+func betFilterParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		userIDQueryParameter(),
+		{Name: "min_amount", In: "query", Description: "Minimum bet amount (inclusive)", Schema: &openapi.Schema{Type: "number", Minimum: openapi.Float(0)}},
+		{Name: "max_amount", In: "query", Description: "Maximum bet amount (inclusive)", Schema: &openapi.Schema{Type: "number", Minimum: openapi.Float(0)}},
+	}
+}
+
+func exportParameters() []*openapi.Parameter {
+	formats := make([]interface{}, len(export.Formats))
+	for i, format := range export.Formats {
+		formats[i] = string(format)
+	}
+	compressions := make([]interface{}, len(export.Compressions))
+	for i, compression := range export.Compressions {
+		compressions[i] = string(compression)
+	}
+
+	params := []*openapi.Parameter{
+		{Name: "format", In: "query", Schema: &openapi.Schema{Type: "string", Enum: formats, Default: defaultExportFormat}},
+		{Name: "compression", In: "query", Schema: &openapi.Schema{Type: "string", Enum: compressions, Default: defaultExportCompression}},
+	}
+	params = append(params, betFilterParameters()...)
+
+	sortBy, order := *paginationParameters()[2], *paginationParameters()[3]
+	orderSchema := *order.Schema
+	orderSchema.Default = defaultExportOrder
+	order.Schema = &orderSchema
+
+	return append(params, &sortBy, &order)
+}
+
+func exportHeaders() map[string]*openapi.Header {
+	headers := requestIDHeader()
+	headers["Content-Disposition"] = &openapi.Header{Description: "Suggested file name", Schema: &openapi.Schema{Type: "string"}}
+	headers["Trailer"] = &openapi.Header{Description: "Announces the `" + headerChecksum + "` and `" + headerExportRows + "` trailers", Schema: &openapi.Schema{Type: "string"}}
+	headers[headerChecksum] = &openapi.Header{Description: "Trailer: hex SHA-256 of the body", Schema: &openapi.Schema{Type: "string", Pattern: "^[0-9a-f]{64}$"}}
+	headers[headerExportRows] = &openapi.Header{Description: "Trailer: number of exported bets", Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(0)}}
+	return headers
+}
+
+func paginationParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		{Name: "page", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(10000), Default: defaultPage}},
+		{Name: "limit", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: openapi.Float(1), Maximum: openapi.Float(100), Default: defaultPageLimit}},
+		{Name: "sort_by", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"amount", "created_at"}, Default: defaultSortBy}},
+		{Name: "order", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"asc", "desc"}, Default: defaultOrder}},
+	}
+}
+
+func stringsToEnum(values []string) []interface{} {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func RecoveryMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				metrics.PanicsRecovered.Add(1)
+
+				stack := debug.Stack()
+
+				span := trace.SpanFromContext(r.Context())
+				span.RecordError(fmt.Errorf("panic: %v", rec))
+				span.SetStatus(codes.Error, "panic recovered")
+
+				logger.Error("panic recovered",
+					zap.String("request_id", middleware.GetRequestID(r.Context())),
+					zap.String("trace_id", middleware.GetTraceID(r.Context())),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Any("panic", rec),
+					zap.ByteString("stack", stack),
+				)
+
+				sendErrorResponse(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred", logger)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
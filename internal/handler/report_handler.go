@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const defaultGranularity = domain.GranularityDay
+
+type ReportHandler struct {
+	service   service.ReportServiceUseCase
+	validator validator.AdminValidator
+	logger    *zap.Logger
+}
+
+func NewReportHandler(service service.ReportServiceUseCase, validator validator.AdminValidator, logger *zap.Logger) *ReportHandler {
+	return &ReportHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *ReportHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "ReportHandler.Summary")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	query := r.URL.Query()
+	from := query.Get("from")
+	to := query.Get("to")
+	granularity := string(defaultGranularity)
+	if value := query.Get("granularity"); value != "" {
+		granularity = sanitizeQueryParam(value)
+	}
+	currency := query.Get("currency")
+	operator := query.Get("operator")
+
+	span.SetAttributes(
+		attribute.String("report.from", from),
+		attribute.String("report.to", to),
+		attribute.String("report.granularity", granularity),
+		attribute.String("report.currency", currency),
+		attribute.String("report.operator", operator),
+	)
+
+	if err := h.validator.ValidateReport(from, to, granularity, currency, operator); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	req := domain.ReportRequest{
+		Granularity: domain.ReportGranularity(granularity),
+		Currency:    currency,
+		Operator:    operator,
+	}
+	req.From, _ = time.Parse(time.RFC3339, from)
+	req.To, _ = time.Parse(time.RFC3339, to)
+
+	report, err := h.service.Summary(r.Context(), req)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, reportSummaryResponse(versionOf(r), report), h.logger)
+}
@@ -16,36 +16,45 @@ func sanitizeQueryParam(s string) string {
 	return s
 }
 
-func validateQueryParam(s string, allowedValues []string) bool {
-	if len(allowedValues) == 0 {
-		return true
-	}
-	s = strings.ToLower(strings.TrimSpace(s))
-	for _, allowed := range allowedValues {
-		if strings.ToLower(allowed) == s {
-			return true
-		}
-	}
-	return false
-}
-
 func ParseListBetsRequest(r *http.Request) domain.ListBetsRequest {
-	page := 1
+	page := defaultPage
 	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		pageStr = sanitizeQueryParam(pageStr)
-		if parsed, err := strconv.Atoi(pageStr); err == nil && parsed > 0 && parsed <= 10000 {
+		if parsed, err := strconv.Atoi(sanitizeQueryParam(pageStr)); err == nil {
 			page = parsed
 		}
 	}
 
-	limit := 10
+	limit := defaultPageLimit
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		limitStr = sanitizeQueryParam(limitStr)
-		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 && parsed <= 100 {
+		if parsed, err := strconv.Atoi(sanitizeQueryParam(limitStr)); err == nil {
 			limit = parsed
 		}
 	}
 
+	sortBy := defaultSortBy
+	if sortByStr := r.URL.Query().Get("sort_by"); sortByStr != "" {
+		sortBy = sanitizeQueryParam(sortByStr)
+	}
+
+	order := defaultOrder
+	if orderStr := r.URL.Query().Get("order"); orderStr != "" {
+		order = sanitizeQueryParam(orderStr)
+	}
+
+	return domain.ListBetsRequest{
+		Filters: ParseBetFilters(r),
+		Pagination: domain.PaginationParams{
+			Page:  page,
+			Limit: limit,
+		},
+		Sort: domain.SortParams{
+			SortBy: sortBy,
+			Order:  order,
+		},
+	}
+}
+
+func ParseBetFilters(r *http.Request) domain.BetFilters {
 	var filters domain.BetFilters
 
 	if userIDStr := r.URL.Query().Get("user_id"); userIDStr != "" {
@@ -69,37 +78,5 @@ func ParseListBetsRequest(r *http.Request) domain.ListBetsRequest {
 		}
 	}
 
-	sortBy := r.URL.Query().Get("sort_by")
-	if sortBy == "" {
-		sortBy = "created_at"
-	} else {
-		sortBy = sanitizeQueryParam(sortBy)
-		allowedSortBy := []string{"amount", "created_at"}
-		if !validateQueryParam(sortBy, allowedSortBy) {
-			sortBy = "created_at"
-		}
-	}
-
-	order := r.URL.Query().Get("order")
-	if order == "" {
-		order = "desc"
-	} else {
-		order = sanitizeQueryParam(order)
-		allowedOrder := []string{"asc", "desc"}
-		if !validateQueryParam(order, allowedOrder) {
-			order = "desc"
-		}
-	}
-
-	return domain.ListBetsRequest{
-		Filters: filters,
-		Pagination: domain.PaginationParams{
-			Page:  page,
-			Limit: limit,
-		},
-		Sort: domain.SortParams{
-			SortBy: sortBy,
-			Order:  order,
-		},
-	}
+	return filters
 }
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"bet/internal/service"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type RiskHandler struct {
+	service service.RiskServiceUseCase
+	logger  *zap.Logger
+}
+
+func NewRiskHandler(service service.RiskServiceUseCase, logger *zap.Logger) *RiskHandler {
+	return &RiskHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RiskHandler) GetExposure(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "RiskHandler.GetExposure")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	report, err := h.service.GetExposure(r.Context())
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Float64("risk.exposure", report.Exposure))
+
+	sendJSON(w, http.StatusOK, exposureResponse(versionOf(r), report), h.logger)
+}
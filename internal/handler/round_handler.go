@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const defaultRoundLimit = 20
+
+type RoundHandler struct {
+	service   service.RoundServiceUseCase
+	validator validator.AdminValidator
+	logger    *zap.Logger
+}
+
+func NewRoundHandler(service service.RoundServiceUseCase, validator validator.AdminValidator, logger *zap.Logger) *RoundHandler {
+	return &RoundHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *RoundHandler) ListRounds(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "RoundHandler.ListRounds")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	limit := defaultRoundLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(sanitizeQueryParam(limitStr))
+		if err != nil {
+			parsed = 0
+		}
+		limit = parsed
+	}
+
+	if err := h.validator.ValidateRoundsLimit(limit); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	rounds, err := h.service.ListRounds(r.Context(), limit)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("rounds.result_count", len(rounds)))
+
+	sendJSON(w, http.StatusOK, listRoundsResponse(versionOf(r), rounds), h.logger)
+}
+
+func (h *RoundHandler) GetRound(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "RoundHandler.GetRound")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("round.id", id))
+
+	if err := h.validator.ValidateRoundID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	round, err := h.service.GetRound(r.Context(), id)
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	sendJSON(w, http.StatusOK, roundResponse(versionOf(r), round), h.logger)
+}
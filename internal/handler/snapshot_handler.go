@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/middleware"
+	"bet/internal/snapshot"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type SnapshotHandler struct {
+	snapshots *snapshot.Manager
+	logger    *zap.Logger
+}
+
+func NewSnapshotHandler(snapshots *snapshot.Manager, logger *zap.Logger) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshots: snapshots,
+		logger:    logger,
+	}
+}
+
+func (h *SnapshotHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "SnapshotHandler.ListSnapshots")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	if h.snapshots == nil {
+		handleError(w, r, domain.ErrSnapshotsDisabled, h.logger)
+		return
+	}
+
+	snapshots, err := h.snapshots.List()
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("snapshots.count", len(snapshots)))
+
+	sendJSON(w, http.StatusOK, ListSnapshotsResponseDTO{Snapshots: snapshotDTOs(snapshots)}, h.logger)
+}
+
+func (h *SnapshotHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "SnapshotHandler.CreateSnapshot")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	if h.snapshots == nil {
+		handleError(w, r, domain.ErrSnapshotsDisabled, h.logger)
+		return
+	}
+
+	snapshot, err := h.snapshots.Create(r.Context())
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("snapshot.id", snapshot.ID), attribute.Int("snapshot.bets", snapshot.Bets))
+	h.logger.Info("snapshot created",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("actor", actorFrom(r).ID),
+		zap.String("snapshot_id", snapshot.ID),
+		zap.Int("bets", snapshot.Bets),
+	)
+
+	sendJSON(w, http.StatusCreated, SnapshotDTOFromDomain(snapshot), h.logger)
+}
+
+func (h *SnapshotHandler) CompactSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "SnapshotHandler.CompactSnapshots")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	if h.snapshots == nil {
+		handleError(w, r, domain.ErrSnapshotsDisabled, h.logger)
+		return
+	}
+
+	result, err := h.snapshots.Compact()
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("snapshots.removed", len(result.Removed)))
+	h.logger.Info("snapshots compacted",
+		zap.String("request_id", middleware.GetRequestID(r.Context())),
+		zap.String("actor", actorFrom(r).ID),
+		zap.Int("removed", len(result.Removed)),
+		zap.Int("kept", result.Kept),
+	)
+
+	sendJSON(w, http.StatusOK, CompactSnapshotsResponseDTO{
+		Removed: snapshotDTOs(result.Removed),
+		Kept:    result.Kept,
+	}, h.logger)
+}
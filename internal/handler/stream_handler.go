@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bet/internal/middleware"
+	"bet/internal/sse"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const sseRetryMillis = 3000
+
+type StreamHandler struct {
+	broker    *sse.Broker
+	keepAlive time.Duration
+	writeWait time.Duration
+	logger    *zap.Logger
+}
+
+func NewStreamHandler(broker *sse.Broker, keepAlive time.Duration, logger *zap.Logger) *StreamHandler {
+	return &StreamHandler{
+		broker:    broker,
+		keepAlive: keepAlive,
+		writeWait: 2 * keepAlive,
+		logger:    logger,
+	}
+}
+
+func (h *StreamHandler) StreamBets(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+	filters := ParseBetFilters(r)
+	version := versionOf(r)
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = sanitizeQueryParam(r.URL.Query().Get("last_event_id"))
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sub, replay := h.broker.Subscribe(lastEventID)
+	defer h.broker.Unsubscribe(sub)
+
+	h.logger.Info("bet stream opened",
+		zap.String("request_id", requestID),
+		zap.String("last_event_id", lastEventID),
+		zap.Int("replayed", len(replay)),
+	)
+
+	write := func(format string, args ...interface{}) bool {
+		rc.SetWriteDeadline(time.Now().Add(h.writeWait))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	writeEvent := func(ev sse.Event) bool {
+		if !filters.Matches(ev.Bet) {
+			return true
+		}
+
+		data, err := json.Marshal(betResponse(version, &ev.Bet))
+		if err != nil {
+			h.logger.Error("failed to encode stream event", zap.String("request_id", requestID), zap.Error(err))
+			return true
+		}
+
+		return write("id: %s\nevent: bet.created\ndata: %s\n\n", ev.ID, data)
+	}
+
+	if !write("retry: %d\n\n", sseRetryMillis) {
+		return
+	}
+
+	for _, ev := range replay {
+		if !writeEvent(ev) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-sub.Done():
+			return
+		case ev := <-sub.C:
+			if !writeEvent(ev) {
+				return
+			}
+		case <-ticker.C:
+			if !write(": keep-alive\n\n") {
+				return
+			}
+		}
+	}
+}
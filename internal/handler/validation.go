@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bet/internal/middleware"
+	"bet/internal/openapi"
+	"bet/internal/validator"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+func RequestValidationMiddleware(doc *openapi.Document, method, path string, logger *zap.Logger) func(http.Handler) http.Handler {
+	op := doc.Operation(method, path)
+
+	return func(next http.Handler) http.Handler {
+		if op == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			errs := validateParameters(doc, op, r)
+
+			if op.RequestBody != nil {
+				bodyErrs, err := validateBody(doc, op.RequestBody, r, logger)
+				if err != nil {
+					metrics.RequestValidationFailures.Add(1)
+					handleError(w, r, err, logger)
+					return
+				}
+				errs = append(errs, bodyErrs...)
+			}
+
+			if len(errs) > 0 {
+				metrics.RequestValidationFailures.Add(1)
+				logger.Debug("request failed schema validation",
+					zap.String("request_id", middleware.GetRequestID(r.Context())),
+					zap.String("operation", op.OperationID),
+					zap.Int("violations", len(errs)),
+				)
+				handleError(w, r, validator.FromFieldErrors(errs), logger)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func validateParameters(doc *openapi.Document, op *openapi.Operation, r *http.Request) []openapi.FieldError {
+	var errs []openapi.FieldError
+	query := r.URL.Query()
+
+	for _, p := range op.Parameters {
+		var raw string
+		switch p.In {
+		case "path":
+			raw = r.PathValue(p.Name)
+		case "query":
+			raw = strings.TrimSpace(query.Get(p.Name))
+		default:
+			continue
+		}
+
+		if raw == "" && p.In == "query" {
+			if p.Required {
+				errs = append(errs, openapi.FieldError{Field: p.Name, Message: p.Name + " is required"})
+			}
+			continue
+		}
+
+		errs = append(errs, doc.ValidateParameter(p, raw)...)
+	}
+
+	if versionOf(r).StrictParams {
+		names := make([]string, 0, len(query))
+		for name := range query {
+			if op.Parameter("query", name) == nil {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			errs = append(errs, openapi.FieldError{Field: name, Message: name + " is not a supported query parameter"})
+		}
+	}
+
+	return errs
+}
+
+func validateBody(doc *openapi.Document, body *openapi.RequestBody, r *http.Request, logger *zap.Logger) ([]openapi.FieldError, error) {
+	media, ok := body.Content["application/json"]
+	if !ok {
+		return nil, nil
+	}
+
+	raw, err := readJSONBody(r, logger)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, &domain.ValidationError{
+			Field:   "body",
+			Message: "invalid request body",
+		}
+	}
+
+	return doc.Validate("", media.Schema, payload), nil
+}
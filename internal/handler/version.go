@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type APIVersion struct {
+	Name           string
+	StrictParams   bool
+	DecimalStrings bool
+	ProblemJSON    bool
+}
+
+var (
+	V1 = APIVersion{Name: "v1"}
+	V2 = APIVersion{Name: "v2", StrictParams: true, DecimalStrings: true, ProblemJSON: true}
+
+	APIVersions = []APIVersion{V1, V2}
+)
+
+type versionKey struct{}
+
+func (v APIVersion) Prefix() string {
+	return "/" + v.Name
+}
+
+func WithAPIVersion(v APIVersion) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionKey{}, v)))
+		})
+	}
+}
+
+func versionOf(r *http.Request) APIVersion {
+	if v, ok := r.Context().Value(versionKey{}).(APIVersion); ok {
+		return v
+	}
+
+	for _, v := range APIVersions {
+		if strings.HasPrefix(r.URL.Path, v.Prefix()+"/") {
+			return v
+		}
+	}
+	return V1
+}
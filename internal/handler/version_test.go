@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bet/internal/domain"
+	"bet/internal/middleware"
+	"bet/internal/service"
+	"bet/internal/validator"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type lookupService struct {
+	service.BetServiceUseCase
+	bets map[string]*domain.Bet
+}
+
+func (s *lookupService) GetBetByID(ctx context.Context, id string) (*domain.Bet, error) {
+	if bet, ok := s.bets[id]; ok {
+		return bet, nil
+	}
+	return nil, domain.ErrBetNotFound
+}
+
+func TestAPIVersionsAndDeprecatedAliases(t *testing.T) {
+	bet := domain.NewBet(7, 12.5, 2.25)
+	missing := domain.NewBet(7, 1, 2).ID
+
+	h := NewBetHandler(&lookupService{bets: map[string]*domain.Bet{bet.ID: bet}}, validator.NewBetValidator(OpenAPISpec(SpecOptions{})), zap.NewNop())
+	deprecation := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	deprecated := middleware.DeprecationMiddleware(middleware.DeprecationConfig{
+		Deprecation:     deprecation,
+		Sunset:          sunset,
+		SuccessorPrefix: V1.Prefix(),
+	})
+
+	mux := http.NewServeMux()
+	for _, v := range APIVersions {
+		mux.Handle("GET "+v.Prefix()+"/bets/{id}", WithAPIVersion(v)(http.HandlerFunc(h.GetBet)))
+	}
+	mux.Handle("GET /bets/{id}", deprecated(WithAPIVersion(V1)(http.HandlerFunc(h.GetBet))))
+
+	tests := []struct {
+		name        string
+		path        string
+		status      int
+		contentType string
+		deprecated  bool
+		decimals    bool
+	}{
+		{name: "v1", path: "/v1/bets/" + bet.ID, status: http.StatusOK, contentType: "application/json"},
+		{name: "v2", path: "/v2/bets/" + bet.ID, status: http.StatusOK, contentType: "application/json", decimals: true},
+		{name: "unversioned alias", path: "/bets/" + bet.ID, status: http.StatusOK, contentType: "application/json", deprecated: true},
+		{name: "v1 not found", path: "/v1/bets/" + missing, status: http.StatusNotFound, contentType: "application/json"},
+		{name: "v2 not found", path: "/v2/bets/" + missing, status: http.StatusNotFound, contentType: "application/problem+json"},
+		{name: "unversioned not found", path: "/bets/" + missing, status: http.StatusNotFound, contentType: "application/json", deprecated: true},
+		{name: "v2 invalid id", path: "/v2/bets/nope", status: http.StatusBadRequest, contentType: "application/problem+json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+
+			header := rec.Header()
+			if tt.deprecated {
+				if got, want := header.Get("Deprecation"), "@1767225600"; got != want {
+					t.Errorf("Deprecation = %q, want %q", got, want)
+				}
+				if got, want := header.Get("Sunset"), "Thu, 31 Dec 2026 00:00:00 GMT"; got != want {
+					t.Errorf("Sunset = %q, want %q", got, want)
+				}
+				if got, want := header.Get("Link"), "</v1"+tt.path+">; rel=\"successor-version\""; got != want {
+					t.Errorf("Link = %q, want %q", got, want)
+				}
+			} else {
+				for _, name := range []string{"Deprecation", "Sunset", "Link"} {
+					if got := header.Get(name); got != "" {
+						t.Errorf("%s = %q on a versioned path", name, got)
+					}
+				}
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body %q: %v", rec.Body, err)
+			}
+
+			if tt.status != http.StatusOK {
+				if tt.contentType == "application/problem+json" {
+					if body["status"] != float64(tt.status) || body["title"] != http.StatusText(tt.status) || body["code"] == nil {
+						t.Errorf("problem = %v, want status, title and code", body)
+					}
+				} else if body["error"] == nil || body["code"] == nil || body["status"] != nil {
+					t.Errorf("error body = %v, want the v1 error and code fields", body)
+				}
+				return
+			}
+
+			if tt.decimals {
+				if body["amount"] != "12.50" || body["crash_point"] != "2.25" {
+					t.Errorf("amount, crash_point = %#v, %#v, want decimal strings", body["amount"], body["crash_point"])
+				}
+			} else if body["amount"] != 12.5 || body["crash_point"] != 2.25 {
+				t.Errorf("amount, crash_point = %#v, %#v, want numbers", body["amount"], body["crash_point"])
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bet/internal/service"
+	"bet/internal/validator"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	service   service.WebhookServiceUseCase
+	validator validator.WebhookValidator
+	logger    *zap.Logger
+}
+
+func NewWebhookHandler(service service.WebhookServiceUseCase, validator validator.WebhookValidator, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "WebhookHandler.CreateWebhook")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	var req CreateWebhookRequest
+
+	if err := decodeJSONBody(r, &req, h.logger); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	if err := h.validator.ValidateCreateRequest(req.URL, req.Events, req.Secret); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), req.URL, req.Events, req.Secret, actorFrom(r))
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.String("webhook.id", webhook.ID))
+
+	sendJSON(w, http.StatusCreated, WebhookDTOFromDomain(webhook), h.logger)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "WebhookHandler.ListDeliveries")
+	defer span.End()
+	r = r.WithContext(ctx)
+
+	id := r.PathValue("id")
+	span.SetAttributes(attribute.String("webhook.id", id))
+
+	if err := h.validator.ValidateWebhookID(id); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	limit := defaultDeliveryLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(sanitizeQueryParam(limitStr))
+		if err != nil {
+			parsed = 0
+		}
+		limit = parsed
+	}
+
+	if err := h.validator.ValidateDeliveriesLimit(limit); err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), id, limit, actorFrom(r))
+	if err != nil {
+		handleError(w, r, err, h.logger)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("deliveries.result_count", len(deliveries)))
+
+	sendJSON(w, http.StatusOK, ListWebhookDeliveriesResponseDTOFromDomain(id, deliveries), h.logger)
+}
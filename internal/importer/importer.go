@@ -0,0 +1,336 @@
+package importer
+
+import (
+	"bet/internal/domain"
+	"bet/internal/validator"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	defaultBatchSize        = 500
+	defaultProgressInterval = 2 * time.Second
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type Sink interface {
+	ImportBets(ctx context.Context, bets []domain.Bet) (domain.ImportResult, error)
+}
+
+type RejectedItemsError struct {
+	Items map[int][]FieldError
+}
+
+func (e *RejectedItemsError) Error() string {
+	return fmt.Sprintf("%d items rejected by the server", len(e.Items))
+}
+
+type Config struct {
+	Path             string
+	Format           Format
+	BatchSize        int
+	RejectsPath      string
+	CheckpointPath   string
+	Restart          bool
+	DryRun           bool
+	Progress         io.Writer
+	ProgressInterval time.Duration
+}
+
+type Stats struct {
+	Line       int
+	Offset     int64
+	Size       int64
+	Imported   int
+	Duplicates int
+	Rejected   int
+	Resumed    bool
+	Completed  bool
+	UpToDate   bool
+}
+
+type reject struct {
+	Line   int          `json:"line"`
+	Record interface{}  `json:"record"`
+	Errors []FieldError `json:"errors"`
+}
+
+type Importer struct {
+	config    Config
+	validator validator.BetValidator
+	sink      Sink
+}
+
+func New(config Config, validator validator.BetValidator, sink Sink) *Importer {
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.ProgressInterval <= 0 {
+		config.ProgressInterval = defaultProgressInterval
+	}
+	if config.Progress == nil {
+		config.Progress = io.Discard
+	}
+	if config.RejectsPath == "" {
+		config.RejectsPath = config.Path + ".rejects.ndjson"
+	}
+	if config.CheckpointPath == "" {
+		config.CheckpointPath = config.Path + ".checkpoint.json"
+	}
+
+	return &Importer{
+		config:    config,
+		validator: validator,
+		sink:      sink,
+	}
+}
+
+func (im *Importer) Run(ctx context.Context) (Stats, error) {
+	source, err := filepath.Abs(im.config.Path)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	checkpoint, err := im.loadCheckpoint(source, info.Size())
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{
+		Line:       checkpoint.Line,
+		Offset:     checkpoint.Offset,
+		Size:       info.Size(),
+		Imported:   checkpoint.Imported,
+		Duplicates: checkpoint.Duplicates,
+		Rejected:   checkpoint.Rejected,
+		Resumed:    checkpoint.Offset > 0,
+		Completed:  checkpoint.Completed,
+		UpToDate:   checkpoint.Completed,
+	}
+	if checkpoint.Completed {
+		return stats, nil
+	}
+
+	rows, err := newReader(im.config.Format, file, checkpoint.Offset, checkpoint.Line)
+	if err != nil {
+		return stats, err
+	}
+
+	rejectsFlags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if !stats.Resumed {
+		rejectsFlags |= os.O_TRUNC
+	}
+	rejects, err := os.OpenFile(im.config.RejectsPath, rejectsFlags, 0o644)
+	if err != nil {
+		return stats, err
+	}
+	defer rejects.Close()
+
+	var batch []record
+	var pending []reject
+	lastProgress := time.Now()
+
+	commit := func(last record) error {
+		if len(batch) > 0 {
+			imported, duplicates, rejected, err := im.send(ctx, batch)
+			if err != nil {
+				return err
+			}
+			stats.Imported += imported
+			stats.Duplicates += duplicates
+			pending = append(pending, rejected...)
+		}
+
+		if err := writeRejects(rejects, pending); err != nil {
+			return err
+		}
+		stats.Rejected += len(pending)
+		stats.Line = last.line
+		stats.Offset = last.offset
+
+		checkpoint.Offset = stats.Offset
+		checkpoint.Line = stats.Line
+		checkpoint.Imported = stats.Imported
+		checkpoint.Duplicates = stats.Duplicates
+		checkpoint.Rejected = stats.Rejected
+		if !im.config.DryRun {
+			if err := checkpoint.Save(im.config.CheckpointPath); err != nil {
+				return fmt.Errorf("save checkpoint: %w", err)
+			}
+		}
+
+		batch, pending = batch[:0], pending[:0]
+
+		if time.Since(lastProgress) >= im.config.ProgressInterval {
+			im.report(stats)
+			lastProgress = time.Now()
+		}
+		return nil
+	}
+
+	var last record
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		rec, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("line %d: %w", last.line+1, err)
+		}
+		last = rec
+
+		if len(rec.errs) == 0 {
+			rec.errs = fieldErrors(im.validator.ValidateImportedBet(rec.bet))
+		}
+		if len(rec.errs) > 0 {
+			pending = append(pending, reject{Line: rec.line, Record: rec.raw, Errors: rec.errs})
+		} else {
+			batch = append(batch, rec)
+		}
+
+		if len(batch) >= im.config.BatchSize || len(pending) >= im.config.BatchSize {
+			if err := commit(rec); err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	if last.offset > 0 {
+		if err := commit(last); err != nil {
+			return stats, err
+		}
+	}
+
+	stats.Completed = true
+	checkpoint.Completed = true
+	if !im.config.DryRun {
+		if err := checkpoint.Save(im.config.CheckpointPath); err != nil {
+			return stats, fmt.Errorf("save checkpoint: %w", err)
+		}
+	}
+	im.report(stats)
+
+	return stats, nil
+}
+
+func (im *Importer) loadCheckpoint(source string, size int64) (*Checkpoint, error) {
+	fresh := &Checkpoint{Source: source, Format: im.config.Format}
+	if im.config.Restart || im.config.DryRun {
+		return fresh, nil
+	}
+
+	checkpoint, err := LoadCheckpoint(im.config.CheckpointPath)
+	if err != nil || checkpoint == nil {
+		return fresh, err
+	}
+
+	if checkpoint.Source != source || checkpoint.Format != im.config.Format {
+		return nil, fmt.Errorf("checkpoint %s belongs to %s (%s); use --restart to start over", im.config.CheckpointPath, checkpoint.Source, checkpoint.Format)
+	}
+	if checkpoint.Offset > size {
+		return nil, fmt.Errorf("checkpoint %s is past the end of %s; the file has changed, use --restart to start over", im.config.CheckpointPath, source)
+	}
+
+	return checkpoint, nil
+}
+
+func (im *Importer) send(ctx context.Context, batch []record) (imported, duplicates int, rejected []reject, err error) {
+	if im.config.DryRun {
+		return len(batch), 0, nil, nil
+	}
+
+	for len(batch) > 0 {
+		bets := make([]domain.Bet, len(batch))
+		for i, rec := range batch {
+			bets[i] = rec.bet
+		}
+
+		result, err := im.sink.ImportBets(ctx, bets)
+		if err == nil {
+			return result.Imported, len(result.Duplicates), rejected, nil
+		}
+
+		var itemsErr *RejectedItemsError
+		if !errors.As(err, &itemsErr) || len(itemsErr.Items) == 0 {
+			return 0, 0, nil, fmt.Errorf("import batch ending at line %d: %w", batch[len(batch)-1].line, err)
+		}
+
+		indexes := make([]int, 0, len(itemsErr.Items))
+		for index := range itemsErr.Items {
+			if index < 0 || index >= len(batch) {
+				return 0, 0, nil, fmt.Errorf("import batch ending at line %d: %w", batch[len(batch)-1].line, err)
+			}
+			indexes = append(indexes, index)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+
+		for _, index := range indexes {
+			rec := batch[index]
+			rejected = append(rejected, reject{Line: rec.line, Record: rec.raw, Errors: itemsErr.Items[index]})
+			batch = slices.Delete(batch, index, index+1)
+		}
+	}
+
+	return 0, 0, rejected, nil
+}
+
+func (im *Importer) report(stats Stats) {
+	percent := 100.0
+	if stats.Size > 0 {
+		percent = float64(stats.Offset) / float64(stats.Size) * 100
+	}
+	fmt.Fprintf(im.config.Progress, "line %d (%.1f%%): imported %d, duplicates %d, rejected %d\n",
+		stats.Line, percent, stats.Imported, stats.Duplicates, stats.Rejected)
+}
+
+func writeRejects(w *os.File, rejects []reject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+
+	sort.Slice(rejects, func(i, j int) bool { return rejects[i].Line < rejects[j].Line })
+
+	encoder := json.NewEncoder(w)
+	for _, r := range rejects {
+		if err := encoder.Encode(r); err != nil {
+			return fmt.Errorf("write rejects: %w", err)
+		}
+	}
+	return w.Sync()
+}
+
+func fieldErrors(err error) []FieldError {
+	violations := domain.FieldViolations(err)
+	errs := make([]FieldError, len(violations))
+	for i, v := range violations {
+		errs[i] = FieldError{Field: v.Field, Message: v.Message}
+	}
+	return errs
+}
@@ -0,0 +1,183 @@
+package importer
+
+import (
+	"bet/internal/domain"
+	"bet/internal/export"
+	"bet/internal/handler"
+	"bet/internal/validator"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memorySink struct {
+	mu       sync.Mutex
+	bets     map[string]domain.Bet
+	sent     map[string]int
+	calls    int
+	failCall int
+	failErr  error
+	store    bool
+	cancel   context.CancelFunc
+}
+
+func (s *memorySink) ImportBets(ctx context.Context, bets []domain.Bet) (domain.ImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	failing := s.calls == s.failCall
+	if failing && !s.store {
+		return domain.ImportResult{}, s.failErr
+	}
+
+	var result domain.ImportResult
+	for _, bet := range bets {
+		s.sent[bet.ID]++
+		if _, exists := s.bets[bet.ID]; exists {
+			result.Duplicates = append(result.Duplicates, bet.ID)
+			continue
+		}
+		s.bets[bet.ID] = bet
+		result.Imported++
+	}
+
+	if failing {
+		if s.cancel != nil {
+			s.cancel()
+		}
+		return domain.ImportResult{}, s.failErr
+	}
+	return result, nil
+}
+
+func writeExport(t *testing.T, path string, format export.Format, n int) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	w, err := export.NewWriter(file, export.Config{Format: format})
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		bet := domain.NewBet(int64(i%5+1), 10, 2)
+		bet.CreatedAt = createdAt.Add(time.Duration(i) * time.Second)
+		settledAt := bet.CreatedAt.Add(time.Minute)
+		bet.Status, bet.SettledAt = domain.BetStatusLost, &settledAt
+		if err := w.Write(*bet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImporterResumesFromCheckpoint(t *testing.T) {
+	const (
+		total     = 23
+		batchSize = 5
+		failCall  = 3
+	)
+	betValidator := validator.NewBetValidator(handler.OpenAPISpec(handler.SpecOptions{}))
+
+	tests := []struct {
+		name       string
+		store      bool
+		cancel     bool
+		imported   int
+		duplicates int
+	}{
+		{name: "batch failed before the server stored it", imported: total},
+		{name: "response lost after the server stored the batch", store: true, imported: total - batchSize, duplicates: batchSize},
+		{name: "cancelled mid batch", store: true, cancel: true, imported: total - batchSize, duplicates: batchSize},
+	}
+
+	for _, format := range []export.Format{export.FormatCSV, export.FormatNDJSON} {
+		for _, tt := range tests {
+			t.Run(string(format)+"/"+tt.name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "bets."+string(format))
+				writeExport(t, path, format, total)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				errInterrupted := errors.New("connection reset")
+				sink := &memorySink{
+					bets:     make(map[string]domain.Bet),
+					sent:     make(map[string]int),
+					failCall: failCall,
+					failErr:  errInterrupted,
+					store:    tt.store,
+				}
+				if tt.cancel {
+					sink.cancel = cancel
+					sink.failErr = context.Canceled
+				}
+				config := Config{Path: path, Format: Format(format), BatchSize: batchSize}
+
+				stats, err := New(config, betValidator, sink).Run(ctx)
+				if !errors.Is(err, sink.failErr) {
+					t.Fatalf("first run: error = %v, want %v", err, sink.failErr)
+				}
+				if stats.Completed || stats.Imported != (failCall-1)*batchSize {
+					t.Fatalf("first run: imported %d, completed %v, want %d and not completed", stats.Imported, stats.Completed, (failCall-1)*batchSize)
+				}
+
+				checkpoint, err := LoadCheckpoint(config.Path + ".checkpoint.json")
+				if err != nil || checkpoint == nil {
+					t.Fatalf("LoadCheckpoint: %v, %v", checkpoint, err)
+				}
+				if checkpoint.Imported != (failCall-1)*batchSize || checkpoint.Completed {
+					t.Fatalf("checkpoint = %+v, want %d imported and not completed", checkpoint, (failCall-1)*batchSize)
+				}
+
+				sink.failCall = 0
+				stats, err = New(config, betValidator, sink).Run(context.Background())
+				if err != nil {
+					t.Fatalf("resumed run: %v", err)
+				}
+				if !stats.Resumed || !stats.Completed {
+					t.Fatalf("resumed run: resumed %v, completed %v, want both", stats.Resumed, stats.Completed)
+				}
+				if stats.Imported != tt.imported || stats.Duplicates != tt.duplicates || stats.Rejected != 0 {
+					t.Fatalf("resumed run: imported %d, duplicates %d, rejected %d, want %d, %d, 0", stats.Imported, stats.Duplicates, stats.Rejected, tt.imported, tt.duplicates)
+				}
+
+				if len(sink.bets) != total {
+					t.Fatalf("server holds %d bets, want %d", len(sink.bets), total)
+				}
+				resent := 0
+				for id, n := range sink.sent {
+					if n > 1 {
+						resent++
+						if n > 2 {
+							t.Errorf("bet %s was sent %d times", id, n)
+						}
+					}
+				}
+				if want := tt.duplicates; resent != want {
+					t.Errorf("%d bets were sent again after resuming, want only the %d from the interrupted batch", resent, want)
+				}
+
+				stats, err = New(config, betValidator, sink).Run(context.Background())
+				if err != nil || !stats.UpToDate {
+					t.Fatalf("third run: up to date %v, error %v, want an up-to-date no-op", stats.UpToDate, err)
+				}
+				if len(sink.bets) != total {
+					t.Fatalf("server holds %d bets after a third run, want %d", len(sink.bets), total)
+				}
+			})
+		}
+	}
+}
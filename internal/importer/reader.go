@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"bet/internal/domain"
+	"bet/internal/export"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+var requiredColumns = []string{"id", "user_id", "amount", "crash_point", "status", "payout", "created_at"}
+
+type record struct {
+	line   int
+	offset int64
+	raw    interface{}
+	bet    domain.Bet
+	errs   []FieldError
+}
+
+type reader interface {
+	next() (record, error)
+}
+
+func DetectFormat(path string) (Format, error) {
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return FormatCSV, nil
+	case strings.HasSuffix(path, ".ndjson"), strings.HasSuffix(path, ".jsonl"):
+		return FormatNDJSON, nil
+	}
+	return "", fmt.Errorf("cannot detect format of %s; pass --format csv or ndjson", path)
+}
+
+func newReader(format Format, file *os.File, offset int64, line int) (reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(file, offset, line)
+	case FormatNDJSON:
+		return newNDJSONReader(file, offset, line)
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+type csvReader struct {
+	r        *csv.Reader
+	columns  []string
+	base     int64
+	baseLine int
+}
+
+func newCSVReader(file *os.File, offset int64, line int) (*csvReader, error) {
+	header := csv.NewReader(file)
+	columns, err := header.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv file is empty; expected a header row")
+		}
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if !slices.Contains(export.Columns, columns[i]) {
+			return nil, fmt.Errorf("unknown csv column %q; expected columns %s", columns[i], strings.Join(export.Columns, ","))
+		}
+	}
+	for _, required := range requiredColumns {
+		if !slices.Contains(columns, required) {
+			return nil, fmt.Errorf("csv header is missing required column %q", required)
+		}
+	}
+
+	if offset == 0 {
+		offset = header.InputOffset()
+		line = 1
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	r := csv.NewReader(bufio.NewReader(file))
+	r.FieldsPerRecord = len(columns)
+
+	return &csvReader{r: r, columns: columns, base: offset, baseLine: line}, nil
+}
+
+func (c *csvReader) next() (record, error) {
+	fields, err := c.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && !errors.Is(err, csv.ErrQuote) && !errors.Is(err, csv.ErrBareQuote) {
+			return record{
+				line:   c.baseLine + parseErr.StartLine,
+				offset: c.base + c.r.InputOffset(),
+				raw:    strings.Join(fields, ","),
+				errs:   []FieldError{{Field: "row", Message: parseErr.Err.Error()}},
+			}, nil
+		}
+		return record{}, err
+	}
+
+	line, _ := c.r.FieldPos(0)
+	rec := record{
+		line:   c.baseLine + line,
+		offset: c.base + c.r.InputOffset(),
+	}
+
+	values := make(map[string]string, len(c.columns))
+	for i, column := range c.columns {
+		values[column] = fields[i]
+	}
+	rec.raw = values
+	rec.bet, rec.errs = betFromValues(values)
+
+	return rec, nil
+}
+
+func betFromValues(values map[string]string) (domain.Bet, []FieldError) {
+	var errs []FieldError
+	bet := domain.Bet{
+		ID:         strings.TrimSpace(values["id"]),
+		RoundID:    strings.TrimSpace(values["round_id"]),
+		Status:     domain.BetStatus(strings.TrimSpace(values["status"])),
+		VoidReason: domain.VoidReason(strings.TrimSpace(values["void_reason"])),
+		Currency:   strings.TrimSpace(values["currency"]),
+		Operator:   strings.TrimSpace(values["operator"]),
+	}
+
+	parseFloat := func(field string) float64 {
+		v, err := strconv.ParseFloat(strings.TrimSpace(values[field]), 64)
+		if err != nil {
+			errs = append(errs, FieldError{Field: field, Message: field + " must be a number"})
+		}
+		return v
+	}
+	parseTime := func(field string) *time.Time {
+		raw := strings.TrimSpace(values[field])
+		if raw == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			errs = append(errs, FieldError{Field: field, Message: field + " must be an RFC 3339 date-time"})
+			return nil
+		}
+		return &t
+	}
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(values["user_id"]), 10, 64)
+	if err != nil {
+		errs = append(errs, FieldError{Field: "user_id", Message: "user_id must be an integer"})
+	}
+	bet.UserID = userID
+	bet.Amount = parseFloat("amount")
+	bet.CrashPoint = parseFloat("crash_point")
+	bet.Payout = parseFloat("payout")
+	if createdAt := parseTime("created_at"); createdAt != nil {
+		bet.CreatedAt = *createdAt
+	}
+	bet.SettledAt = parseTime("settled_at")
+
+	return bet, errs
+}
+
+type ndjsonReader struct {
+	r      *bufio.Reader
+	offset int64
+	line   int
+}
+
+func newNDJSONReader(file *os.File, offset int64, line int) (*ndjsonReader, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &ndjsonReader{r: bufio.NewReader(file), offset: offset, line: line}, nil
+}
+
+func (n *ndjsonReader) next() (record, error) {
+	for {
+		data, err := n.r.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return record{}, err
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return record{}, err
+		}
+
+		n.offset += int64(len(data))
+		n.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		rec := record{line: n.line, offset: n.offset}
+		if !json.Valid(data) {
+			rec.raw = string(data)
+			rec.errs = []FieldError{{Field: "row", Message: "row must be a JSON object"}}
+			return rec, nil
+		}
+		rec.raw = json.RawMessage(data)
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		var row export.Row
+		if err := decoder.Decode(&row); err != nil {
+			rec.errs = []FieldError{{Field: "row", Message: "row is not a valid bet: " + err.Error()}}
+			return rec, nil
+		}
+
+		rec.bet = row.Bet()
+		return rec, nil
+	}
+}
@@ -0,0 +1,20 @@
+package leaderboard
+
+type expiryHeap []*entry
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool { return h[i].settledAt.Before(h[j].settledAt) }
+
+func (h expiryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(*entry)) }
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
@@ -0,0 +1,273 @@
+package leaderboard
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/metrics"
+	"container/heap"
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+const allTimeCapacity = 1000
+
+type Config struct {
+	UserMask   string
+	MaskSecret string
+	Now        func() time.Time
+}
+
+type entry struct {
+	betID      string
+	userID     int64
+	amount     float64
+	payout     float64
+	multiplier float64
+	settledAt  time.Time
+	refs       int
+}
+
+func newEntry(bet domain.Bet) *entry {
+	settledAt := bet.CreatedAt
+	if bet.SettledAt != nil {
+		settledAt = *bet.SettledAt
+	}
+	return &entry{
+		betID:      bet.ID,
+		userID:     bet.UserID,
+		amount:     bet.Amount,
+		payout:     bet.Payout,
+		multiplier: bet.CrashPoint,
+		settledAt:  settledAt.UTC(),
+	}
+}
+
+type window struct {
+	span     time.Duration
+	capacity int
+	boards   map[domain.LeaderboardType]*skipList
+	expiry   expiryHeap
+}
+
+type Manager struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	byUser  map[int64]map[string]*entry
+	windows map[domain.LeaderboardWindow]*window
+	mask    func(int64) string
+	now     func() time.Time
+}
+
+func NewManager(config Config) *Manager {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	m := &Manager{
+		entries: make(map[string]*entry),
+		byUser:  make(map[int64]map[string]*entry),
+		windows: make(map[domain.LeaderboardWindow]*window),
+		mask:    newMasker(config.UserMask, config.MaskSecret),
+		now:     config.Now,
+	}
+	for _, w := range domain.LeaderboardWindows {
+		win := &window{
+			span:   w.Span(),
+			boards: make(map[domain.LeaderboardType]*skipList),
+		}
+		if win.span == 0 {
+			win.capacity = allTimeCapacity
+		}
+		for _, t := range domain.LeaderboardTypes {
+			win.boards[t] = newSkipList(scoreFunc(t))
+		}
+		m.windows[w] = win
+	}
+	return m
+}
+
+func scoreFunc(t domain.LeaderboardType) func(*entry) float64 {
+	if t == domain.LeaderboardMultipliers {
+		return func(e *entry) float64 { return e.multiplier }
+	}
+	return func(e *entry) float64 { return e.payout }
+}
+
+func (m *Manager) Seed(bets iter.Seq2[domain.Bet, error]) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	seeded := 0
+	for bet, err := range bets {
+		if err != nil {
+			return seeded, err
+		}
+		if m.addLocked(bet, now) {
+			seeded++
+		}
+	}
+	metrics.LeaderboardEntries.Set(int64(len(m.entries)))
+	return seeded, nil
+}
+
+func (m *Manager) HandleEvent(ctx context.Context, record events.OutboxRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch e := record.Event.(type) {
+	case events.BetSettled:
+		m.addLocked(e.Bet, m.now())
+	case events.BetVoided:
+		m.removeLocked(e.Bet.ID)
+	}
+	metrics.LeaderboardEntries.Set(int64(len(m.entries)))
+	return nil
+}
+
+func (m *Manager) Leaderboard(req domain.LeaderboardRequest) domain.Leaderboard {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expireLocked(m.now())
+
+	board := domain.Leaderboard{LeaderboardRequest: req, Entries: []domain.LeaderboardEntry{}}
+	win, ok := m.windows[req.Window]
+	if !ok {
+		return board
+	}
+	list, ok := win.boards[req.Type]
+	if !ok {
+		return board
+	}
+
+	entries, first := list.top(req.Limit), 1
+	if req.AroundUser != 0 {
+		entries, first = nil, 0
+		if best := m.bestLocked(list, req.AroundUser); best != nil {
+			entries, first = list.around(best, req.Limit)
+		}
+	}
+
+	for i, e := range entries {
+		board.Entries = append(board.Entries, domain.LeaderboardEntry{
+			Rank:       first + i,
+			User:       m.mask(e.userID),
+			Self:       req.AroundUser != 0 && e.userID == req.AroundUser,
+			Amount:     e.amount,
+			Payout:     e.payout,
+			Multiplier: e.multiplier,
+			SettledAt:  e.settledAt,
+		})
+	}
+	return board
+}
+
+func (m *Manager) bestLocked(list *skipList, userID int64) *entry {
+	var best *entry
+	for _, e := range m.byUser[userID] {
+		if (best == nil || list.less(e, best)) && list.rank(e) > 0 {
+			best = e
+		}
+	}
+	return best
+}
+
+func (m *Manager) addLocked(bet domain.Bet, now time.Time) bool {
+	if bet.Status != domain.BetStatusWon {
+		return false
+	}
+	if _, exists := m.entries[bet.ID]; exists {
+		return false
+	}
+
+	e := newEntry(bet)
+	m.trackLocked(e)
+	for _, win := range m.windows {
+		if win.span > 0 {
+			if !e.settledAt.After(now.Add(-win.span)) {
+				continue
+			}
+			heap.Push(&win.expiry, e)
+		}
+		for _, list := range win.boards {
+			list.insert(e)
+			e.refs++
+			if win.capacity > 0 && list.length > win.capacity {
+				dropped := list.last()
+				list.remove(dropped)
+				if dropped == e {
+					e.refs--
+				} else {
+					m.releaseLocked(dropped)
+				}
+			}
+		}
+	}
+
+	if e.refs == 0 {
+		m.forgetLocked(e)
+		return false
+	}
+	m.expireLocked(now)
+	return true
+}
+
+func (m *Manager) removeLocked(betID string) {
+	e, exists := m.entries[betID]
+	if !exists {
+		return
+	}
+	for _, win := range m.windows {
+		for _, list := range win.boards {
+			list.remove(e)
+		}
+	}
+	m.forgetLocked(e)
+}
+
+func (m *Manager) expireLocked(now time.Time) {
+	for _, win := range m.windows {
+		if win.span == 0 {
+			continue
+		}
+		cutoff := now.Add(-win.span)
+		for win.expiry.Len() > 0 && !win.expiry[0].settledAt.After(cutoff) {
+			e := heap.Pop(&win.expiry).(*entry)
+			for _, list := range win.boards {
+				if list.remove(e) {
+					m.releaseLocked(e)
+				}
+			}
+		}
+	}
+}
+
+func (m *Manager) releaseLocked(e *entry) {
+	e.refs--
+	if e.refs == 0 && m.entries[e.betID] == e {
+		m.forgetLocked(e)
+	}
+}
+
+func (m *Manager) trackLocked(e *entry) {
+	m.entries[e.betID] = e
+	bets, ok := m.byUser[e.userID]
+	if !ok {
+		bets = make(map[string]*entry)
+		m.byUser[e.userID] = bets
+	}
+	bets[e.betID] = e
+}
+
+func (m *Manager) forgetLocked(e *entry) {
+	delete(m.entries, e.betID)
+	if bets, ok := m.byUser[e.userID]; ok {
+		delete(bets, e.betID)
+		if len(bets) == 0 {
+			delete(m.byUser, e.userID)
+		}
+	}
+}
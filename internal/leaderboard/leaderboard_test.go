@@ -0,0 +1,214 @@
+package leaderboard
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func settle(t *testing.T, m *Manager, id string, userID int64, payout float64, settledAt time.Time) {
+	t.Helper()
+	bet := domain.Bet{
+		ID:         id,
+		UserID:     userID,
+		Amount:     10,
+		CrashPoint: payout / 10,
+		Payout:     payout,
+		Status:     domain.BetStatusWon,
+		CreatedAt:  settledAt,
+		SettledAt:  &settledAt,
+	}
+	if err := m.HandleEvent(context.Background(), events.OutboxRecord{Event: events.BetSettled{Bet: bet}}); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+}
+
+func boardUsers(board domain.Leaderboard) []string {
+	users := make([]string, len(board.Entries))
+	for i, e := range board.Entries {
+		users[i] = e.User
+	}
+	return users
+}
+
+func TestLeaderboardWindowExpiry(t *testing.T) {
+	c := &clock{now: base}
+	m := NewManager(Config{UserMask: MaskNone, Now: c.Now})
+
+	settle(t, m, "fresh", 1, 100, base.Add(-time.Hour))
+	settle(t, m, "yesterday", 2, 300, base.Add(-30*time.Hour))
+	settle(t, m, "last-week", 3, 500, base.Add(-8*24*time.Hour))
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    map[domain.LeaderboardWindow][]string
+	}{
+		{
+			name: "now",
+			want: map[domain.LeaderboardWindow][]string{
+				domain.LeaderboardDay:  {"1"},
+				domain.LeaderboardWeek: {"2", "1"},
+				domain.LeaderboardAll:  {"3", "2", "1"},
+			},
+		},
+		{
+			name:    "a day later",
+			advance: 23 * time.Hour,
+			want: map[domain.LeaderboardWindow][]string{
+				domain.LeaderboardDay:  {},
+				domain.LeaderboardWeek: {"2", "1"},
+				domain.LeaderboardAll:  {"3", "2", "1"},
+			},
+		},
+		{
+			name:    "a week later",
+			advance: 6 * 24 * time.Hour,
+			want: map[domain.LeaderboardWindow][]string{
+				domain.LeaderboardDay:  {},
+				domain.LeaderboardWeek: {},
+				domain.LeaderboardAll:  {"3", "2", "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.now = c.now.Add(tt.advance)
+			for window, want := range tt.want {
+				for _, boardType := range domain.LeaderboardTypes {
+					board := m.Leaderboard(domain.LeaderboardRequest{Type: boardType, Window: window, Limit: 10})
+					if got := boardUsers(board); !slices.Equal(got, want) {
+						t.Errorf("%s/%s = %v, want %v", boardType, window, got, want)
+					}
+				}
+			}
+		})
+	}
+
+	if len(m.entries) != 3 {
+		t.Fatalf("entries = %d, want 3 kept by the all-time boards", len(m.entries))
+	}
+}
+
+func TestLeaderboardQueries(t *testing.T) {
+	m := NewManager(Config{UserMask: MaskNone, Now: func() time.Time { return base }})
+
+	payouts := []float64{90, 80, 70, 60, 50, 40, 30, 20, 10}
+	for i, payout := range payouts {
+		settle(t, m, string(rune('a'+i)), int64(i+1), payout, base.Add(-time.Duration(i+1)*time.Minute))
+	}
+	settle(t, m, "tie", 4, 60, base.Add(-time.Second))
+	settle(t, m, "second", 7, 25, base.Add(-time.Second))
+
+	tests := []struct {
+		name       string
+		limit      int
+		aroundUser int64
+		users      []string
+		ranks      []int
+		self       []bool
+	}{
+		{
+			name:  "top n",
+			limit: 3,
+			users: []string{"1", "2", "3"},
+			ranks: []int{1, 2, 3},
+		},
+		{
+			name:  "ties keep the earlier settlement first",
+			limit: 6,
+			users: []string{"1", "2", "3", "4", "4", "5"},
+			ranks: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:       "around a user",
+			limit:      3,
+			aroundUser: 6,
+			users:      []string{"5", "6", "7"},
+			ranks:      []int{6, 7, 8},
+			self:       []bool{false, true, false},
+		},
+		{
+			name:       "around a user with several bets uses the best one",
+			limit:      3,
+			aroundUser: 4,
+			users:      []string{"3", "4", "4"},
+			ranks:      []int{3, 4, 5},
+			self:       []bool{false, true, true},
+		},
+		{
+			name:       "around the leader",
+			limit:      3,
+			aroundUser: 1,
+			users:      []string{"1", "2", "3"},
+			ranks:      []int{1, 2, 3},
+			self:       []bool{true, false, false},
+		},
+		{
+			name:       "around the last",
+			limit:      4,
+			aroundUser: 9,
+			users:      []string{"7", "7", "8", "9"},
+			ranks:      []int{8, 9, 10, 11},
+			self:       []bool{false, false, false, true},
+		},
+		{
+			name:       "user without a bet",
+			limit:      3,
+			aroundUser: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := m.Leaderboard(domain.LeaderboardRequest{Type: domain.LeaderboardWins, Window: domain.LeaderboardDay, Limit: tt.limit, AroundUser: tt.aroundUser})
+
+			if got := boardUsers(board); !slices.Equal(got, tt.users) {
+				t.Fatalf("users = %v, want %v", got, tt.users)
+			}
+			for i, e := range board.Entries {
+				if e.Rank != tt.ranks[i] {
+					t.Errorf("entry %d rank = %d, want %d", i, e.Rank, tt.ranks[i])
+				}
+				if want := tt.self != nil && tt.self[i]; e.Self != want {
+					t.Errorf("entry %d self = %v, want %v", i, e.Self, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderboardVoidRemovesEntries(t *testing.T) {
+	m := NewManager(Config{UserMask: MaskNone, Now: func() time.Time { return base }})
+	settle(t, m, "a", 1, 30, base.Add(-time.Minute))
+	settle(t, m, "b", 2, 20, base.Add(-time.Minute))
+	settle(t, m, "c", 3, 10, base.Add(-time.Minute))
+
+	voided := domain.Bet{ID: "b", UserID: 2, Status: domain.BetStatusVoided}
+	if err := m.HandleEvent(context.Background(), events.OutboxRecord{Event: events.BetVoided{Bet: voided}}); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+
+	for _, window := range domain.LeaderboardWindows {
+		board := m.Leaderboard(domain.LeaderboardRequest{Type: domain.LeaderboardWins, Window: window, Limit: 10})
+		if got := boardUsers(board); !slices.Equal(got, []string{"1", "3"}) {
+			t.Errorf("%s = %v, want [1 3]", window, got)
+		}
+		if board.Entries[1].Rank != 2 {
+			t.Errorf("%s: user 3 rank = %d, want 2", window, board.Entries[1].Rank)
+		}
+	}
+
+	board := m.Leaderboard(domain.LeaderboardRequest{Type: domain.LeaderboardWins, Window: domain.LeaderboardDay, Limit: 3, AroundUser: 2})
+	if len(board.Entries) != 0 {
+		t.Fatalf("around a voided user = %v, want no entries", boardUsers(board))
+	}
+}
@@ -0,0 +1,47 @@
+package leaderboard
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaskNone    = "none"
+	MaskPartial = "partial"
+	MaskHash    = "hash"
+
+	maskVisibleDigits = 3
+	maskHashLength    = 10
+)
+
+func newMasker(mode, secret string) func(int64) string {
+	switch mode {
+	case MaskNone:
+		return func(userID int64) string {
+			return strconv.FormatInt(userID, 10)
+		}
+	case MaskHash:
+		key := []byte(secret)
+		if len(key) == 0 {
+			key = make([]byte, sha256.Size)
+			rand.Read(key)
+		}
+		return func(userID int64) string {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(strconv.AppendInt(nil, userID, 10))
+			return "player-" + hex.EncodeToString(mac.Sum(nil))[:maskHashLength]
+		}
+	default:
+		return maskPartial
+	}
+}
+
+func maskPartial(userID int64) string {
+	digits := strconv.FormatInt(userID, 10)
+	visible := min(maskVisibleDigits, len(digits)/2)
+	return strings.Repeat("*", max(len(digits)-visible, 3)) + digits[len(digits)-visible:]
+}
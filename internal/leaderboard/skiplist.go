@@ -0,0 +1,189 @@
+package leaderboard
+
+import "math/rand/v2"
+
+const (
+	maxLevel    = 24
+	levelFactor = 4
+)
+
+type skipNode struct {
+	entry *entry
+	prev  *skipNode
+	next  []*skipNode
+	span  []int
+}
+
+type skipList struct {
+	score  func(*entry) float64
+	head   *skipNode
+	tail   *skipNode
+	level  int
+	length int
+}
+
+func newSkipList(score func(*entry) float64) *skipList {
+	return &skipList{
+		score: score,
+		head:  &skipNode{next: make([]*skipNode, maxLevel), span: make([]int, maxLevel)},
+		level: 1,
+	}
+}
+
+func (l *skipList) less(a, b *entry) bool {
+	if sa, sb := l.score(a), l.score(b); sa != sb {
+		return sa > sb
+	}
+	if !a.settledAt.Equal(b.settledAt) {
+		return a.settledAt.Before(b.settledAt)
+	}
+	return a.betID < b.betID
+}
+
+func (l *skipList) insert(e *entry) {
+	var update [maxLevel]*skipNode
+	var rank [maxLevel]int
+	node := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for node.next[i] != nil && l.less(node.next[i].entry, e) {
+			rank[i] += node.span[i]
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+
+	level := randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			l.head.span[i] = l.length
+		}
+		l.level = level
+	}
+
+	inserted := &skipNode{entry: e, next: make([]*skipNode, level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		inserted.next[i] = update[i].next[i]
+		update[i].next[i] = inserted
+		inserted.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < l.level; i++ {
+		update[i].span[i]++
+	}
+	if update[0] != l.head {
+		inserted.prev = update[0]
+	}
+	if inserted.next[0] != nil {
+		inserted.next[0].prev = inserted
+	} else {
+		l.tail = inserted
+	}
+	l.length++
+}
+
+func (l *skipList) remove(e *entry) bool {
+	var update [maxLevel]*skipNode
+	node := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && l.less(node.next[i].entry, e) {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+
+	target := node.next[0]
+	if target == nil || target.entry != e {
+		return false
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i] == target {
+			update[i].span[i] += target.span[i] - 1
+			update[i].next[i] = target.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	if target.next[0] != nil {
+		target.next[0].prev = target.prev
+	} else {
+		l.tail = target.prev
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.length--
+	return true
+}
+
+func (l *skipList) last() *entry {
+	if l.tail == nil {
+		return nil
+	}
+	return l.tail.entry
+}
+
+func (l *skipList) top(n int) []*entry {
+	entries := make([]*entry, 0, min(n, l.length))
+	for node := l.head.next[0]; node != nil && len(entries) < n; node = node.next[0] {
+		entries = append(entries, node.entry)
+	}
+	return entries
+}
+
+func (l *skipList) find(e *entry) (*skipNode, int) {
+	rank := 0
+	node := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && l.less(node.next[i].entry, e) {
+			rank += node.span[i]
+			node = node.next[i]
+		}
+	}
+
+	target := node.next[0]
+	if target == nil || target.entry != e {
+		return nil, 0
+	}
+	return target, rank + 1
+}
+
+func (l *skipList) rank(e *entry) int {
+	_, rank := l.find(e)
+	return rank
+}
+
+func (l *skipList) around(e *entry, n int) ([]*entry, int) {
+	node, rank := l.find(e)
+	if node == nil || n <= 0 {
+		return nil, 0
+	}
+
+	first := node
+	for i := 0; i < (n-1)/2 && first.prev != nil; i++ {
+		first = first.prev
+		rank--
+	}
+
+	entries := make([]*entry, 0, min(n, l.length))
+	for cur := first; cur != nil && len(entries) < n; cur = cur.next[0] {
+		entries = append(entries, cur.entry)
+	}
+	for cur := first.prev; cur != nil && len(entries) < n; cur = cur.prev {
+		entries = append([]*entry{cur.entry}, entries...)
+		rank--
+	}
+	return entries, rank
+}
+
+func randomLevel() int {
+	level := 1
+	for level < maxLevel && rand.IntN(levelFactor) == 0 {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,194 @@
+package leaderboard
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func testEntry(id string, payout float64, settledAfter time.Duration) *entry {
+	return &entry{betID: id, payout: payout, settledAt: base.Add(settledAfter)}
+}
+
+func payoutScore(e *entry) float64 { return e.payout }
+
+func ids(entries []*entry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.betID
+	}
+	return out
+}
+
+func checkRanks(t *testing.T, list *skipList, want []string) {
+	t.Helper()
+
+	if got := ids(list.top(len(want) + 1)); !slices.Equal(got, want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+	if list.length != len(want) {
+		t.Fatalf("length = %d, want %d", list.length, len(want))
+	}
+	for node, rank := list.head.next[0], 1; node != nil; node, rank = node.next[0], rank+1 {
+		if got := list.rank(node.entry); got != rank {
+			t.Fatalf("rank(%s) = %d, want %d", node.entry.betID, got, rank)
+		}
+	}
+	if len(want) > 0 && list.last().betID != want[len(want)-1] {
+		t.Fatalf("last = %s, want %s", list.last().betID, want[len(want)-1])
+	}
+}
+
+func TestSkipListRanksTies(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []*entry
+		want    []string
+	}{
+		{
+			name: "higher score first",
+			entries: []*entry{
+				testEntry("a", 10, 0),
+				testEntry("b", 30, 0),
+				testEntry("c", 20, 0),
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name: "earlier settlement wins a tie",
+			entries: []*entry{
+				testEntry("late", 50, 2*time.Minute),
+				testEntry("early", 50, time.Minute),
+				testEntry("top", 80, 3*time.Minute),
+			},
+			want: []string{"top", "early", "late"},
+		},
+		{
+			name: "bet id breaks a full tie",
+			entries: []*entry{
+				testEntry("c", 50, 0),
+				testEntry("a", 50, 0),
+				testEntry("b", 50, 0),
+			},
+			want: []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := newSkipList(payoutScore)
+			for _, e := range tt.entries {
+				list.insert(e)
+			}
+			checkRanks(t, list, tt.want)
+		})
+	}
+}
+
+func TestSkipListRanksMatchSortedOrder(t *testing.T) {
+	list := newSkipList(payoutScore)
+	var entries []*entry
+	for i := 0; i < 2000; i++ {
+		e := testEntry(fmt.Sprintf("bet-%04d", i), float64(rand.IntN(50)), time.Duration(rand.IntN(10))*time.Second)
+		entries = append(entries, e)
+		list.insert(e)
+	}
+	for _, e := range entries[:500] {
+		if !list.remove(e) {
+			t.Fatalf("remove(%s) = false", e.betID)
+		}
+	}
+	entries = entries[500:]
+
+	slices.SortFunc(entries, func(a, b *entry) int {
+		if list.less(a, b) {
+			return -1
+		}
+		return 1
+	})
+	checkRanks(t, list, ids(entries))
+}
+
+func TestSkipListUpdatesMoveEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		payout float64
+		rank   int
+		want   []string
+	}{
+		{name: "up to the top", id: "d", payout: 100, rank: 1, want: []string{"d", "a", "b", "c", "e"}},
+		{name: "up one place", id: "c", payout: 45, rank: 2, want: []string{"a", "c", "b", "d", "e"}},
+		{name: "down to the bottom", id: "a", payout: 1, rank: 5, want: []string{"b", "c", "d", "e", "a"}},
+		{name: "down into a tie", id: "b", payout: 20, rank: 3, want: []string{"a", "c", "b", "d", "e"}},
+		{name: "same score", id: "c", payout: 30, rank: 3, want: []string{"a", "b", "c", "d", "e"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := newSkipList(payoutScore)
+			byID := make(map[string]*entry)
+			for i, id := range []string{"a", "b", "c", "d", "e"} {
+				e := testEntry(id, float64(50-10*i), time.Duration(i)*time.Minute)
+				byID[id] = e
+				list.insert(e)
+			}
+
+			e := byID[tt.id]
+			if !list.remove(e) {
+				t.Fatalf("remove(%s) = false", tt.id)
+			}
+			e.payout = tt.payout
+			list.insert(e)
+
+			checkRanks(t, list, tt.want)
+			if got := list.rank(e); got != tt.rank {
+				t.Fatalf("rank(%s) = %d, want %d", tt.id, got, tt.rank)
+			}
+		})
+	}
+}
+
+func TestSkipListAround(t *testing.T) {
+	list := newSkipList(payoutScore)
+	byID := make(map[string]*entry)
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("r%02d", i+1)
+		e := testEntry(id, float64(100-i), 0)
+		byID[id] = e
+		list.insert(e)
+	}
+
+	tests := []struct {
+		name  string
+		id    string
+		n     int
+		first int
+		want  []string
+	}{
+		{name: "middle", id: "r05", n: 3, first: 4, want: []string{"r04", "r05", "r06"}},
+		{name: "even window", id: "r05", n: 4, first: 4, want: []string{"r04", "r05", "r06", "r07"}},
+		{name: "top", id: "r01", n: 3, first: 1, want: []string{"r01", "r02", "r03"}},
+		{name: "bottom", id: "r10", n: 3, first: 8, want: []string{"r08", "r09", "r10"}},
+		{name: "near the bottom", id: "r09", n: 5, first: 6, want: []string{"r06", "r07", "r08", "r09", "r10"}},
+		{name: "larger than the board", id: "r03", n: 20, first: 1, want: ids(list.top(10))},
+		{name: "single", id: "r07", n: 1, first: 7, want: []string{"r07"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, first := list.around(byID[tt.id], tt.n)
+			if first != tt.first || !slices.Equal(ids(entries), tt.want) {
+				t.Fatalf("around(%s, %d) = %v from rank %d, want %v from rank %d", tt.id, tt.n, ids(entries), first, tt.want, tt.first)
+			}
+		})
+	}
+
+	if entries, first := list.around(testEntry("missing", 50, 0), 3); entries != nil || first != 0 {
+		t.Fatalf("around(missing) = %v from rank %d, want nothing", ids(entries), first)
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import "expvar"
+
+var (
+	PanicsRecovered           = expvar.NewInt("panics_recovered_total")
+	RequestTimeouts           = expvar.NewInt("http_request_timeouts_total")
+	ClientDisconnects         = expvar.NewInt("http_client_disconnects_total")
+	RequestValidationFailures = expvar.NewInt("http_request_validation_failures_total")
+	BetsCancelled             = expvar.NewInt("bets_cancelled_total")
+	BetsVoided                = expvar.NewInt("bets_voided_total")
+	BetBatches                = expvar.NewInt("bet_batches_total")
+	BetBatchItemsRejected     = expvar.NewInt("bet_batch_items_rejected_total")
+	BetExports                = expvar.NewInt("bet_exports_total")
+	BetExportRows             = expvar.NewInt("bet_export_rows_total")
+	BetExportsAborted         = expvar.NewInt("bet_exports_aborted_total")
+	BetsImported              = expvar.NewInt("bets_imported_total")
+	BetSettlementRetries      = expvar.NewInt("bet_settlement_retries_total")
+	BetsUnsettled             = expvar.NewInt("bets_unsettled")
+	SnapshotsCreated          = expvar.NewInt("snapshots_created_total")
+	SnapshotFailures          = expvar.NewInt("snapshot_failures_total")
+	SnapshotsRemoved          = expvar.NewInt("snapshots_removed_total")
+	FeedConnections           = expvar.NewInt("feed_connections")
+	FeedDroppedTicks          = expvar.NewInt("feed_dropped_ticks_total")
+	FeedSlowConsumers         = expvar.NewInt("feed_slow_consumers_total")
+	EventsPublished           = expvar.NewInt("events_published_total")
+	EventDeliveryRetries      = expvar.NewInt("events_delivery_retries_total")
+	EventsDeadLettered        = expvar.NewInt("events_dead_lettered_total")
+	EventDeliveriesDeferred   = expvar.NewInt("events_deliveries_deferred_total")
+	WebhookDeliveries         = expvar.NewInt("webhook_deliveries_total")
+	WebhookFailures           = expvar.NewInt("webhook_failures_total")
+	WebhooksDisabled          = expvar.NewInt("webhooks_disabled_total")
+	BrokerPublished           = expvar.NewInt("broker_published_total")
+	BrokerPublishFailures     = expvar.NewInt("broker_publish_failures_total")
+	BrokerBuffered            = expvar.NewInt("broker_buffered")
+	BrokerDeadLettered        = expvar.NewInt("broker_dead_lettered_total")
+	LeaderboardEntries        = expvar.NewInt("leaderboard_entries")
+	RiskBetsRejected          = expvar.NewInt("risk_bets_rejected_total")
+	RiskBetsCapped            = expvar.NewInt("risk_bets_capped_total")
+	RiskRoundsThrottled       = expvar.NewInt("risk_rounds_throttled_total")
+	FraudBetsFlagged          = expvar.NewInt("fraud_bets_flagged_total")
+	FraudBetsHeld             = expvar.NewInt("fraud_bets_held_total")
+	FraudBetsDeclined         = expvar.NewInt("fraud_bets_declined_total")
+	FraudRuleReloads          = expvar.NewInt("fraud_rule_reloads_total")
+	FraudRuleReloadFailures   = expvar.NewInt("fraud_rule_reload_failures_total")
+)
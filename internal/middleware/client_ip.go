@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+var clientIPKeyValue = clientIPKey{}
+
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+func NewClientIPResolver(trustedProxies []netip.Prefix) *ClientIPResolver {
+	return &ClientIPResolver{trusted: trustedProxies}
+}
+
+func (c *ClientIPResolver) Resolve(remoteAddr string, forwardedFor []string, realIP string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if !c.isTrusted(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, header := range forwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	if len(hops) == 0 {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(realIP)); err == nil {
+			return addr.Unmap().String()
+		}
+		return ip
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			break
+		}
+		ip = addr.Unmap().String()
+		if !c.isTrusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func (c *ClientIPResolver) isTrusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func ClientIPMiddleware(resolver *ClientIPResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolver.Resolve(r.RemoteAddr, r.Header.Values("X-Forwarded-For"), r.Header.Get("X-Real-IP"))
+			next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), ip)))
+		})
+	}
+}
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKeyValue, ip)
+}
+
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKeyValue).(string); ok {
+		return ip
+	}
+	return ""
+}
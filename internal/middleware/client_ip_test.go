@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIPResolverResolve(t *testing.T) {
+	resolver := NewClientIPResolver([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.10/32"),
+	})
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		want         string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:5123",
+			want:       "203.0.113.7",
+		},
+		{
+			name:         "untrusted peer cannot forward",
+			remoteAddr:   "203.0.113.7:5123",
+			forwardedFor: []string{"198.51.100.1"},
+			realIP:       "198.51.100.2",
+			want:         "203.0.113.7",
+		},
+		{
+			name:         "trusted peer forwards the client",
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "spoofed leftmost entry is skipped",
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "trusted hops are skipped",
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"1.1.1.1, 198.51.100.1, 192.168.1.10", "10.9.9.9"},
+			want:         "198.51.100.1",
+		},
+		{
+			name:         "all hops trusted",
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"10.0.0.5"},
+			want:         "10.0.0.5",
+		},
+		{
+			name:         "malformed hop stops the walk",
+			remoteAddr:   "10.1.2.3:443",
+			forwardedFor: []string{"198.51.100.1, not-an-ip"},
+			want:         "10.1.2.3",
+		},
+		{
+			name:       "trusted peer sets X-Real-IP",
+			remoteAddr: "10.1.2.3:443",
+			realIP:     "198.51.100.1",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "IPv6 peer",
+			remoteAddr: "[2001:db8::1]:443",
+			want:       "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.Resolve(tt.remoteAddr, tt.forwardedFor, tt.realIP); got != tt.want {
+				t.Errorf("Resolve = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPMiddlewareStoresResolvedIP(t *testing.T) {
+	var got string
+	h := ClientIPMiddleware(NewClientIPResolver(nil))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = GetClientIP(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/bets", nil)
+	r.RemoteAddr = "203.0.113.7:5123"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if got != "203.0.113.7" {
+		t.Fatalf("client IP = %q, want the peer address without trusted proxies", got)
+	}
+}
@@ -0,0 +1,300 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+type CompressionConfig struct {
+	MinSize int
+}
+
+var compressibleTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/x-ndjson",
+	"text/plain",
+	"text/csv",
+	"text/html",
+}
+
+var (
+	gzipPool = sync.Pool{
+		New: func() interface{} {
+			w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+			return w
+		},
+	}
+	zstdPool = sync.Pool{
+		New: func() interface{} {
+			w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+			return w
+		},
+	}
+)
+
+func CompressionMiddleware(config CompressionConfig) func(http.Handler) http.Handler {
+	if config.MinSize < 0 {
+		config.MinSize = 0
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding, identity := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        config.MinSize,
+				statusCode:     http.StatusOK,
+			}
+			if !identity {
+				cw.minSize = 0
+				cw.force = true
+			}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+func negotiateEncoding(acceptEncoding string) (string, bool) {
+	if acceptEncoding == "" {
+		return "", true
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && parsed >= 0 && parsed <= 1 {
+				q = parsed
+			}
+		}
+		weights[name] = q
+	}
+
+	best := ""
+	bestQ := 0.0
+	for _, candidate := range []string{encodingZstd, encodingGzip} {
+		q, ok := weights[candidate]
+		if !ok {
+			q, ok = weights["*"]
+		}
+		if ok && q > bestQ {
+			best = candidate
+			bestQ = q
+		}
+	}
+
+	identity := true
+	if q, ok := weights["identity"]; ok {
+		identity = q > 0
+	} else if q, ok := weights["*"]; ok {
+		identity = q > 0
+	}
+
+	return best, identity
+}
+
+func isCompressible(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range compressibleTypes {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	encoding    string
+	minSize     int
+	force       bool
+	statusCode  int
+	wroteHeader bool
+	decided     bool
+	buf         bytes.Buffer
+	encoder     io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.statusCode = code
+
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf.Write(p)
+	if cw.buf.Len() >= cw.minSize {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (cw *compressWriter) decide(sizeReached bool) error {
+	if cw.decided {
+		return nil
+	}
+	cw.decided = true
+
+	header := cw.ResponseWriter.Header()
+	if sizeReached && cw.compressible(header) {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.newEncoder()
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+
+	return err
+}
+
+func (cw *compressWriter) compressible(header http.Header) bool {
+	if cw.statusCode == http.StatusPartialContent || header.Get("Content-Range") != "" || header.Get("Content-Encoding") != "" {
+		return false
+	}
+	return cw.force || isCompressible(header.Get("Content-Type"))
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	switch cw.encoding {
+	case encodingZstd:
+		enc := zstdPool.Get().(*zstd.Encoder)
+		enc.Reset(cw.ResponseWriter)
+		return &pooledEncoder{WriteCloser: enc, release: func() { zstdPool.Put(enc) }}
+	default:
+		enc := gzipPool.Get().(*gzip.Writer)
+		enc.Reset(cw.ResponseWriter)
+		return &pooledEncoder{WriteCloser: enc, release: func() { gzipPool.Put(enc) }}
+	}
+}
+
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			cw.WriteHeader(http.StatusOK)
+		}
+		cw.decide(cw.buf.Len() >= cw.minSize)
+	}
+
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			return nil
+		}
+		if err := cw.decide(false); err != nil {
+			return err
+		}
+	}
+
+	if cw.encoder != nil {
+		err := cw.encoder.Close()
+		cw.encoder = nil
+		return err
+	}
+
+	return nil
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+type pooledEncoder struct {
+	io.WriteCloser
+	release func()
+}
+
+func (e *pooledEncoder) Flush() error {
+	if flusher, ok := e.WriteCloser.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.WriteCloser.Close()
+	e.release()
+	return err
+}
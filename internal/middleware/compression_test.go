@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestCompressionMiddleware(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"id":"bet","amount":10},`), 100)
+	small := []byte(`{"id":"bet"}`)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		status         int
+		header         map[string]string
+		body           []byte
+		wantEncoding   string
+	}{
+		{name: "no accept-encoding", body: large},
+		{name: "gzip", acceptEncoding: "gzip", body: large, wantEncoding: "gzip"},
+		{name: "zstd wins a tie", acceptEncoding: "gzip, zstd", body: large, wantEncoding: "zstd"},
+		{name: "higher q wins", acceptEncoding: "zstd;q=0.5, gzip;q=0.8", body: large, wantEncoding: "gzip"},
+		{name: "q with other params", acceptEncoding: "zstd;level=3;q=0.2, gzip", body: large, wantEncoding: "gzip"},
+		{name: "q=0 refuses an encoding", acceptEncoding: "gzip;q=0", body: large},
+		{name: "wildcard", acceptEncoding: "*;q=0.1", body: large, wantEncoding: "zstd"},
+		{name: "wildcard does not override q=0", acceptEncoding: "zstd;q=0, *", body: large, wantEncoding: "gzip"},
+		{name: "below min size", acceptEncoding: "gzip", body: small},
+		{name: "identity;q=0 compresses below min size", acceptEncoding: "gzip, identity;q=0", body: small, wantEncoding: "gzip"},
+		{name: "*;q=0 refuses identity", acceptEncoding: "gzip;q=0.5, *;q=0", body: small, wantEncoding: "gzip"},
+		{name: "not compressible", acceptEncoding: "gzip", header: map[string]string{"Content-Type": "image/png"}, body: large},
+		{
+			name:           "partial content",
+			acceptEncoding: "gzip",
+			status:         http.StatusPartialContent,
+			header:         map[string]string{"Content-Range": "bytes 0-2499/5000"},
+			body:           large,
+		},
+		{name: "content-range", acceptEncoding: "gzip", header: map[string]string{"Content-Range": "bytes */5000"}, body: large},
+		{name: "already encoded", acceptEncoding: "gzip", header: map[string]string{"Content-Encoding": "br"}, body: large, wantEncoding: "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := CompressionMiddleware(CompressionConfig{MinSize: 1024})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				for key, value := range tt.header {
+					w.Header().Set(key, value)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				w.Write(tt.body)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/bets", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if got := rec.Header().Values("Vary"); len(got) != 1 || got[0] != "Accept-Encoding" {
+				t.Errorf("Vary = %v, want [Accept-Encoding]", got)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if tt.status != 0 && rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			var body []byte
+			switch tt.wantEncoding {
+			case "gzip":
+				zr, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				body, err = io.ReadAll(zr)
+				if err != nil {
+					t.Fatalf("read gzip body: %v", err)
+				}
+			case "zstd":
+				zr, err := zstd.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("zstd.NewReader: %v", err)
+				}
+				defer zr.Close()
+				body, err = io.ReadAll(zr)
+				if err != nil {
+					t.Fatalf("read zstd body: %v", err)
+				}
+			default:
+				body = rec.Body.Bytes()
+			}
+			if !bytes.Equal(body, tt.body) {
+				t.Errorf("body = %d bytes, want the %d bytes written by the handler", len(body), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+type wildcardOrigin struct {
+	scheme string
+	suffix string
+}
+
+type corsPolicy struct {
+	allowAll         bool
+	exactOrigins     map[string]struct{}
+	wildcardOrigins  []wildcardOrigin
+	allowedMethodSet map[string]struct{}
+	allowedMethods   string
+	allowedHeaders   map[string]struct{}
+	allowedHeaderStr string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+func newCORSPolicy(config CORSConfig) *corsPolicy {
+	p := &corsPolicy{
+		exactOrigins:     make(map[string]struct{}),
+		allowedMethodSet: make(map[string]struct{}),
+		allowedHeaders:   make(map[string]struct{}),
+		allowedMethods:   strings.Join(config.AllowedMethods, ", "),
+		allowedHeaderStr: strings.Join(config.AllowedHeaders, ", "),
+		exposedHeaders:   strings.Join(config.ExposedHeaders, ", "),
+		allowCredentials: config.AllowCredentials,
+	}
+
+	if config.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(config.MaxAge)
+	}
+
+	for _, origin := range config.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "*":
+			p.allowAll = true
+		case strings.HasPrefix(origin, "*."):
+			p.wildcardOrigins = append(p.wildcardOrigins, wildcardOrigin{suffix: origin[1:]})
+		case strings.Contains(origin, "://*."):
+			scheme, suffix, _ := strings.Cut(origin, "://*")
+			p.wildcardOrigins = append(p.wildcardOrigins, wildcardOrigin{scheme: scheme, suffix: suffix})
+		case origin != "":
+			p.exactOrigins[origin] = struct{}{}
+		}
+	}
+
+	for _, m := range config.AllowedMethods {
+		p.allowedMethodSet[strings.ToUpper(strings.TrimSpace(m))] = struct{}{}
+	}
+
+	for _, h := range config.AllowedHeaders {
+		p.allowedHeaders[strings.ToLower(strings.TrimSpace(h))] = struct{}{}
+	}
+
+	return p
+}
+
+func (p *corsPolicy) isOriginAllowed(origin string) bool {
+	if p.allowAll {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if _, ok := p.exactOrigins[origin]; ok {
+		return true
+	}
+
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok {
+		return false
+	}
+
+	for _, wildcard := range p.wildcardOrigins {
+		if wildcard.scheme != "" && wildcard.scheme != scheme {
+			continue
+		}
+		if strings.HasSuffix(host, wildcard.suffix) && len(host) > len(wildcard.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *corsPolicy) areHeadersAllowed(requested string) bool {
+	if requested == "" {
+		return true
+	}
+
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" {
+			continue
+		}
+		if _, ok := p.allowedHeaders[h]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *corsPolicy) isMethodAllowed(method string) bool {
+	_, ok := p.allowedMethodSet[method]
+	return ok
+}
+
+func OriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	policy := newCORSPolicy(CORSConfig{AllowedOrigins: allowedOrigins})
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || policy.isOriginAllowed(origin)
+	}
+}
+
+func CORSMiddleware(config CORSConfig) func(http.Handler) http.Handler {
+	policy := newCORSPolicy(config)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			headers := w.Header()
+			headers.Add("Vary", "Origin")
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if !policy.isOriginAllowed(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if policy.allowAll {
+				headers.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				headers.Set("Access-Control-Allow-Origin", origin)
+			}
+			if policy.allowCredentials {
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if policy.exposedHeaders != "" {
+					headers.Set("Access-Control-Expose-Headers", policy.exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			headers.Add("Vary", "Access-Control-Request-Method")
+			headers.Add("Vary", "Access-Control-Request-Headers")
+
+			requestedMethod := r.Header.Get("Access-Control-Request-Method")
+			requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+			if !policy.isMethodAllowed(requestedMethod) || !policy.areHeadersAllowed(requestedHeaders) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			headers.Set("Access-Control-Allow-Methods", policy.allowedMethods)
+			if policy.allowedHeaderStr != "" {
+				headers.Set("Access-Control-Allow-Headers", policy.allowedHeaderStr)
+			}
+			if policy.maxAge != "" {
+				headers.Set("Access-Control-Max-Age", policy.maxAge)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	config := CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.partner.example"},
+		AllowedMethods:   []string{"GET", "POST", "DELETE"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+
+	tests := []struct {
+		name           string
+		config         *CORSConfig
+		method         string
+		origin         string
+		requestMethod  string
+		requestHeaders string
+		status         int
+		allowOrigin    string
+		credentials    bool
+		allowMethods   string
+		exposeHeaders  string
+		reachedHandler bool
+	}{
+		{
+			name:          "preflight from an allowed origin",
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: "POST", requestHeaders: "content-type, authorization",
+			status:       http.StatusNoContent,
+			allowOrigin:  "https://app.example.com",
+			credentials:  true,
+			allowMethods: "GET, POST, DELETE",
+		},
+		{
+			name:          "preflight from a wildcard subdomain",
+			method:        http.MethodOptions,
+			origin:        "https://eu.partner.example",
+			requestMethod: "DELETE",
+			status:        http.StatusNoContent,
+			allowOrigin:   "https://eu.partner.example",
+			credentials:   true,
+			allowMethods:  "GET, POST, DELETE",
+		},
+		{
+			name:          "preflight from a disallowed origin",
+			method:        http.MethodOptions,
+			origin:        "https://evil.example",
+			requestMethod: "GET",
+			status:        http.StatusForbidden,
+		},
+		{
+			name:          "wildcard does not match the bare domain",
+			method:        http.MethodOptions,
+			origin:        "https://partner.example",
+			requestMethod: "GET",
+			status:        http.StatusForbidden,
+		},
+		{
+			name:          "wildcard does not match another scheme",
+			method:        http.MethodOptions,
+			origin:        "http://eu.partner.example",
+			requestMethod: "GET",
+			status:        http.StatusForbidden,
+		},
+		{
+			name:          "preflight for a disallowed method",
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: "PUT",
+			status:        http.StatusForbidden,
+			allowOrigin:   "https://app.example.com",
+			credentials:   true,
+		},
+		{
+			name:          "preflight for a disallowed header",
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: "POST", requestHeaders: "Content-Type, X-Secret",
+			status:      http.StatusForbidden,
+			allowOrigin: "https://app.example.com",
+			credentials: true,
+		},
+		{
+			name:           "simple request from an allowed origin",
+			method:         http.MethodGet,
+			origin:         "https://app.example.com",
+			status:         http.StatusOK,
+			allowOrigin:    "https://app.example.com",
+			credentials:    true,
+			exposeHeaders:  "X-Request-ID",
+			reachedHandler: true,
+		},
+		{
+			name:           "simple request from a disallowed origin",
+			method:         http.MethodGet,
+			origin:         "https://evil.example",
+			status:         http.StatusOK,
+			reachedHandler: true,
+		},
+		{
+			name:           "same-origin request",
+			method:         http.MethodGet,
+			status:         http.StatusOK,
+			reachedHandler: true,
+		},
+		{
+			name:           "OPTIONS without a requested method is not a preflight",
+			method:         http.MethodOptions,
+			origin:         "https://app.example.com",
+			status:         http.StatusOK,
+			allowOrigin:    "https://app.example.com",
+			credentials:    true,
+			exposeHeaders:  "X-Request-ID",
+			reachedHandler: true,
+		},
+		{
+			name:          "any origin without credentials",
+			config:        &CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}},
+			method:        http.MethodOptions,
+			origin:        "https://anywhere.example",
+			requestMethod: "GET",
+			status:        http.StatusNoContent,
+			allowOrigin:   "*",
+			allowMethods:  "GET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config
+			if tt.config != nil {
+				cfg = *tt.config
+			}
+
+			reached := false
+			h := CORSMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+			}))
+
+			req := httptest.NewRequest(tt.method, "/v1/bets", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			if tt.requestHeaders != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.requestHeaders)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if reached != tt.reachedHandler {
+				t.Errorf("handler reached = %v, want %v", reached, tt.reachedHandler)
+			}
+
+			header := rec.Header()
+			if got := header.Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if got := header.Get("Access-Control-Allow-Credentials") == "true"; got != tt.credentials {
+				t.Errorf("Access-Control-Allow-Credentials = %v, want %v", got, tt.credentials)
+			}
+			if got := header.Get("Access-Control-Allow-Methods"); got != tt.allowMethods {
+				t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, tt.allowMethods)
+			}
+			if got := header.Get("Access-Control-Expose-Headers"); got != tt.exposeHeaders {
+				t.Errorf("Access-Control-Expose-Headers = %q, want %q", got, tt.exposeHeaders)
+			}
+			if tt.allowMethods != "" {
+				if got := header.Get("Access-Control-Allow-Headers"); tt.config == nil && got != "Content-Type, Authorization" {
+					t.Errorf("Access-Control-Allow-Headers = %q, want the configured headers", got)
+				}
+				if got := header.Get("Access-Control-Max-Age"); tt.config == nil && got != "600" {
+					t.Errorf("Access-Control-Max-Age = %q, want 600", got)
+				}
+			}
+			if vary := strings.Join(header.Values("Vary"), ", "); tt.origin != "" && !strings.Contains(vary, "Origin") {
+				t.Errorf("Vary = %q, want it to include Origin", vary)
+			}
+		})
+	}
+}
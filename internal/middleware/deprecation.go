@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type DeprecationConfig struct {
+	Deprecation     time.Time
+	Sunset          time.Time
+	SuccessorPrefix string
+}
+
+func DeprecationMiddleware(config DeprecationConfig) func(http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(config.Deprecation.Unix(), 10)
+	sunset := config.Sunset.UTC().Format(http.TimeFormat)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Set("Sunset", sunset)
+			if config.SuccessorPrefix != "" {
+				w.Header().Add("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", config.SuccessorPrefix, r.URL.Path))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
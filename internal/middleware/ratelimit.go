@@ -112,18 +112,6 @@ func (rl *RateLimiter) Shutdown(ctx context.Context) error {
 	}
 }
 
-func getClientIP(r *http.Request) string {
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		return forwarded
-	}
-
-	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-		return realIP
-	}
-
-	return r.RemoteAddr
-}
-
 func (rl *RateLimiter) Allow(ip string) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -163,7 +151,7 @@ func RateLimitMiddleware(limiter *RateLimiter) func(http.Handler) http.Handler {
 				return
 			}
 
-			clientIP := getClientIP(r)
+			clientIP := GetClientIP(r.Context())
 
 			if !limiter.Allow(clientIP) {
 				limiter.logger.Warn("rate limit exceeded",
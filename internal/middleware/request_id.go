@@ -19,11 +19,14 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 		}
 
 		w.Header().Set("X-Request-ID", requestID)
-		ctx := context.WithValue(r.Context(), requestIDKeyValue, requestID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), requestID)))
 	})
 }
 
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKeyValue, requestID)
+}
+
 func GetRequestID(ctx context.Context) string {
 	if requestID, ok := ctx.Value(requestIDKeyValue).(string); ok {
 		return requestID
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddlewareNamesSpansByRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	mux := http.NewServeMux()
+	pattern := "GET /v1/bets/{id}"
+	mux.Handle(pattern, TraceRoute(pattern)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	h := TracingMiddleware(mux)
+
+	tests := []struct {
+		name  string
+		path  string
+		span  string
+		route string
+	}{
+		{name: "matched route", path: "/v1/bets/3f2a?user_id=7", span: "GET /v1/bets/{id}", route: "/v1/bets/{id}"},
+		{name: "another id", path: "/v1/bets/9c1d", span: "GET /v1/bets/{id}", route: "/v1/bets/{id}"},
+		{name: "unmatched", path: "/nope/123", span: "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			if span.Name() != tt.span {
+				t.Errorf("span name = %q, want %q", span.Name(), tt.span)
+			}
+
+			attrs := make(map[attribute.Key]string)
+			for _, kv := range span.Attributes() {
+				attrs[kv.Key] = kv.Value.Emit()
+			}
+			if attrs["http.route"] != tt.route {
+				t.Errorf("http.route = %q, want %q", attrs["http.route"], tt.route)
+			}
+			if _, ok := attrs["url.query"]; ok {
+				t.Errorf("span records url.query %q", attrs["url.query"])
+			}
+		})
+	}
+}
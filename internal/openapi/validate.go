@@ -0,0 +1,331 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const refPrefix = "#/components/schemas/"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var patterns sync.Map
+
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (d *Document) Resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, refPrefix)]
+	}
+	return s
+}
+
+func (d *Document) Property(schema, name string) *Schema {
+	s := d.Schema(schema)
+	if s == nil {
+		return nil
+	}
+	return d.Resolve(s.Properties[name])
+}
+
+func (op *Operation) Parameter(in, name string) *Parameter {
+	for _, p := range op.Parameters {
+		if p.In == in && p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (d *Document) ValidateParameter(p *Parameter, raw string) []FieldError {
+	schema := d.Resolve(p.Schema)
+	if schema == nil {
+		return nil
+	}
+
+	var value interface{} = raw
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return []FieldError{fieldError(p.Name, "must be an integer")}
+		}
+		value = n
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return []FieldError{fieldError(p.Name, "must be a number")}
+		}
+		value = n
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []FieldError{fieldError(p.Name, "must be true or false")}
+		}
+		value = b
+	}
+
+	return d.Validate(p.Name, schema, value)
+}
+
+func (d *Document) Validate(field string, s *Schema, value interface{}) []FieldError {
+	s = d.Resolve(s)
+	if s == nil {
+		return nil
+	}
+
+	var errs []FieldError
+	switch s.Type {
+	case "object":
+		errs = d.validateObject(field, s, value)
+	case "array":
+		errs = d.validateArray(field, s, value)
+	case "string":
+		errs = validateString(field, s, value)
+	case "integer":
+		errs = validateInteger(field, s, value)
+	case "number":
+		errs = validateNumber(field, s, value)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			errs = []FieldError{fieldError(field, "must be a boolean")}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return []FieldError{fieldError(field, "must be one of: "+enumList(s.Enum))}
+	}
+
+	return nil
+}
+
+func (d *Document) validateObject(field string, s *Schema, value interface{}) []FieldError {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return []FieldError{fieldError(field, "must be an object")}
+	}
+
+	var errs []FieldError
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			errs = append(errs, fieldError(joinField(field, name), "is required"))
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if prop, ok := s.Properties[name]; ok {
+			errs = append(errs, d.Validate(joinField(field, name), prop, obj[name])...)
+		} else if s.Closed {
+			errs = append(errs, fieldError(joinField(field, name), "is not a supported field"))
+		} else if s.AdditionalProperties != nil {
+			errs = append(errs, d.Validate(joinField(field, name), s.AdditionalProperties, obj[name])...)
+		}
+	}
+
+	return errs
+}
+
+func (d *Document) validateArray(field string, s *Schema, value interface{}) []FieldError {
+	var items []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		items = v
+	case []string:
+		items = make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+	default:
+		return []FieldError{fieldError(field, "must be an array")}
+	}
+
+	if s.MinItems != nil && len(items) < *s.MinItems {
+		return []FieldError{fieldError(field, fmt.Sprintf("must contain at least %s", plural(*s.MinItems, "item")))}
+	}
+	if s.MaxItems != nil && len(items) > *s.MaxItems {
+		return []FieldError{fieldError(field, fmt.Sprintf("must not contain more than %s", plural(*s.MaxItems, "item")))}
+	}
+	if s.UniqueItems {
+		seen := make(map[interface{}]bool, len(items))
+		for _, item := range items {
+			switch item.(type) {
+			case string, float64, bool:
+				if seen[item] {
+					return []FieldError{fieldError(field, "must not contain duplicate items")}
+				}
+				seen[item] = true
+			}
+		}
+	}
+
+	var errs []FieldError
+	for i, item := range items {
+		errs = append(errs, d.Validate(fmt.Sprintf("%s[%d]", field, i), s.Items, item)...)
+	}
+	return errs
+}
+
+func validateString(field string, s *Schema, value interface{}) []FieldError {
+	str, ok := value.(string)
+	if !ok {
+		return []FieldError{fieldError(field, "must be a string")}
+	}
+
+	length := utf8.RuneCountInString(str)
+	if s.MinLength != nil && length < *s.MinLength {
+		if *s.MinLength == 1 {
+			return []FieldError{fieldError(field, "is required")}
+		}
+		return []FieldError{fieldError(field, fmt.Sprintf("must be at least %d characters", *s.MinLength))}
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return []FieldError{fieldError(field, fmt.Sprintf("must not exceed %d characters", *s.MaxLength))}
+	}
+
+	switch s.Format {
+	case "uuid":
+		if !uuidPattern.MatchString(str) {
+			return []FieldError{fieldError(field, "must be a valid UUID")}
+		}
+	case "uri":
+		parsed, err := url.Parse(str)
+		if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+			return []FieldError{fieldError(field, "must be an absolute URL")}
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return []FieldError{fieldError(field, "must be an RFC 3339 date-time")}
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, str); err != nil {
+			return []FieldError{fieldError(field, "must be a date in YYYY-MM-DD format")}
+		}
+	}
+
+	if s.Pattern != "" && !compilePattern(s.Pattern).MatchString(str) {
+		return []FieldError{fieldError(field, fmt.Sprintf("must match %s", s.Pattern))}
+	}
+
+	return nil
+}
+
+func validateInteger(field string, s *Schema, value interface{}) []FieldError {
+	n, ok := toFloat(value)
+	if !ok || n != math.Trunc(n) || math.IsInf(n, 0) {
+		return []FieldError{fieldError(field, "must be an integer")}
+	}
+
+	below := s.Minimum != nil && n < *s.Minimum
+	above := s.Maximum != nil && n > *s.Maximum
+	switch {
+	case (below || above) && s.Minimum != nil && s.Maximum != nil:
+		return []FieldError{fieldError(field, fmt.Sprintf("must be between %d and %d", int64(*s.Minimum), int64(*s.Maximum)))}
+	case below:
+		return []FieldError{fieldError(field, fmt.Sprintf("must be at least %d", int64(*s.Minimum)))}
+	case above:
+		return []FieldError{fieldError(field, fmt.Sprintf("must not exceed %d", int64(*s.Maximum)))}
+	}
+	return nil
+}
+
+func validateNumber(field string, s *Schema, value interface{}) []FieldError {
+	n, ok := toFloat(value)
+	if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+		return []FieldError{fieldError(field, "must be a valid numeric value")}
+	}
+
+	if s.Minimum != nil && n < *s.Minimum {
+		return []FieldError{fieldError(field, fmt.Sprintf("must be at least %.2f", *s.Minimum))}
+	}
+	if s.Maximum != nil && n > *s.Maximum {
+		return []FieldError{fieldError(field, fmt.Sprintf("must not exceed %.2f", *s.Maximum))}
+	}
+	return nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return strings.Join(values, ", ")
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
+
+func fieldError(field, message string) FieldError {
+	if field == "" {
+		return FieldError{Field: "body", Message: "request body " + message}
+	}
+	return FieldError{Field: field, Message: field + " " + message}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -2,12 +2,31 @@ package repository
 
 import (
 	"bet/internal/domain"
+	"bet/internal/events"
 	"context"
+	"iter"
 )
 
+type Change struct {
+	Outbox []events.Event
+	Audit  []domain.AuditEntry
+}
+
 type BetRepository interface {
-	Create(ctx context.Context, bet *domain.Bet) error
+	Create(ctx context.Context, bet *domain.Bet, outbox ...events.Event) error
+	CreateBatch(ctx context.Context, bets []*domain.Bet, outbox ...events.Event) error
+	Import(ctx context.Context, bets []domain.Bet) (duplicates []string, err error)
+	Update(ctx context.Context, bet *domain.Bet, outbox ...events.Event) error
+	Modify(ctx context.Context, id string, apply func(bet *domain.Bet) (Change, error)) (*domain.Bet, error)
 	GetByID(ctx context.Context, id string) (*domain.Bet, error)
 	List(ctx context.Context, req domain.ListBetsRequest) (domain.ListBetsResponse, error)
+	Stream(ctx context.Context, req domain.ExportBetsRequest) iter.Seq2[domain.Bet, error]
+	UserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error)
+	Summary(ctx context.Context, req domain.ReportRequest) (domain.Report, error)
+	ListAudit(ctx context.Context, resource, id string) ([]domain.AuditEntry, error)
 	HealthCheck(ctx context.Context) error
+
+	AppendOutbox(ctx context.Context, outbox ...events.Event) error
+	FetchOutbox(ctx context.Context, afterSeq uint64, limit int) ([]events.OutboxRecord, error)
+	MarkOutboxPublished(ctx context.Context, id string) error
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+)
+
+type FraudFlagRepository interface {
+	Create(ctx context.Context, flags ...domain.FraudFlag) error
+	GetByID(ctx context.Context, id string) (domain.FraudFlag, error)
+	List(ctx context.Context, filter domain.FraudFlagFilter) ([]domain.FraudFlag, error)
+	Update(ctx context.Context, id string, update func(*domain.FraudFlag) error) (domain.FraudFlag, error)
+	HasHold(ctx context.Context, userID int64) (bool, error)
+}
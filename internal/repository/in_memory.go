@@ -2,26 +2,57 @@ package repository
 
 import (
 	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/tracing"
 	"context"
+	"iter"
 	"sort"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const streamChunkSize = 500
+
+var tracer = otel.Tracer("bet/internal/repository")
+
 type inMemoryBetRepository struct {
 	bets        map[string]*domain.Bet
 	mu          sync.RWMutex
 	userIDIndex map[int64][]string
 	muIndex     sync.RWMutex
+	stats       map[int64]*userStats
+	rollups     map[rollupKey]*hourRollup
+	audit       []domain.AuditEntry
+	auditIndex  map[string][]int
+	outboxMu    sync.RWMutex
+	outbox      []events.OutboxRecord
+	outboxIndex map[string]uint64
+	outboxSeq   uint64
 }
 
 func NewInMemoryBetRepository() BetRepository {
 	return &inMemoryBetRepository{
 		bets:        make(map[string]*domain.Bet),
 		userIDIndex: make(map[int64][]string),
+		stats:       make(map[int64]*userStats),
+		rollups:     make(map[rollupKey]*hourRollup),
+		auditIndex:  make(map[string][]int),
+		outboxIndex: make(map[string]uint64),
 	}
 }
 
-func (r *inMemoryBetRepository) Create(ctx context.Context, bet *domain.Bet) error {
+func (r *inMemoryBetRepository) Create(ctx context.Context, bet *domain.Bet, outbox ...events.Event) error {
+	ctx, span := startSpan(ctx, "BetRepository.Create",
+		attribute.String("bet.id", bet.ID),
+		attribute.Int64("bet.user_id", bet.UserID),
+	)
+	defer span.End()
+
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -35,10 +66,163 @@ func (r *inMemoryBetRepository) Create(ctx context.Context, bet *domain.Bet) err
 	r.userIDIndex[bet.UserID] = append(r.userIDIndex[bet.UserID], bet.ID)
 	r.muIndex.Unlock()
 
+	r.aggregateLocked(bet)
+	r.appendOutboxLocked(outbox)
+
+	return nil
+}
+
+func (r *inMemoryBetRepository) CreateBatch(ctx context.Context, bets []*domain.Bet, outbox ...events.Event) error {
+	ctx, span := startSpan(ctx, "BetRepository.CreateBatch", attribute.Int("bets.count", len(bets)))
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.muIndex.Lock()
+	for _, bet := range bets {
+		r.bets[bet.ID] = bet
+		r.userIDIndex[bet.UserID] = append(r.userIDIndex[bet.UserID], bet.ID)
+	}
+	r.muIndex.Unlock()
+
+	for _, bet := range bets {
+		r.aggregateLocked(bet)
+	}
+	r.appendOutboxLocked(outbox)
+
+	return nil
+}
+
+func (r *inMemoryBetRepository) Import(ctx context.Context, bets []domain.Bet) ([]string, error) {
+	ctx, span := startSpan(ctx, "BetRepository.Import", attribute.Int("bets.count", len(bets)))
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var duplicates []string
+
+	r.muIndex.Lock()
+	for _, bet := range bets {
+		if _, exists := r.bets[bet.ID]; exists {
+			duplicates = append(duplicates, bet.ID)
+			continue
+		}
+
+		stored := bet
+		r.bets[bet.ID] = &stored
+		r.userIDIndex[bet.UserID] = append(r.userIDIndex[bet.UserID], bet.ID)
+		r.aggregateLocked(&stored)
+	}
+	r.muIndex.Unlock()
+
+	span.SetAttributes(attribute.Int("bets.duplicates", len(duplicates)))
+
+	return duplicates, nil
+}
+
+func (r *inMemoryBetRepository) Update(ctx context.Context, bet *domain.Bet, outbox ...events.Event) error {
+	ctx, span := startSpan(ctx, "BetRepository.Update",
+		attribute.String("bet.id", bet.ID),
+		attribute.String("bet.status", string(bet.Status)),
+	)
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, exists := r.bets[bet.ID]
+	if !exists {
+		return domain.ErrBetNotFound
+	}
+
+	betCopy := *bet
+	r.bets[bet.ID] = &betCopy
+	r.reaggregateLocked(old, &betCopy)
+
+	r.appendOutboxLocked(outbox)
+
 	return nil
 }
 
+func (r *inMemoryBetRepository) Modify(ctx context.Context, id string, apply func(bet *domain.Bet) (Change, error)) (*domain.Bet, error) {
+	ctx, span := startSpan(ctx, "BetRepository.Modify", attribute.String("bet.id", id))
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bet, exists := r.bets[id]
+	if !exists {
+		return nil, domain.ErrBetNotFound
+	}
+
+	betCopy := *bet
+	change, err := apply(&betCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("bet.status", string(betCopy.Status)))
+
+	stored := betCopy
+	r.bets[id] = &stored
+	r.reaggregateLocked(bet, &stored)
+
+	for _, entry := range change.Audit {
+		key := auditKey(entry.Resource, entry.ResourceID)
+		r.auditIndex[key] = append(r.auditIndex[key], len(r.audit))
+		r.audit = append(r.audit, entry)
+	}
+	r.appendOutboxLocked(change.Outbox)
+
+	return &betCopy, nil
+}
+
+func (r *inMemoryBetRepository) ListAudit(ctx context.Context, resource, id string) ([]domain.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "BetRepository.ListAudit", attribute.String("audit.resource_id", id))
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	indexes := r.auditIndex[auditKey(resource, id)]
+	entries := make([]domain.AuditEntry, len(indexes))
+	for i, idx := range indexes {
+		entries[i] = r.audit[idx]
+	}
+	return entries, nil
+}
+
+func auditKey(resource, id string) string {
+	return resource + "/" + id
+}
+
 func (r *inMemoryBetRepository) GetByID(ctx context.Context, id string) (*domain.Bet, error) {
+	ctx, span := startSpan(ctx, "BetRepository.GetByID", attribute.String("bet.id", id))
+	defer span.End()
+
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -56,6 +240,9 @@ func (r *inMemoryBetRepository) GetByID(ctx context.Context, id string) (*domain
 }
 
 func (r *inMemoryBetRepository) List(ctx context.Context, req domain.ListBetsRequest) (domain.ListBetsResponse, error) {
+	ctx, span := startSpan(ctx, "BetRepository.List", tracing.ListBetsAttributes(req)...)
+	defer span.End()
+
 	if ctx.Err() != nil {
 		return domain.ListBetsResponse{}, ctx.Err()
 	}
@@ -63,22 +250,85 @@ func (r *inMemoryBetRepository) List(ctx context.Context, req domain.ListBetsReq
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	matched := r.matchingLocked(req.Filters)
+	filteredBets := make([]domain.Bet, len(matched))
+	for i, bet := range matched {
+		filteredBets[i] = *bet
+	}
+
+	sortedBets := r.applySorting(filteredBets, req.Sort)
+
+	total := len(sortedBets)
+	paginatedBets := r.applyPagination(sortedBets, req.Pagination)
+
+	span.SetAttributes(
+		attribute.Int("bets.result_count", len(paginatedBets)),
+		attribute.Int("bets.total", total),
+	)
+
+	return domain.ListBetsResponse{
+		Bets:  paginatedBets,
+		Total: total,
+		Page:  req.Pagination.Page,
+		Limit: req.Pagination.Limit,
+	}, nil
+}
+
+func (r *inMemoryBetRepository) Stream(ctx context.Context, req domain.ExportBetsRequest) iter.Seq2[domain.Bet, error] {
+	return func(yield func(domain.Bet, error) bool) {
+		ctx, span := startSpan(ctx, "BetRepository.Stream", tracing.ExportBetsAttributes(req)...)
+		defer span.End()
+
+		if ctx.Err() != nil {
+			yield(domain.Bet{}, ctx.Err())
+			return
+		}
+
+		r.mu.RLock()
+		matched := r.matchingLocked(req.Filters)
+		r.mu.RUnlock()
+
+		sort.SliceStable(matched, func(i, j int) bool {
+			return betLess(matched[i], matched[j], req.Sort)
+		})
+
+		span.SetAttributes(attribute.Int("bets.total", len(matched)))
+
+		chunk := make([]domain.Bet, 0, streamChunkSize)
+		for start := 0; start < len(matched); start += streamChunkSize {
+			if err := ctx.Err(); err != nil {
+				yield(domain.Bet{}, err)
+				return
+			}
+
+			end := min(start+streamChunkSize, len(matched))
+			chunk = chunk[:0]
+
+			r.mu.RLock()
+			for _, bet := range matched[start:end] {
+				if current, exists := r.bets[bet.ID]; exists {
+					chunk = append(chunk, *current)
+				}
+			}
+			r.mu.RUnlock()
+
+			for _, bet := range chunk {
+				if !yield(bet, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *inMemoryBetRepository) matchingLocked(filters domain.BetFilters) []*domain.Bet {
 	var betsToCheck []*domain.Bet
 
-	if req.Filters.UserID != nil {
+	if filters.UserID != nil {
 		r.muIndex.RLock()
-		betIDs, exists := r.userIDIndex[*req.Filters.UserID]
+		betIDs := r.userIDIndex[*filters.UserID]
 		r.muIndex.RUnlock()
 
-		if !exists {
-			return domain.ListBetsResponse{
-				Bets:  []domain.Bet{},
-				Total: 0,
-				Page:  req.Pagination.Page,
-				Limit: req.Pagination.Limit,
-			}, nil
-		}
-
 		betsToCheck = make([]*domain.Bet, 0, len(betIDs))
 		for _, betID := range betIDs {
 			if bet, exists := r.bets[betID]; exists {
@@ -96,44 +346,14 @@ func (r *inMemoryBetRepository) List(ctx context.Context, req domain.ListBetsReq
 	if estimatedCapacity < 10 {
 		estimatedCapacity = 10
 	}
-	filteredBets := make([]domain.Bet, 0, estimatedCapacity)
+	matched := make([]*domain.Bet, 0, estimatedCapacity)
 	for _, bet := range betsToCheck {
-		if r.matchesFilter(*bet, req.Filters) {
-			filteredBets = append(filteredBets, *bet)
+		if filters.Matches(*bet) {
+			matched = append(matched, bet)
 		}
 	}
 
-	sortedBets := r.applySorting(filteredBets, req.Sort)
-
-	total := len(sortedBets)
-	paginatedBets := r.applyPagination(sortedBets, req.Pagination)
-
-	return domain.ListBetsResponse{
-		Bets:  paginatedBets,
-		Total: total,
-		Page:  req.Pagination.Page,
-		Limit: req.Pagination.Limit,
-	}, nil
-}
-
-func (r *inMemoryBetRepository) matchesFilter(bet domain.Bet, filters domain.BetFilters) bool {
-	if filters.UserID == nil && filters.MinAmount == nil && filters.MaxAmount == nil {
-		return true
-	}
-
-	if filters.UserID != nil && bet.UserID != *filters.UserID {
-		return false
-	}
-
-	if filters.MinAmount != nil && bet.Amount < *filters.MinAmount {
-		return false
-	}
-
-	if filters.MaxAmount != nil && bet.Amount > *filters.MaxAmount {
-		return false
-	}
-
-	return true
+	return matched
 }
 
 func (r *inMemoryBetRepository) applySorting(bets []domain.Bet, sortParams domain.SortParams) []domain.Bet {
@@ -141,26 +361,30 @@ func (r *inMemoryBetRepository) applySorting(bets []domain.Bet, sortParams domai
 	copy(sorted, bets)
 
 	sort.Slice(sorted, func(i, j int) bool {
-		var less bool
-
-		switch sortParams.SortBy {
-		case "amount":
-			less = sorted[i].Amount < sorted[j].Amount
-		case "created_at":
-			less = sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
-		default:
-			less = sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
-		}
-
-		if sortParams.Order == "desc" {
-			return !less
-		}
-		return less
+		return betLess(&sorted[i], &sorted[j], sortParams)
 	})
 
 	return sorted
 }
 
+func betLess(a, b *domain.Bet, sortParams domain.SortParams) bool {
+	var less bool
+
+	switch sortParams.SortBy {
+	case "amount":
+		less = a.Amount < b.Amount
+	case "created_at":
+		less = a.CreatedAt.Before(b.CreatedAt)
+	default:
+		less = a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	if sortParams.Order == "desc" {
+		return !less
+	}
+	return less
+}
+
 func (r *inMemoryBetRepository) applyPagination(bets []domain.Bet, pagination domain.PaginationParams) []domain.Bet {
 	if pagination.Page < 1 {
 		pagination.Page = 1
@@ -186,6 +410,9 @@ func (r *inMemoryBetRepository) applyPagination(bets []domain.Bet, pagination do
 }
 
 func (r *inMemoryBetRepository) HealthCheck(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "BetRepository.HealthCheck")
+	defer span.End()
+
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -196,3 +423,99 @@ func (r *inMemoryBetRepository) HealthCheck(ctx context.Context) error {
 	_ = len(r.bets)
 	return nil
 }
+
+func (r *inMemoryBetRepository) AppendOutbox(ctx context.Context, outbox ...events.Event) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.appendOutboxLocked(outbox)
+	return nil
+}
+
+func (r *inMemoryBetRepository) appendOutboxLocked(outbox []events.Event) {
+	r.outboxMu.Lock()
+	defer r.outboxMu.Unlock()
+
+	now := time.Now()
+	for _, event := range outbox {
+		r.outboxSeq++
+		record := events.OutboxRecord{
+			ID:        uuid.New().String(),
+			Seq:       r.outboxSeq,
+			Event:     event,
+			CreatedAt: now,
+		}
+		r.outbox = append(r.outbox, record)
+		r.outboxIndex[record.ID] = record.Seq
+	}
+}
+
+func (r *inMemoryBetRepository) FetchOutbox(ctx context.Context, afterSeq uint64, limit int) ([]events.OutboxRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.outboxMu.RLock()
+	defer r.outboxMu.RUnlock()
+
+	start := sort.Search(len(r.outbox), func(i int) bool {
+		return r.outbox[i].Seq > afterSeq
+	})
+
+	var records []events.OutboxRecord
+	for _, record := range r.outbox[start:] {
+		if limit > 0 && len(records) == limit {
+			break
+		}
+		if _, pending := r.outboxIndex[record.ID]; pending {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *inMemoryBetRepository) MarkOutboxPublished(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.outboxMu.Lock()
+	defer r.outboxMu.Unlock()
+
+	if _, ok := r.outboxIndex[id]; !ok {
+		return nil
+	}
+	delete(r.outboxIndex, id)
+
+	head := 0
+	for head < len(r.outbox) {
+		if _, pending := r.outboxIndex[r.outbox[head].ID]; pending {
+			break
+		}
+		head++
+	}
+	r.outbox = r.outbox[head:]
+
+	if len(r.outbox) > 2*len(r.outboxIndex)+64 {
+		live := make([]events.OutboxRecord, 0, len(r.outboxIndex))
+		for _, record := range r.outbox {
+			if _, pending := r.outboxIndex[record.ID]; pending {
+				live = append(live, record)
+			}
+		}
+		r.outbox = live
+	}
+	return nil
+}
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("db.system", "memory"))
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
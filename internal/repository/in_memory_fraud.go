@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"sync"
+)
+
+type inMemoryFraudFlagRepository struct {
+	flags  map[string]*domain.FraudFlag
+	order  []string
+	byUser map[int64][]string
+	mu     sync.RWMutex
+}
+
+func NewInMemoryFraudFlagRepository() FraudFlagRepository {
+	return &inMemoryFraudFlagRepository{
+		flags:  make(map[string]*domain.FraudFlag),
+		byUser: make(map[int64][]string),
+	}
+}
+
+func (r *inMemoryFraudFlagRepository) Create(ctx context.Context, flags ...domain.FraudFlag) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, flag := range flags {
+		r.flags[flag.ID] = copyFraudFlag(&flag)
+		r.order = append(r.order, flag.ID)
+		r.byUser[flag.UserID] = append(r.byUser[flag.UserID], flag.ID)
+	}
+	return nil
+}
+
+func (r *inMemoryFraudFlagRepository) GetByID(ctx context.Context, id string) (domain.FraudFlag, error) {
+	if ctx.Err() != nil {
+		return domain.FraudFlag{}, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flag, exists := r.flags[id]
+	if !exists {
+		return domain.FraudFlag{}, domain.ErrFraudFlagNotFound
+	}
+	return *copyFraudFlag(flag), nil
+}
+
+func (r *inMemoryFraudFlagRepository) List(ctx context.Context, filter domain.FraudFlagFilter) ([]domain.FraudFlag, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.order
+	if filter.UserID != nil {
+		ids = r.byUser[*filter.UserID]
+	}
+
+	flags := make([]domain.FraudFlag, 0)
+	for i := len(ids) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(flags) >= filter.Limit {
+			break
+		}
+		flag := r.flags[ids[i]]
+		if filter.Status != "" && flag.Status != filter.Status {
+			continue
+		}
+		flags = append(flags, *copyFraudFlag(flag))
+	}
+	return flags, nil
+}
+
+func (r *inMemoryFraudFlagRepository) Update(ctx context.Context, id string, update func(*domain.FraudFlag) error) (domain.FraudFlag, error) {
+	if ctx.Err() != nil {
+		return domain.FraudFlag{}, ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	flag, exists := r.flags[id]
+	if !exists {
+		return domain.FraudFlag{}, domain.ErrFraudFlagNotFound
+	}
+
+	updated := copyFraudFlag(flag)
+	if err := update(updated); err != nil {
+		return domain.FraudFlag{}, err
+	}
+	r.flags[id] = updated
+	return *copyFraudFlag(updated), nil
+}
+
+func (r *inMemoryFraudFlagRepository) HasHold(ctx context.Context, userID int64) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range r.byUser[userID] {
+		if r.flags[id].Holds() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func copyFraudFlag(flag *domain.FraudFlag) *domain.FraudFlag {
+	flagCopy := *flag
+	flagCopy.Hits = append([]domain.RuleHit(nil), flag.Hits...)
+	if flag.ReviewedAt != nil {
+		reviewedAt := *flag.ReviewedAt
+		flagCopy.ReviewedAt = &reviewedAt
+	}
+	return &flagCopy
+}
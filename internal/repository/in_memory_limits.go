@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+type inMemoryLimitRepository struct {
+	limits map[int64]*domain.UserLimits
+	mu     sync.RWMutex
+}
+
+func NewInMemoryLimitRepository() LimitRepository {
+	return &inMemoryLimitRepository{
+		limits: make(map[int64]*domain.UserLimits),
+	}
+}
+
+func (r *inMemoryLimitRepository) Get(ctx context.Context, userID int64) (domain.UserLimits, error) {
+	if ctx.Err() != nil {
+		return domain.UserLimits{}, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	limits, exists := r.limits[userID]
+	if !exists {
+		return domain.UserLimits{UserID: userID}, nil
+	}
+	return copyUserLimits(limits), nil
+}
+
+func (r *inMemoryLimitRepository) Update(ctx context.Context, userID int64, update func(*domain.UserLimits) error) (domain.UserLimits, error) {
+	if ctx.Err() != nil {
+		return domain.UserLimits{}, ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limits := domain.UserLimits{UserID: userID}
+	if existing, exists := r.limits[userID]; exists {
+		limits = copyUserLimits(existing)
+	}
+
+	if err := update(&limits); err != nil {
+		return domain.UserLimits{}, err
+	}
+
+	stored := copyUserLimits(&limits)
+	r.limits[userID] = &stored
+	return limits, nil
+}
+
+func (r *inMemoryLimitRepository) List(ctx context.Context) ([]domain.UserLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	limits := make([]domain.UserLimits, 0, len(r.limits))
+	for _, userLimits := range r.limits {
+		limits = append(limits, copyUserLimits(userLimits))
+	}
+	sort.Slice(limits, func(i, j int) bool { return limits[i].UserID < limits[j].UserID })
+	return limits, nil
+}
+
+func (r *inMemoryLimitRepository) Import(ctx context.Context, limits []domain.UserLimits) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range limits {
+		stored := copyUserLimits(&limits[i])
+		r.limits[stored.UserID] = &stored
+	}
+	return nil
+}
+
+func copyUserLimits(limits *domain.UserLimits) domain.UserLimits {
+	limitsCopy := *limits
+	limitsCopy.Limits = append([]domain.Limit(nil), limits.Limits...)
+	limitsCopy.Deposits = append([]domain.Deposit(nil), limits.Deposits...)
+	return limitsCopy
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type rollupKey struct {
+	hour     time.Time
+	currency string
+	operator string
+}
+
+func rollupKeyOf(bet *domain.Bet) rollupKey {
+	return rollupKey{
+		hour:     domain.GranularityHour.Truncate(bet.CreatedAt),
+		currency: bet.Currency,
+		operator: bet.Operator,
+	}
+}
+
+type hourRollup struct {
+	totals  domain.ReportTotals
+	players map[int64]struct{}
+}
+
+func (r *inMemoryBetRepository) Summary(ctx context.Context, req domain.ReportRequest) (domain.Report, error) {
+	ctx, span := startSpan(ctx, "BetRepository.Summary",
+		attribute.String("report.granularity", string(req.Granularity)),
+		attribute.String("report.from", req.From.Format(time.RFC3339)),
+		attribute.String("report.to", req.To.Format(time.RFC3339)),
+		attribute.String("report.currency", req.Currency),
+		attribute.String("report.operator", req.Operator),
+	)
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return domain.Report{}, ctx.Err()
+	}
+
+	report := domain.Report{ReportRequest: req}
+	index := make(map[time.Time]int)
+	for start := req.Granularity.Truncate(req.From); start.Before(req.To); start = req.Granularity.Next(start) {
+		index[start] = len(report.Buckets)
+		report.Buckets = append(report.Buckets, domain.ReportBucket{Start: start, End: req.Granularity.Next(start)})
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	from := domain.GranularityHour.Truncate(req.From)
+	bucketPlayers := make([]map[int64]struct{}, len(report.Buckets))
+	allPlayers := make(map[int64]struct{})
+	for key, rollup := range r.rollups {
+		if key.hour.Before(from) || !key.hour.Before(req.To) {
+			continue
+		}
+		if (req.Currency != "" && key.currency != req.Currency) || (req.Operator != "" && key.operator != req.Operator) {
+			continue
+		}
+		i := index[req.Granularity.Truncate(key.hour)]
+
+		report.Buckets[i].Merge(rollup.totals)
+		report.Totals.Merge(rollup.totals)
+
+		if bucketPlayers[i] == nil {
+			bucketPlayers[i] = make(map[int64]struct{})
+		}
+		for userID := range rollup.players {
+			bucketPlayers[i][userID] = struct{}{}
+			allPlayers[userID] = struct{}{}
+		}
+	}
+
+	for i := range report.Buckets {
+		report.Buckets[i].Players = len(bucketPlayers[i])
+	}
+	report.Totals.Players = len(allPlayers)
+
+	span.SetAttributes(attribute.Int("report.buckets", len(report.Buckets)), attribute.Int("bets.count", report.Totals.Bets))
+
+	return report, nil
+}
+
+func (r *inMemoryBetRepository) addRollupLocked(bet *domain.Bet) {
+	key := rollupKeyOf(bet)
+	rollup, exists := r.rollups[key]
+	if !exists {
+		rollup = &hourRollup{players: make(map[int64]struct{})}
+		r.rollups[key] = rollup
+	}
+
+	rollup.totals.Add(*bet)
+	rollup.players[bet.UserID] = struct{}{}
+}
+
+func (r *inMemoryBetRepository) replaceRollupLocked(old, updated *domain.Bet) {
+	if old.Status == updated.Status && old.Payout == updated.Payout {
+		return
+	}
+
+	rollup := r.rollups[rollupKeyOf(updated)]
+	rollup.totals.Remove(*old)
+	rollup.totals.Add(*updated)
+}
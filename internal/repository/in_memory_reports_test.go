@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSummaryFiltersByCurrencyAndOperator(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryBetRepository()
+	hour := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	place := func(userID int64, amount float64, currency, operator string, minute int) *domain.Bet {
+		t.Helper()
+		bet := domain.NewBet(userID, amount, 2)
+		bet.Currency, bet.Operator = currency, operator
+		bet.CreatedAt = hour.Add(time.Duration(minute) * time.Minute)
+		if err := repo.Create(ctx, bet); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return bet
+	}
+
+	won := place(1, 10, "EUR", "acme", 5)
+	place(2, 20, "EUR", "globex", 10)
+	place(1, 30, "USD", "acme", 15)
+	place(3, 40, "EUR", "", 70)
+
+	settled := *won
+	settledAt := hour.Add(20 * time.Minute)
+	settled.Status, settled.Payout, settled.SettledAt = domain.BetStatusWon, 20, &settledAt
+	if err := repo.Update(ctx, &settled); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		operator string
+		bets     int
+		players  int
+		turnover float64
+		payouts  float64
+		buckets  []int
+	}{
+		{name: "unfiltered", bets: 4, players: 3, turnover: 100, payouts: 20, buckets: []int{3, 1}},
+		{name: "currency", currency: "EUR", bets: 3, players: 3, turnover: 70, payouts: 20, buckets: []int{2, 1}},
+		{name: "operator", operator: "acme", bets: 2, players: 1, turnover: 40, payouts: 20, buckets: []int{2, 0}},
+		{name: "currency and operator", currency: "USD", operator: "acme", bets: 1, players: 1, turnover: 30, buckets: []int{1, 0}},
+		{name: "no match", currency: "GBP", buckets: []int{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := repo.Summary(ctx, domain.ReportRequest{
+				From:        hour,
+				To:          hour.Add(2 * time.Hour),
+				Granularity: domain.GranularityHour,
+				Currency:    tt.currency,
+				Operator:    tt.operator,
+			})
+			if err != nil {
+				t.Fatalf("Summary: %v", err)
+			}
+
+			if report.Totals.Bets != tt.bets || report.Totals.Players != tt.players {
+				t.Errorf("bets, players = %d, %d, want %d, %d", report.Totals.Bets, report.Totals.Players, tt.bets, tt.players)
+			}
+			if report.Totals.Turnover != tt.turnover || report.Totals.Payouts != tt.payouts {
+				t.Errorf("turnover, payouts = %v, %v, want %v, %v", report.Totals.Turnover, report.Totals.Payouts, tt.turnover, tt.payouts)
+			}
+			if len(report.Buckets) != len(tt.buckets) {
+				t.Fatalf("got %d buckets, want %d", len(report.Buckets), len(tt.buckets))
+			}
+			for i, want := range tt.buckets {
+				if got := report.Buckets[i].Bets; got != want {
+					t.Errorf("bucket %d has %d bets, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type userStats struct {
+	total domain.UserStats
+	days  map[time.Time]*domain.UserStats
+}
+
+func (r *inMemoryBetRepository) UserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error) {
+	ctx, span := startSpan(ctx, "BetRepository.UserStats", attribute.Int64("bet.user_id", req.UserID))
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return domain.UserStats{}, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := domain.UserStats{UserID: req.UserID}
+	stats, exists := r.stats[req.UserID]
+	if !exists {
+		return result, nil
+	}
+
+	if req.From == nil && req.To == nil {
+		result.Merge(stats.total)
+		return result, nil
+	}
+
+	for day, dayStats := range stats.days {
+		if req.From != nil && day.Before(*req.From) {
+			continue
+		}
+		if req.To != nil && day.After(*req.To) {
+			continue
+		}
+		result.Merge(*dayStats)
+	}
+
+	span.SetAttributes(attribute.Int("bets.count", result.Bets))
+
+	return result, nil
+}
+
+func (r *inMemoryBetRepository) aggregateLocked(bet *domain.Bet) {
+	r.addStatsLocked(bet)
+	r.addRollupLocked(bet)
+}
+
+func (r *inMemoryBetRepository) reaggregateLocked(old, updated *domain.Bet) {
+	r.replaceStatsLocked(old, updated)
+	r.replaceRollupLocked(old, updated)
+}
+
+func (r *inMemoryBetRepository) addStatsLocked(bet *domain.Bet) {
+	stats, exists := r.stats[bet.UserID]
+	if !exists {
+		stats = &userStats{
+			total: domain.UserStats{UserID: bet.UserID},
+			days:  make(map[time.Time]*domain.UserStats),
+		}
+		r.stats[bet.UserID] = stats
+	}
+
+	day := domain.StatsDay(bet.CreatedAt)
+	dayStats, exists := stats.days[day]
+	if !exists {
+		dayStats = &domain.UserStats{UserID: bet.UserID}
+		stats.days[day] = dayStats
+	}
+
+	stats.total.Add(*bet)
+	dayStats.Add(*bet)
+}
+
+func (r *inMemoryBetRepository) replaceStatsLocked(old, updated *domain.Bet) {
+	if old.Status == updated.Status && old.Payout == updated.Payout {
+		return
+	}
+
+	stats := r.stats[updated.UserID]
+	day := domain.StatsDay(updated.CreatedAt)
+
+	if stats.total.Remove(*old) {
+		stats.total.Add(*updated)
+	} else {
+		stats.total = r.rebuildStatsLocked(updated.UserID, nil)
+	}
+
+	if stats.days[day].Remove(*old) {
+		stats.days[day].Add(*updated)
+	} else {
+		rebuilt := r.rebuildStatsLocked(updated.UserID, &day)
+		stats.days[day] = &rebuilt
+	}
+}
+
+func (r *inMemoryBetRepository) rebuildStatsLocked(userID int64, day *time.Time) domain.UserStats {
+	r.muIndex.RLock()
+	betIDs := r.userIDIndex[userID]
+	r.muIndex.RUnlock()
+
+	stats := domain.UserStats{UserID: userID}
+	for _, betID := range betIDs {
+		bet, exists := r.bets[betID]
+		if !exists {
+			continue
+		}
+		if day != nil && !domain.StatsDay(bet.CreatedAt).Equal(*day) {
+			continue
+		}
+		stats.Add(*bet)
+	}
+	return stats
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUserStatsRollsUpAcrossUTCDayBoundary(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryBetRepository()
+	midnight := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 60*60)
+
+	place := func(amount float64, createdAt time.Time) *domain.Bet {
+		t.Helper()
+		bet := domain.NewBet(1, amount, 2)
+		bet.CreatedAt = createdAt
+		if err := repo.Create(ctx, bet); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return bet
+	}
+
+	lateNight := place(10, midnight.Add(-30*time.Minute))
+	place(20, midnight.Add(-time.Nanosecond))
+	place(40, midnight.In(berlin).Add(-10*time.Minute))
+	place(80, midnight)
+	place(160, midnight.Add(90*time.Minute).In(berlin))
+
+	settled := *lateNight
+	settledAt := midnight.Add(15 * time.Minute)
+	settled.Status, settled.Payout, settled.SettledAt = domain.BetStatusWon, 20, &settledAt
+	if err := repo.Update(ctx, &settled); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	day1 := midnight.Add(-24 * time.Hour)
+	day2 := midnight
+
+	tests := []struct {
+		name    string
+		from    *time.Time
+		to      *time.Time
+		bets    int
+		staked  float64
+		won     int
+		paidOut float64
+	}{
+		{name: "all time", bets: 5, staked: 310, won: 1, paidOut: 20},
+		{name: "day before midnight", from: &day1, to: &day1, bets: 3, staked: 70, won: 1, paidOut: 20},
+		{name: "day after midnight", from: &day2, to: &day2, bets: 2, staked: 240},
+		{name: "from the second day", from: &day2, bets: 2, staked: 240},
+		{name: "up to the first day", to: &day1, bets: 3, staked: 70, won: 1, paidOut: 20},
+		{name: "both days", from: &day1, to: &day2, bets: 5, staked: 310, won: 1, paidOut: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := repo.UserStats(ctx, domain.UserStatsRequest{UserID: 1, From: tt.from, To: tt.to})
+			if err != nil {
+				t.Fatalf("UserStats: %v", err)
+			}
+			if staked := stats.Wagered + stats.PendingWagered; stats.Bets != tt.bets || staked != tt.staked {
+				t.Errorf("bets, staked = %d, %v, want %d, %v", stats.Bets, staked, tt.bets, tt.staked)
+			}
+			if stats.Won != tt.won || stats.PaidOut != tt.paidOut {
+				t.Errorf("won, paid out = %d, %v, want %d, %v", stats.Won, stats.PaidOut, tt.won, tt.paidOut)
+			}
+		})
+	}
+}
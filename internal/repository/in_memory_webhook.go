@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+const maxDeliveriesPerWebhook = 1000
+
+type inMemoryWebhookRepository struct {
+	webhooks   map[string]*domain.Webhook
+	deliveries map[string][]domain.WebhookDelivery
+	mu         sync.RWMutex
+}
+
+func NewInMemoryWebhookRepository() WebhookRepository {
+	return &inMemoryWebhookRepository{
+		webhooks:   make(map[string]*domain.Webhook),
+		deliveries: make(map[string][]domain.WebhookDelivery),
+	}
+}
+
+func (r *inMemoryWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.ID] = copyWebhook(webhook)
+	return nil
+}
+
+func (r *inMemoryWebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[webhook.ID]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	r.webhooks[webhook.ID] = copyWebhook(webhook)
+	return nil
+}
+
+func (r *inMemoryWebhookRepository) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return copyWebhook(webhook), nil
+}
+
+func (r *inMemoryWebhookRepository) ListActive(ctx context.Context, eventType string) ([]domain.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]domain.Webhook, 0)
+	for _, webhook := range r.webhooks {
+		if webhook.Active && webhook.Subscribes(eventType) {
+			webhooks = append(webhooks, *copyWebhook(webhook))
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+func (r *inMemoryWebhookRepository) AddDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[delivery.WebhookID]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	deliveries := append(r.deliveries[delivery.WebhookID], *delivery)
+	if len(deliveries) > maxDeliveriesPerWebhook {
+		deliveries = deliveries[len(deliveries)-maxDeliveriesPerWebhook:]
+	}
+	r.deliveries[delivery.WebhookID] = deliveries
+
+	return nil
+}
+
+func (r *inMemoryWebhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, exists := r.webhooks[webhookID]; !exists {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	deliveries := r.deliveries[webhookID]
+	if limit <= 0 || limit > len(deliveries) {
+		limit = len(deliveries)
+	}
+
+	result := make([]domain.WebhookDelivery, 0, limit)
+	for i := len(deliveries) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, deliveries[i])
+	}
+
+	return result, nil
+}
+
+func copyWebhook(webhook *domain.Webhook) *domain.Webhook {
+	webhookCopy := *webhook
+	webhookCopy.Events = append([]string(nil), webhook.Events...)
+	return &webhookCopy
+}
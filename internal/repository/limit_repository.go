@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+)
+
+type LimitRepository interface {
+	Get(ctx context.Context, userID int64) (domain.UserLimits, error)
+	Update(ctx context.Context, userID int64, update func(*domain.UserLimits) error) (domain.UserLimits, error)
+	List(ctx context.Context) ([]domain.UserLimits, error)
+	Import(ctx context.Context, limits []domain.UserLimits) error
+}
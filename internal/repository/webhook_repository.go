@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"bet/internal/domain"
+	"context"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	Update(ctx context.Context, webhook *domain.Webhook) error
+	GetByID(ctx context.Context, id string) (*domain.Webhook, error)
+	ListActive(ctx context.Context, eventType string) ([]domain.Webhook, error)
+	AddDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error)
+}
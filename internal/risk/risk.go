@@ -0,0 +1,275 @@
+package risk
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultThrottleRatio       = 0.8
+	defaultThrottledCrashPoint = 10.0
+	minCappedCrashPoint        = 1.01
+	tolerance                  = 1e-6
+)
+
+type Config struct {
+	Mode                domain.RiskMode
+	RoundLiability      float64
+	UserLiability       float64
+	ThrottleRatio       float64
+	ThrottledCrashPoint float64
+	Logger              *zap.Logger
+	Now                 func() time.Time
+}
+
+type position struct {
+	userID   int64
+	stake    float64
+	exposure float64
+}
+
+type book struct {
+	positions   map[string]position
+	stakes      float64
+	exposure    float64
+	throttledAt *time.Time
+}
+
+type userExposure struct {
+	bets     int
+	exposure float64
+}
+
+type Manager struct {
+	config Config
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	rounds map[string]*book
+	users  map[int64]*userExposure
+}
+
+func NewManager(config Config) *Manager {
+	if config.Mode != domain.RiskModeCap {
+		config.Mode = domain.RiskModeReject
+	}
+	if config.ThrottleRatio <= 0 || config.ThrottleRatio > 1 {
+		config.ThrottleRatio = defaultThrottleRatio
+	}
+	if config.ThrottledCrashPoint <= 1 || config.ThrottledCrashPoint > domain.MaxBetCrashPoint {
+		config.ThrottledCrashPoint = defaultThrottledCrashPoint
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &Manager{
+		config: config,
+		logger: config.Logger,
+		rounds: make(map[string]*book),
+		users:  make(map[int64]*userExposure),
+	}
+}
+
+func (m *Manager) Reserve(roundID string, bets []*domain.Bet) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := m.bookLocked(roundID)
+	throttledAt := b.throttledAt
+	for i, bet := range bets {
+		capped, err := m.admitLocked(b, bet)
+		if err != nil {
+			for _, reserved := range bets[:i] {
+				m.releaseLocked(b, reserved.ID)
+			}
+			b.throttledAt = throttledAt
+			metrics.RiskBetsRejected.Add(1)
+			return err
+		}
+		if capped {
+			metrics.RiskBetsCapped.Add(1)
+		}
+		m.addLocked(roundID, b, bet)
+	}
+	return nil
+}
+
+func (m *Manager) Release(roundID, betID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if b, ok := m.rounds[roundID]; ok {
+		m.releaseLocked(b, betID)
+	}
+}
+
+func (m *Manager) Settle(roundID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.rounds[roundID]
+	if !ok {
+		return
+	}
+	for betID := range b.positions {
+		m.releaseLocked(b, betID)
+	}
+	delete(m.rounds, roundID)
+}
+
+func (m *Manager) Exposure(roundIDs []string) domain.ExposureReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := domain.ExposureReport{
+		Mode:                m.config.Mode,
+		RoundLiability:      m.config.RoundLiability,
+		UserLiability:       m.config.UserLiability,
+		ThrottleRatio:       m.config.ThrottleRatio,
+		ThrottledCrashPoint: m.config.ThrottledCrashPoint,
+		Rounds:              make([]domain.RoundExposure, len(roundIDs)),
+		TopUsers:            []domain.UserExposure{},
+	}
+	for i, roundID := range roundIDs {
+		round := domain.RoundExposure{RoundID: roundID, MaxCrashPoint: domain.MaxBetCrashPoint}
+		if b, ok := m.rounds[roundID]; ok {
+			round.Bets = len(b.positions)
+			round.Stakes = b.stakes
+			round.Exposure = b.exposure
+			round.MaxCrashPoint = m.maxCrashPointLocked(b)
+			round.ThrottledAt = b.throttledAt
+		}
+		report.Exposure += round.Exposure
+		report.Rounds[i] = round
+	}
+
+	for userID, u := range m.users {
+		report.TopUsers = append(report.TopUsers, domain.UserExposure{UserID: userID, Bets: u.bets, Exposure: u.exposure})
+	}
+	sort.Slice(report.TopUsers, func(i, j int) bool {
+		a, b := report.TopUsers[i], report.TopUsers[j]
+		if a.Exposure != b.Exposure {
+			return a.Exposure > b.Exposure
+		}
+		return a.UserID < b.UserID
+	})
+	if len(report.TopUsers) > domain.ExposureTopUsers {
+		report.TopUsers = report.TopUsers[:domain.ExposureTopUsers]
+	}
+
+	return report
+}
+
+func (m *Manager) bookLocked(roundID string) *book {
+	b, ok := m.rounds[roundID]
+	if !ok {
+		b = &book{positions: make(map[string]position)}
+		m.rounds[roundID] = b
+	}
+	return b
+}
+
+func (m *Manager) maxCrashPointLocked(b *book) float64 {
+	if b.throttledAt != nil {
+		return m.config.ThrottledCrashPoint
+	}
+	return domain.MaxBetCrashPoint
+}
+
+func (m *Manager) admitLocked(b *book, bet *domain.Bet) (bool, error) {
+	capped := false
+	if limit := m.maxCrashPointLocked(b); bet.CrashPoint > limit {
+		if m.config.Mode != domain.RiskModeCap {
+			return false, domain.NewCrashPointRestrictedError(limit)
+		}
+		bet.CrashPoint = limit
+		capped = true
+	}
+
+	headroom := math.Inf(1)
+	var breach error
+	if m.config.RoundLiability > 0 {
+		headroom = m.config.RoundLiability - b.exposure
+		breach = domain.NewRoundLiabilityError(m.config.RoundLiability, max(headroom, 0))
+	}
+	if m.config.UserLiability > 0 {
+		remaining := m.config.UserLiability
+		if u, ok := m.users[bet.UserID]; ok {
+			remaining -= u.exposure
+		}
+		if remaining < headroom {
+			headroom = remaining
+			breach = domain.NewUserExposureError(m.config.UserLiability, max(remaining, 0))
+		}
+	}
+
+	if bet.PotentialPayout() <= headroom+tolerance {
+		return capped, nil
+	}
+	if m.config.Mode == domain.RiskModeCap {
+		if crashPoint := math.Floor(headroom/bet.Amount*100) / 100; crashPoint >= minCappedCrashPoint {
+			bet.CrashPoint = crashPoint
+			return true, nil
+		}
+	}
+	return false, breach
+}
+
+func (m *Manager) addLocked(roundID string, b *book, bet *domain.Bet) {
+	p := position{userID: bet.UserID, stake: bet.Amount, exposure: bet.PotentialPayout()}
+	b.positions[bet.ID] = p
+	b.stakes += p.stake
+	b.exposure += p.exposure
+
+	u, ok := m.users[p.userID]
+	if !ok {
+		u = &userExposure{}
+		m.users[p.userID] = u
+	}
+	u.bets++
+	u.exposure += p.exposure
+
+	if b.throttledAt != nil || m.config.RoundLiability <= 0 || b.exposure < m.config.ThrottleRatio*m.config.RoundLiability {
+		return
+	}
+	now := m.config.Now()
+	b.throttledAt = &now
+	metrics.RiskRoundsThrottled.Add(1)
+	m.logger.Warn("round exposure is high, lowering the maximum crash point",
+		zap.String("round_id", roundID),
+		zap.Float64("exposure", b.exposure),
+		zap.Float64("round_liability", m.config.RoundLiability),
+		zap.Float64("max_crash_point", m.config.ThrottledCrashPoint),
+	)
+}
+
+func (m *Manager) releaseLocked(b *book, betID string) {
+	p, ok := b.positions[betID]
+	if !ok {
+		return
+	}
+	delete(b.positions, betID)
+	b.stakes -= p.stake
+	b.exposure -= p.exposure
+	if len(b.positions) == 0 {
+		b.stakes, b.exposure = 0, 0
+	}
+
+	if u, ok := m.users[p.userID]; ok {
+		u.bets--
+		u.exposure -= p.exposure
+		if u.bets == 0 {
+			delete(m.users, p.userID)
+		}
+	}
+}
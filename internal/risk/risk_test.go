@@ -0,0 +1,238 @@
+package risk
+
+import (
+	"bet/internal/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testRound = "round-1"
+
+var testNow = time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+
+func newTestManager(mode domain.RiskMode, roundLiability, userLiability float64) *Manager {
+	return NewManager(Config{
+		Mode:           mode,
+		RoundLiability: roundLiability,
+		UserLiability:  userLiability,
+		Now:            func() time.Time { return testNow },
+	})
+}
+
+func riskReason(t *testing.T, err error) string {
+	t.Helper()
+
+	var riskErr *domain.RiskError
+	if !errors.As(err, &riskErr) {
+		t.Fatalf("error = %v, want a risk error", err)
+	}
+	return riskErr.Reason
+}
+
+func roundExposure(m *Manager) domain.RoundExposure {
+	return m.Exposure([]string{testRound}).Rounds[0]
+}
+
+func TestManagerReserveRoundLiability(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           domain.RiskMode
+		amount         float64
+		crashPoint     float64
+		wantReason     string
+		wantCrashPoint float64
+		wantExposure   float64
+	}{
+		{
+			name:           "fits under the cap",
+			mode:           domain.RiskModeReject,
+			amount:         100,
+			crashPoint:     5,
+			wantCrashPoint: 5,
+			wantExposure:   1000,
+		},
+		{
+			name:         "reject mode refuses the bet",
+			mode:         domain.RiskModeReject,
+			amount:       100,
+			crashPoint:   6,
+			wantReason:   "ROUND_LIABILITY_EXCEEDED",
+			wantExposure: 500,
+		},
+		{
+			name:           "cap mode lowers the crash point to the headroom",
+			mode:           domain.RiskModeCap,
+			amount:         100,
+			crashPoint:     6,
+			wantCrashPoint: 5,
+			wantExposure:   1000,
+		},
+		{
+			name:           "cap mode rounds the crash point down",
+			mode:           domain.RiskModeCap,
+			amount:         300,
+			crashPoint:     3,
+			wantCrashPoint: 1.66,
+			wantExposure:   998,
+		},
+		{
+			name:         "cap mode refuses when the capped crash point is too low",
+			mode:         domain.RiskModeCap,
+			amount:       1000,
+			crashPoint:   2,
+			wantReason:   "ROUND_LIABILITY_EXCEEDED",
+			wantExposure: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(Config{Mode: tt.mode, RoundLiability: 1000, ThrottleRatio: 1, Now: func() time.Time { return testNow }})
+			if err := m.Reserve(testRound, []*domain.Bet{domain.NewBet(1, 100, 5)}); err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+
+			bet := domain.NewBet(2, tt.amount, tt.crashPoint)
+			err := m.Reserve(testRound, []*domain.Bet{bet})
+			if tt.wantReason != "" {
+				if reason := riskReason(t, err); reason != tt.wantReason {
+					t.Fatalf("reason = %s, want %s", reason, tt.wantReason)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Reserve: %v", err)
+				}
+				if bet.CrashPoint != tt.wantCrashPoint {
+					t.Errorf("crash point = %v, want %v", bet.CrashPoint, tt.wantCrashPoint)
+				}
+			}
+
+			if got := roundExposure(m).Exposure; got != tt.wantExposure {
+				t.Errorf("round exposure = %v, want %v", got, tt.wantExposure)
+			}
+		})
+	}
+}
+
+func TestManagerReserveUserLiability(t *testing.T) {
+	m := newTestManager(domain.RiskModeReject, 0, 300)
+
+	if err := m.Reserve(testRound, []*domain.Bet{domain.NewBet(1, 100, 2)}); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	err := m.Reserve("round-2", []*domain.Bet{domain.NewBet(1, 100, 2)})
+	if reason := riskReason(t, err); reason != "USER_EXPOSURE_EXCEEDED" {
+		t.Fatalf("reason = %s, want USER_EXPOSURE_EXCEEDED across rounds", reason)
+	}
+	var riskErr *domain.RiskError
+	errors.As(err, &riskErr)
+	if riskErr.Remaining != 100 {
+		t.Errorf("remaining = %v, want 100", riskErr.Remaining)
+	}
+
+	if err := m.Reserve(testRound, []*domain.Bet{domain.NewBet(2, 100, 2)}); err != nil {
+		t.Fatalf("other user: %v", err)
+	}
+}
+
+func TestManagerThrottlesRound(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           domain.RiskMode
+		wantReason     string
+		wantCrashPoint float64
+	}{
+		{name: "reject mode", mode: domain.RiskModeReject, wantReason: "CRASH_POINT_RESTRICTED"},
+		{name: "cap mode", mode: domain.RiskModeCap, wantCrashPoint: defaultThrottledCrashPoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(tt.mode, 10000, 0)
+			if err := m.Reserve(testRound, []*domain.Bet{domain.NewBet(1, 100, 80)}); err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+
+			round := roundExposure(m)
+			if round.ThrottledAt == nil || !round.ThrottledAt.Equal(testNow) {
+				t.Fatalf("throttled at = %v, want %s once exposure reached the throttle ratio", round.ThrottledAt, testNow)
+			}
+			if round.MaxCrashPoint != defaultThrottledCrashPoint {
+				t.Fatalf("max crash point = %v, want %v", round.MaxCrashPoint, defaultThrottledCrashPoint)
+			}
+
+			bet := domain.NewBet(2, 1, 20)
+			err := m.Reserve(testRound, []*domain.Bet{bet})
+			if tt.wantReason != "" {
+				if reason := riskReason(t, err); reason != tt.wantReason {
+					t.Fatalf("reason = %s, want %s", reason, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reserve: %v", err)
+			}
+			if bet.CrashPoint != tt.wantCrashPoint {
+				t.Fatalf("crash point = %v, want %v", bet.CrashPoint, tt.wantCrashPoint)
+			}
+		})
+	}
+}
+
+func TestManagerReserveRollsBackFailedBatch(t *testing.T) {
+	m := newTestManager(domain.RiskModeReject, 1000, 0)
+
+	err := m.Reserve(testRound, []*domain.Bet{
+		domain.NewBet(1, 100, 8),
+		domain.NewBet(2, 100, 5),
+	})
+	if reason := riskReason(t, err); reason != "ROUND_LIABILITY_EXCEEDED" {
+		t.Fatalf("reason = %s, want ROUND_LIABILITY_EXCEEDED", reason)
+	}
+
+	round := roundExposure(m)
+	if round.Bets != 0 || round.Exposure != 0 || round.Stakes != 0 {
+		t.Errorf("round = %+v, want the batch fully released", round)
+	}
+	if round.ThrottledAt != nil {
+		t.Errorf("throttled at = %s, want the throttle from the failed batch rolled back", *round.ThrottledAt)
+	}
+	if users := m.Exposure(nil).TopUsers; len(users) != 0 {
+		t.Errorf("user exposure = %+v, want none", users)
+	}
+}
+
+func TestManagerReleaseAndSettle(t *testing.T) {
+	m := newTestManager(domain.RiskModeReject, 1000, 500)
+
+	first := domain.NewBet(1, 100, 4)
+	second := domain.NewBet(1, 50, 2)
+	if err := m.Reserve(testRound, []*domain.Bet{first, second}); err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+
+	m.Release(testRound, first.ID)
+	m.Release(testRound, first.ID)
+
+	round := roundExposure(m)
+	if round.Bets != 1 || round.Exposure != 100 || round.Stakes != 50 {
+		t.Fatalf("round after release = %+v, want only the second bet", round)
+	}
+	if users := m.Exposure(nil).TopUsers; len(users) != 1 || users[0].Exposure != 100 || users[0].Bets != 1 {
+		t.Fatalf("user exposure after release = %+v, want 100 over one bet", users)
+	}
+
+	if err := m.Reserve(testRound, []*domain.Bet{domain.NewBet(1, 100, 4)}); err != nil {
+		t.Fatalf("Reserve after release: %v", err)
+	}
+
+	m.Settle(testRound)
+	if round := roundExposure(m); round.Bets != 0 || round.Exposure != 0 {
+		t.Fatalf("round after settle = %+v, want empty", round)
+	}
+	if users := m.Exposure(nil).TopUsers; len(users) != 0 {
+		t.Fatalf("user exposure after settle = %+v, want none", users)
+	}
+}
@@ -4,53 +4,347 @@ import (
 	"bet/internal/domain"
 	"bet/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
+var tracer = otel.Tracer("bet/internal/service")
+
 type BetServiceUseCase interface {
-	CreateBet(ctx context.Context, userID int64, amount, crashPoint float64) (*domain.Bet, error)
+	CreateBet(ctx context.Context, req domain.PlaceBetRequest, client domain.Client, actor domain.Actor) (*domain.Bet, error)
+	CreateBets(ctx context.Context, requests []domain.PlaceBetRequest, client domain.Client, actor domain.Actor) ([]*domain.Bet, error)
+	CreateBetsPerItem(ctx context.Context, requests []domain.PlaceBetRequest, client domain.Client, actor domain.Actor) []domain.PlaceBetResult
+	ImportBets(ctx context.Context, bets []domain.Bet) (domain.ImportResult, error)
 	GetBetByID(ctx context.Context, id string) (*domain.Bet, error)
 	ListBets(ctx context.Context, req domain.ListBetsRequest) (domain.ListBetsResponse, error)
+	ExportBets(ctx context.Context, req domain.ExportBetsRequest) iter.Seq2[domain.Bet, error]
+	GetUserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error)
+	CancelBet(ctx context.Context, id string, actor domain.Actor) (*domain.Bet, error)
+	VoidBet(ctx context.Context, id string, req domain.VoidBetRequest, actor domain.Actor) (*domain.Bet, error)
+	GetBetAudit(ctx context.Context, id string) ([]domain.AuditEntry, error)
+}
+
+type RoundManager interface {
+	PlaceBet(ctx context.Context, bet *domain.Bet) error
+	PlaceBets(ctx context.Context, bets []*domain.Bet) error
+	CancelBet(ctx context.Context, id string, actor domain.Actor) (*domain.Bet, error)
+	VoidBet(ctx context.Context, id string, req domain.VoidBetRequest, actor domain.Actor) (*domain.Bet, error)
+}
+
+type LimitChecker interface {
+	CheckBet(ctx context.Context, userID int64, amount float64) (release func(), err error)
+}
+
+type FraudScreener interface {
+	Screen(ctx context.Context, attempts []domain.BetAttempt) ([]domain.FraudAssessment, error)
+	Record(ctx context.Context, assessments []domain.FraudAssessment, bets []*domain.Bet)
 }
 
 type BetService struct {
-	repo repository.BetRepository
+	repo     repository.BetRepository
+	rounds   RoundManager
+	limits   LimitChecker
+	fraud    FraudScreener
+	currency string
 }
 
-func NewBetService(repo repository.BetRepository) *BetService {
+func NewBetService(repo repository.BetRepository, rounds RoundManager, limits LimitChecker, fraud FraudScreener, defaultCurrency string) *BetService {
 	return &BetService{
-		repo: repo,
+		repo:     repo,
+		rounds:   rounds,
+		limits:   limits,
+		fraud:    fraud,
+		currency: defaultCurrency,
 	}
 }
 
-func (s *BetService) CreateBet(ctx context.Context, userID int64, amount, crashPoint float64) (*domain.Bet, error) {
+func (s *BetService) CreateBet(ctx context.Context, req domain.PlaceBetRequest, client domain.Client, actor domain.Actor) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "BetService.CreateBet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("bet.user_id", req.UserID),
+		attribute.Float64("bet.amount", req.Amount),
+		attribute.Float64("bet.crash_point", req.CrashPoint),
+	)
+
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	bet := domain.NewBet(userID, amount, crashPoint)
+	if !actor.ActsFor(req.UserID) {
+		span.RecordError(domain.ErrNotUsersKey)
+		return nil, domain.ErrNotUsersKey
+	}
+
+	release, err := s.limits.CheckBet(ctx, req.UserID, req.Amount)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	defer release()
+
+	bet := s.newBet(req, actor)
+	span.SetAttributes(
+		attribute.String("bet.id", bet.ID),
+		attribute.String("bet.currency", bet.Currency),
+	)
 
-	if err := s.repo.Create(ctx, bet); err != nil {
+	assessments, err := s.fraud.Screen(ctx, []domain.BetAttempt{newBetAttempt(bet, client)})
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.rounds.PlaceBet(ctx, bet); err != nil {
+		span.RecordError(err)
+		if domain.IsRiskError(err) {
+			return nil, err
+		}
+		span.SetStatus(codes.Error, "failed to create bet")
 		return nil, domain.NewRepositoryError("CreateBet", "failed to create bet", err)
 	}
 
+	span.SetAttributes(attribute.String("bet.round_id", bet.RoundID))
+	s.fraud.Record(ctx, assessments, []*domain.Bet{bet})
+
 	return bet, nil
 }
 
+func (s *BetService) CreateBets(ctx context.Context, requests []domain.PlaceBetRequest, client domain.Client, actor domain.Actor) ([]*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "BetService.CreateBets")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("bets.count", len(requests)))
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if len(requests) == 0 {
+		return []*domain.Bet{}, nil
+	}
+
+	var users []int64
+	totals := make(map[int64]float64)
+	for _, req := range requests {
+		if !actor.ActsFor(req.UserID) {
+			span.RecordError(domain.ErrNotUsersKey)
+			return nil, domain.ErrNotUsersKey
+		}
+		if _, exists := totals[req.UserID]; !exists {
+			users = append(users, req.UserID)
+		}
+		totals[req.UserID] += req.Amount
+	}
+	slices.Sort(users)
+	for _, userID := range users {
+		release, err := s.limits.CheckBet(ctx, userID, totals[userID])
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		defer release()
+	}
+
+	bets := make([]*domain.Bet, len(requests))
+	attempts := make([]domain.BetAttempt, len(requests))
+	for i, req := range requests {
+		bets[i] = s.newBet(req, actor)
+		attempts[i] = newBetAttempt(bets[i], client)
+	}
+
+	assessments, err := s.fraud.Screen(ctx, attempts)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := s.rounds.PlaceBets(ctx, bets); err != nil {
+		span.RecordError(err)
+		if domain.IsRiskError(err) {
+			return nil, err
+		}
+		span.SetStatus(codes.Error, "failed to create bets")
+		return nil, domain.NewRepositoryError("CreateBets", "failed to create bets", err)
+	}
+
+	s.fraud.Record(ctx, assessments, bets)
+
+	return bets, nil
+}
+
+func (s *BetService) CreateBetsPerItem(ctx context.Context, requests []domain.PlaceBetRequest, client domain.Client, actor domain.Actor) []domain.PlaceBetResult {
+	ctx, span := tracer.Start(ctx, "BetService.CreateBetsPerItem")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("bets.count", len(requests)))
+
+	results := make([]domain.PlaceBetResult, len(requests))
+	created := 0
+	for i, req := range requests {
+		bet, err := s.CreateBet(ctx, req, client, actor)
+		results[i] = domain.PlaceBetResult{Bet: bet, Err: err}
+		if err == nil {
+			created++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("bets.created", created))
+
+	return results
+}
+
+func (s *BetService) newBet(req domain.PlaceBetRequest, actor domain.Actor) *domain.Bet {
+	bet := domain.NewBet(req.UserID, req.Amount, req.CrashPoint)
+	bet.Currency = req.Currency
+	if bet.Currency == "" {
+		bet.Currency = s.currency
+	}
+	bet.Operator = actor.OperatorName
+	return bet
+}
+
+func newBetAttempt(bet *domain.Bet, client domain.Client) domain.BetAttempt {
+	return domain.BetAttempt{
+		UserID:     bet.UserID,
+		Amount:     bet.Amount,
+		CrashPoint: bet.CrashPoint,
+		Client:     client,
+		At:         bet.CreatedAt,
+	}
+}
+
+func (s *BetService) ImportBets(ctx context.Context, bets []domain.Bet) (domain.ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "BetService.ImportBets")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("bets.count", len(bets)))
+
+	if ctx.Err() != nil {
+		return domain.ImportResult{}, ctx.Err()
+	}
+
+	for i := range bets {
+		if bets[i].Currency == "" {
+			bets[i].Currency = s.currency
+		}
+	}
+
+	duplicates, err := s.repo.Import(ctx, bets)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to import bets")
+		return domain.ImportResult{}, domain.NewRepositoryError("ImportBets", "failed to import bets", err)
+	}
+
+	span.SetAttributes(attribute.Int("bets.duplicates", len(duplicates)))
+
+	return domain.ImportResult{
+		Imported:   len(bets) - len(duplicates),
+		Duplicates: duplicates,
+	}, nil
+}
+
+func (s *BetService) CancelBet(ctx context.Context, id string, actor domain.Actor) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "BetService.CancelBet")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bet.id", id))
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	bet, err := s.rounds.CancelBet(ctx, id, actor)
+	if err != nil {
+		span.RecordError(err)
+		if domain.IsConflictError(err) || domain.IsForbiddenError(err) {
+			return nil, err
+		}
+		span.SetStatus(codes.Error, "failed to cancel bet")
+		return nil, domain.NewRepositoryError("CancelBet", fmt.Sprintf("failed to cancel bet %s", id), err)
+	}
+
+	return bet, nil
+}
+
+func (s *BetService) VoidBet(ctx context.Context, id string, req domain.VoidBetRequest, actor domain.Actor) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "BetService.VoidBet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bet.id", id),
+		attribute.String("bet.void_reason", string(req.Reason)),
+	)
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	bet, err := s.rounds.VoidBet(ctx, id, req, actor)
+	if err != nil {
+		span.RecordError(err)
+		if domain.IsConflictError(err) {
+			return nil, err
+		}
+		span.SetStatus(codes.Error, "failed to void bet")
+		return nil, domain.NewRepositoryError("VoidBet", fmt.Sprintf("failed to void bet %s", id), err)
+	}
+
+	return bet, nil
+}
+
+func (s *BetService) GetBetAudit(ctx context.Context, id string) ([]domain.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "BetService.GetBetAudit")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bet.id", id))
+
+	if _, err := s.GetBetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListAudit(ctx, "bet", id)
+	if err != nil {
+		span.RecordError(err)
+		return nil, domain.NewRepositoryError("GetBetAudit", "failed to list audit entries", err)
+	}
+
+	return entries, nil
+}
+
 func (s *BetService) GetBetByID(ctx context.Context, id string) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "BetService.GetBetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("bet.id", id))
+
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
 	bet, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		span.RecordError(err)
 		return nil, domain.NewRepositoryError("GetBetByID", fmt.Sprintf("failed to get bet by id %s", id), err)
 	}
 
+	span.SetAttributes(attribute.Int64("bet.user_id", bet.UserID))
+
 	return bet, nil
 }
 
 func (s *BetService) ListBets(ctx context.Context, req domain.ListBetsRequest) (domain.ListBetsResponse, error) {
+	ctx, span := tracer.Start(ctx, "BetService.ListBets")
+	defer span.End()
+
 	if ctx.Err() != nil {
 		return domain.ListBetsResponse{}, ctx.Err()
 	}
@@ -62,10 +356,86 @@ func (s *BetService) ListBets(ctx context.Context, req domain.ListBetsRequest) (
 		req.Sort.Order = "desc"
 	}
 
+	span.SetAttributes(
+		attribute.String("bets.sort_by", req.Sort.SortBy),
+		attribute.String("bets.order", req.Sort.Order),
+	)
+
 	response, err := s.repo.List(ctx, req)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to list bets")
 		return domain.ListBetsResponse{}, domain.NewRepositoryError("ListBets", "failed to list bets", err)
 	}
 
+	span.SetAttributes(
+		attribute.Int("bets.result_count", len(response.Bets)),
+		attribute.Int("bets.total", response.Total),
+	)
+
 	return response, nil
 }
+
+func (s *BetService) GetUserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error) {
+	ctx, span := tracer.Start(ctx, "BetService.GetUserStats")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("bet.user_id", req.UserID))
+
+	if ctx.Err() != nil {
+		return domain.UserStats{}, ctx.Err()
+	}
+
+	stats, err := s.repo.UserStats(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get user stats")
+		return domain.UserStats{}, domain.NewRepositoryError("GetUserStats", fmt.Sprintf("failed to get stats for user %d", req.UserID), err)
+	}
+
+	span.SetAttributes(attribute.Int("bets.count", stats.Bets))
+
+	return stats, nil
+}
+
+func (s *BetService) ExportBets(ctx context.Context, req domain.ExportBetsRequest) iter.Seq2[domain.Bet, error] {
+	if req.Sort.SortBy == "" {
+		req.Sort.SortBy = "created_at"
+	}
+	if req.Sort.Order == "" {
+		req.Sort.Order = "asc"
+	}
+
+	return func(yield func(domain.Bet, error) bool) {
+		ctx, span := tracer.Start(ctx, "BetService.ExportBets")
+		defer span.End()
+
+		span.SetAttributes(
+			attribute.String("bets.sort_by", req.Sort.SortBy),
+			attribute.String("bets.order", req.Sort.Order),
+		)
+
+		rows := 0
+		defer func() {
+			span.SetAttributes(attribute.Int("bets.exported", rows))
+		}()
+
+		for bet, err := range s.repo.Stream(ctx, req) {
+			if err != nil {
+				span.RecordError(err)
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					yield(domain.Bet{}, err)
+					return
+				}
+				span.SetStatus(codes.Error, "failed to export bets")
+				yield(domain.Bet{}, domain.NewRepositoryError("ExportBets", "failed to stream bets", err))
+				return
+			}
+
+			rows++
+			if !yield(bet, nil) {
+				return
+			}
+		}
+	}
+}
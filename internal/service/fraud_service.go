@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"bet/internal/metrics"
+	"bet/internal/repository"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type FraudServiceUseCase interface {
+	ListFlags(ctx context.Context, filter domain.FraudFlagFilter) ([]domain.FraudFlag, error)
+	ReviewFlag(ctx context.Context, id string, review domain.FlagReview) (domain.FraudFlag, error)
+	GetRules(ctx context.Context) (domain.FraudRuleSet, error)
+	ReloadRules(ctx context.Context) (domain.FraudRuleSet, error)
+}
+
+type FraudEvaluator interface {
+	Evaluate(attempts []domain.BetAttempt) []domain.FraudAssessment
+	Record(attempts []domain.BetAttempt)
+	Rules() (domain.FraudRuleSet, error)
+	Reload() (domain.FraudRuleSet, error)
+}
+
+type FraudService struct {
+	repo   repository.FraudFlagRepository
+	engine FraudEvaluator
+	now    func() time.Time
+}
+
+func NewFraudService(repo repository.FraudFlagRepository, engine FraudEvaluator) *FraudService {
+	return &FraudService{
+		repo:   repo,
+		engine: engine,
+		now:    time.Now,
+	}
+}
+
+func (s *FraudService) Screen(ctx context.Context, attempts []domain.BetAttempt) ([]domain.FraudAssessment, error) {
+	ctx, span := tracer.Start(ctx, "FraudService.Screen")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("fraud.attempts", len(attempts)))
+
+	checked := make(map[int64]bool)
+	for _, attempt := range attempts {
+		if checked[attempt.UserID] {
+			continue
+		}
+		checked[attempt.UserID] = true
+
+		held, err := s.repo.HasHold(ctx, attempt.UserID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to check holds")
+			return nil, domain.NewRepositoryError("Screen", "failed to check fraud holds", err)
+		}
+		if held {
+			span.SetAttributes(attribute.Int64("fraud.held_user_id", attempt.UserID))
+			return nil, domain.ErrAccountOnHold
+		}
+	}
+
+	assessments := s.engine.Evaluate(attempts)
+	worst := domain.ActionAllow
+	for _, assessment := range assessments {
+		if assessment.Action.Severity() > worst.Severity() {
+			worst = assessment.Action
+		}
+	}
+
+	span.SetAttributes(attribute.String("fraud.action", string(worst)))
+
+	if worst.Severity() < domain.ActionHold.Severity() {
+		return assessments, nil
+	}
+
+	var flags []domain.FraudFlag
+	for _, assessment := range assessments {
+		if assessment.Action != domain.ActionAllow {
+			flags = append(flags, domain.NewFraudFlag(assessment, ""))
+		}
+	}
+	if err := s.repo.Create(context.WithoutCancel(ctx), flags...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to store fraud flags")
+		return nil, domain.NewRepositoryError("Screen", "failed to store fraud flags", err)
+	}
+
+	if worst == domain.ActionHold {
+		metrics.FraudBetsHeld.Add(int64(len(attempts)))
+		return nil, domain.ErrAccountOnHold
+	}
+	metrics.FraudBetsDeclined.Add(int64(len(attempts)))
+	return nil, domain.ErrBetDeclined
+}
+
+func (s *FraudService) Record(ctx context.Context, assessments []domain.FraudAssessment, bets []*domain.Bet) {
+	ctx, span := tracer.Start(ctx, "FraudService.Record")
+	defer span.End()
+
+	attempts := make([]domain.BetAttempt, len(assessments))
+	var flags []domain.FraudFlag
+	for i, assessment := range assessments {
+		attempts[i] = assessment.Attempt
+		attempts[i].CrashPoint = bets[i].CrashPoint
+		if assessment.Action == domain.ActionFlag {
+			flags = append(flags, domain.NewFraudFlag(assessment, bets[i].ID))
+		}
+	}
+	s.engine.Record(attempts)
+	if len(flags) == 0 {
+		return
+	}
+
+	span.SetAttributes(attribute.Int("fraud.flags", len(flags)))
+
+	if err := s.repo.Create(context.WithoutCancel(ctx), flags...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to store fraud flags")
+		return
+	}
+	metrics.FraudBetsFlagged.Add(int64(len(flags)))
+}
+
+func (s *FraudService) ListFlags(ctx context.Context, filter domain.FraudFlagFilter) ([]domain.FraudFlag, error) {
+	ctx, span := tracer.Start(ctx, "FraudService.ListFlags")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = domain.DefaultFraudFlagLimit
+	}
+
+	span.SetAttributes(
+		attribute.String("fraud.status", string(filter.Status)),
+		attribute.Int("fraud.limit", filter.Limit),
+	)
+
+	flags, err := s.repo.List(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to list fraud flags")
+		return nil, domain.NewRepositoryError("ListFlags", "failed to list fraud flags", err)
+	}
+
+	span.SetAttributes(attribute.Int("fraud.result_count", len(flags)))
+
+	return flags, nil
+}
+
+func (s *FraudService) ReviewFlag(ctx context.Context, id string, review domain.FlagReview) (domain.FraudFlag, error) {
+	ctx, span := tracer.Start(ctx, "FraudService.ReviewFlag")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("fraud.flag_id", id),
+		attribute.String("fraud.status", string(review.Status)),
+	)
+
+	if ctx.Err() != nil {
+		return domain.FraudFlag{}, ctx.Err()
+	}
+
+	flag, err := s.repo.Update(ctx, id, func(flag *domain.FraudFlag) error {
+		reviewedAt := s.now()
+		flag.Status = review.Status
+		flag.Note = review.Note
+		flag.ReviewedAt = &reviewedAt
+		flag.ReviewedBy = review.Actor.ID
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		return domain.FraudFlag{}, domain.NewRepositoryError("ReviewFlag", "failed to review fraud flag", err)
+	}
+
+	return flag, nil
+}
+
+func (s *FraudService) GetRules(ctx context.Context) (domain.FraudRuleSet, error) {
+	_, span := tracer.Start(ctx, "FraudService.GetRules")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return domain.FraudRuleSet{}, ctx.Err()
+	}
+
+	return s.engine.Rules()
+}
+
+func (s *FraudService) ReloadRules(ctx context.Context) (domain.FraudRuleSet, error) {
+	_, span := tracer.Start(ctx, "FraudService.ReloadRules")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return domain.FraudRuleSet{}, ctx.Err()
+	}
+
+	rules, err := s.engine.Reload()
+	if err != nil {
+		span.RecordError(err)
+		return domain.FraudRuleSet{}, err
+	}
+
+	span.SetAttributes(
+		attribute.String("fraud.checksum", rules.Checksum),
+		attribute.Int("fraud.rules", len(rules.Rules)),
+	)
+	return rules, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type LeaderboardServiceUseCase interface {
+	GetLeaderboard(ctx context.Context, req domain.LeaderboardRequest) (domain.Leaderboard, error)
+}
+
+type LeaderboardReader interface {
+	Leaderboard(req domain.LeaderboardRequest) domain.Leaderboard
+}
+
+type LeaderboardService struct {
+	leaderboards LeaderboardReader
+}
+
+func NewLeaderboardService(leaderboards LeaderboardReader) *LeaderboardService {
+	return &LeaderboardService{leaderboards: leaderboards}
+}
+
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, req domain.LeaderboardRequest) (domain.Leaderboard, error) {
+	_, span := tracer.Start(ctx, "LeaderboardService.GetLeaderboard")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("leaderboard.type", string(req.Type)),
+		attribute.String("leaderboard.window", string(req.Window)),
+		attribute.Int("leaderboard.limit", req.Limit),
+	)
+
+	if ctx.Err() != nil {
+		return domain.Leaderboard{}, ctx.Err()
+	}
+
+	board := s.leaderboards.Leaderboard(req)
+	span.SetAttributes(attribute.Int("leaderboard.result_count", len(board.Entries)))
+	return board, nil
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"bet/internal/repository"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LimitsServiceUseCase interface {
+	GetLimits(ctx context.Context, userID int64) (domain.LimitsStatus, error)
+	SetLimit(ctx context.Context, userID int64, limitType domain.LimitType, period domain.LimitPeriod, amount *float64) (domain.LimitsStatus, error)
+	StartCoolOff(ctx context.Context, userID int64, days int) (domain.LimitsStatus, error)
+	SelfExclude(ctx context.Context, userID int64, duration domain.ExclusionDuration) (domain.LimitsStatus, error)
+	RecordDeposit(ctx context.Context, userID int64, amount float64) (domain.LimitsStatus, error)
+}
+
+type StatsReader interface {
+	UserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error)
+}
+
+type LimitsService struct {
+	repo          repository.LimitRepository
+	stats         StatsReader
+	increaseDelay time.Duration
+	now           func() time.Time
+	locks         userLocks
+}
+
+func NewLimitsService(repo repository.LimitRepository, stats StatsReader, increaseDelay time.Duration) *LimitsService {
+	return &LimitsService{
+		repo:          repo,
+		stats:         stats,
+		increaseDelay: increaseDelay,
+		now:           time.Now,
+		locks:         userLocks{locks: make(map[int64]*userLock)},
+	}
+}
+
+func (s *LimitsService) GetLimits(ctx context.Context, userID int64) (domain.LimitsStatus, error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.GetLimits")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("limits.user_id", userID))
+
+	if ctx.Err() != nil {
+		return domain.LimitsStatus{}, ctx.Err()
+	}
+
+	limits, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get limits")
+		return domain.LimitsStatus{}, domain.NewRepositoryError("GetLimits", "failed to get limits", err)
+	}
+
+	return s.status(ctx, limits)
+}
+
+func (s *LimitsService) SetLimit(ctx context.Context, userID int64, limitType domain.LimitType, period domain.LimitPeriod, amount *float64) (domain.LimitsStatus, error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.SetLimit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("limits.user_id", userID),
+		attribute.String("limits.type", string(limitType)),
+		attribute.String("limits.period", string(period)),
+		attribute.Bool("limits.remove", amount == nil),
+	)
+
+	return s.update(ctx, "SetLimit", userID, func(limits *domain.UserLimits) error {
+		limits.Set(limitType, period, amount, s.now(), s.increaseDelay)
+		return nil
+	})
+}
+
+func (s *LimitsService) StartCoolOff(ctx context.Context, userID int64, days int) (domain.LimitsStatus, error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.StartCoolOff")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("limits.user_id", userID),
+		attribute.Int("limits.cool_off_days", days),
+	)
+
+	return s.update(ctx, "StartCoolOff", userID, func(limits *domain.UserLimits) error {
+		now := s.now()
+		until := now.AddDate(0, 0, days)
+		limits.Apply(now)
+		limits.CoolOff = limits.CoolOff.Extend(now, &until)
+		limits.UpdatedAt = now
+		return nil
+	})
+}
+
+func (s *LimitsService) SelfExclude(ctx context.Context, userID int64, duration domain.ExclusionDuration) (domain.LimitsStatus, error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.SelfExclude")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("limits.user_id", userID),
+		attribute.String("limits.exclusion", string(duration)),
+	)
+
+	return s.update(ctx, "SelfExclude", userID, func(limits *domain.UserLimits) error {
+		now := s.now()
+		limits.Apply(now)
+		limits.SelfExclusion = limits.SelfExclusion.Extend(now, duration.Until(now))
+		limits.UpdatedAt = now
+		return nil
+	})
+}
+
+func (s *LimitsService) RecordDeposit(ctx context.Context, userID int64, amount float64) (domain.LimitsStatus, error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.RecordDeposit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("limits.user_id", userID),
+		attribute.Float64("deposit.amount", amount),
+	)
+
+	status, err := s.update(ctx, "RecordDeposit", userID, func(limits *domain.UserLimits) error {
+		now := s.now()
+		limits.Apply(now)
+		if err := restricted(limits, now); err != nil {
+			return err
+		}
+
+		for _, limit := range limits.Limits {
+			if limit.Type != domain.LimitDeposit || limit.Amount == nil {
+				continue
+			}
+			used := limits.Deposited(limit.Period.Start(now))
+			if math.Round((used+amount)*100) > math.Round(*limit.Amount*100) {
+				span.SetAttributes(attribute.String("limits.exceeded", string(limit.Period)+"_"+string(limit.Type)))
+				return domain.NewLimitExceededError(limit.Type, limit.Period, *limit.Amount, max(0, *limit.Amount-used), limit.Period.End(now))
+			}
+		}
+
+		limits.Deposits = append(limits.Deposits, domain.Deposit{Amount: amount, At: now})
+		limits.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+	}
+	return status, err
+}
+
+func (s *LimitsService) CheckBet(ctx context.Context, userID int64, amount float64) (func(), error) {
+	ctx, span := tracer.Start(ctx, "LimitsService.CheckBet")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("limits.user_id", userID),
+		attribute.Float64("bet.amount", amount),
+	)
+
+	release := s.locks.lock(userID)
+	if err := s.checkBet(ctx, userID, amount); err != nil {
+		span.RecordError(err)
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (s *LimitsService) checkBet(ctx context.Context, userID int64, amount float64) error {
+	span := trace.SpanFromContext(ctx)
+
+	limits, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get limits")
+		return domain.NewRepositoryError("CheckBet", "failed to get limits", err)
+	}
+
+	now := s.now()
+	limits.Apply(now)
+	if err := restricted(&limits, now); err != nil {
+		return err
+	}
+
+	status, err := s.status(ctx, limits)
+	if err != nil {
+		return err
+	}
+	for _, usage := range status.Limits {
+		if !usage.Tracked || usage.Amount == nil || usage.Type == domain.LimitDeposit {
+			continue
+		}
+		if math.Round((usage.Used+amount)*100) > math.Round(*usage.Amount*100) {
+			span.SetAttributes(attribute.String("limits.exceeded", string(usage.Period)+"_"+string(usage.Type)))
+			return domain.NewLimitExceededError(usage.Type, usage.Period, *usage.Amount, usage.Remaining, usage.ResetsAt)
+		}
+	}
+
+	return nil
+}
+
+func (s *LimitsService) update(ctx context.Context, op string, userID int64, update func(*domain.UserLimits) error) (domain.LimitsStatus, error) {
+	if ctx.Err() != nil {
+		return domain.LimitsStatus{}, ctx.Err()
+	}
+
+	release := s.locks.lock(userID)
+	defer release()
+
+	limits, err := s.repo.Update(ctx, userID, update)
+	if domain.IsLimitError(err) {
+		return domain.LimitsStatus{}, err
+	}
+	if err != nil {
+		return domain.LimitsStatus{}, domain.NewRepositoryError(op, "failed to update limits", err)
+	}
+
+	return s.status(ctx, limits)
+}
+
+func (s *LimitsService) status(ctx context.Context, limits domain.UserLimits) (domain.LimitsStatus, error) {
+	now := s.now()
+	limits.Apply(now)
+
+	status := domain.LimitsStatus{
+		UserID:        limits.UserID,
+		Limits:        make([]domain.LimitUsage, len(limits.Limits)),
+		CoolOff:       limits.CoolOff,
+		SelfExclusion: limits.SelfExclusion,
+	}
+
+	periodStats := make(map[domain.LimitPeriod]domain.UserStats)
+	for i, limit := range limits.Limits {
+		usage := domain.LimitUsage{Limit: limit, ResetsAt: limit.Period.End(now)}
+
+		stats, exists := periodStats[limit.Period]
+		if !exists {
+			from := limit.Period.Start(now)
+			var err error
+			stats, err = s.stats.UserStats(ctx, domain.UserStatsRequest{UserID: limits.UserID, From: &from})
+			if err != nil {
+				return domain.LimitsStatus{}, domain.NewRepositoryError("LimitUsage", "failed to get user stats", err)
+			}
+			periodStats[limit.Period] = stats
+		}
+
+		usage.Used, usage.Tracked = stats.LimitUsed(limit.Type)
+		if limit.Type == domain.LimitDeposit {
+			usage.Used, usage.Tracked = limits.Deposited(limit.Period.Start(now)), true
+		}
+		if usage.Tracked && limit.Amount != nil {
+			usage.Remaining = max(0, *limit.Amount-usage.Used)
+		}
+		status.Limits[i] = usage
+	}
+
+	return status, nil
+}
+
+func restricted(limits *domain.UserLimits, now time.Time) error {
+	if limits.SelfExclusion.ActiveAt(now) {
+		return domain.NewSelfExcludedError(limits.SelfExclusion.Until)
+	}
+	if limits.CoolOff.ActiveAt(now) {
+		return domain.NewCoolOffError(limits.CoolOff.Until)
+	}
+	return nil
+}
+
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[int64]*userLock
+}
+
+type userLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func (l *userLocks) lock(userID int64) func() {
+	l.mu.Lock()
+	ul, exists := l.locks[userID]
+	if !exists {
+		ul = &userLock{}
+		l.locks[userID] = ul
+	}
+	ul.refs++
+	l.mu.Unlock()
+
+	ul.mu.Lock()
+	return func() {
+		ul.mu.Unlock()
+
+		l.mu.Lock()
+		ul.refs--
+		if ul.refs == 0 {
+			delete(l.locks, userID)
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"bet/internal/repository"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeStats struct {
+	mu      sync.Mutex
+	wagered map[int64]float64
+}
+
+func (s *fakeStats) UserStats(ctx context.Context, req domain.UserStatsRequest) (domain.UserStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return domain.UserStats{PendingWagered: s.wagered[req.UserID]}, nil
+}
+
+func (s *fakeStats) place(userID int64, amount float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wagered[userID] += amount
+}
+
+func newTestLimitsService(t *testing.T) (*LimitsService, *fakeStats) {
+	t.Helper()
+
+	stats := &fakeStats{wagered: make(map[int64]float64)}
+	s := NewLimitsService(repository.NewInMemoryLimitRepository(), stats, 24*time.Hour)
+	now := time.Date(2026, 3, 11, 15, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, stats
+}
+
+func TestLimitsServiceCheckBetHoldsUserUntilReleased(t *testing.T) {
+	s, stats := newTestLimitsService(t)
+	ctx := context.Background()
+
+	limit := 100.0
+	if _, err := s.SetLimit(ctx, 7, domain.LimitWager, domain.LimitDay, &limit); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := s.CheckBet(ctx, 7, 20)
+			if err != nil {
+				if !domain.IsLimitError(err) {
+					t.Errorf("CheckBet: %v", err)
+				}
+				return
+			}
+			defer release()
+
+			stats.place(7, 20)
+			mu.Lock()
+			accepted++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if accepted != 5 {
+		t.Fatalf("accepted %d bets of 20, want 5 within the 100 limit", accepted)
+	}
+}
+
+func TestLimitsServiceCheckBetReleasesOnRejection(t *testing.T) {
+	s, _ := newTestLimitsService(t)
+	ctx := context.Background()
+
+	limit := 10.0
+	if _, err := s.SetLimit(ctx, 7, domain.LimitWager, domain.LimitDay, &limit); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+
+	if _, err := s.CheckBet(ctx, 7, 20); !domain.IsLimitError(err) {
+		t.Fatalf("CheckBet error = %v, want a limit error", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		release, err := s.CheckBet(ctx, 7, 5)
+		if err == nil {
+			release()
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("CheckBet: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("user lock was not released after a rejected bet")
+	}
+}
+
+func TestLimitsServiceDepositLimits(t *testing.T) {
+	s, _ := newTestLimitsService(t)
+	ctx := context.Background()
+	now := s.now()
+
+	limit := 100.0
+	if _, err := s.SetLimit(ctx, 7, domain.LimitDeposit, domain.LimitDay, &limit); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+	if _, err := s.RecordDeposit(ctx, 7, 60); err != nil {
+		t.Fatalf("RecordDeposit: %v", err)
+	}
+
+	_, err := s.RecordDeposit(ctx, 7, 50)
+	var limitErr *domain.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Reason != "DEPOSIT_LIMIT_EXCEEDED" || limitErr.Remaining != 40 {
+		t.Fatalf("RecordDeposit over the limit: error = %v, want DEPOSIT_LIMIT_EXCEEDED with 40 remaining", err)
+	}
+	if _, err := s.RecordDeposit(ctx, 7, 40); err != nil {
+		t.Fatalf("RecordDeposit up to the limit: %v", err)
+	}
+
+	release, err := s.CheckBet(ctx, 7, 500)
+	if err != nil {
+		t.Fatalf("CheckBet: %v, want deposit limits not to restrict bets", err)
+	}
+	release()
+
+	higher := 200.0
+	status, err := s.SetLimit(ctx, 7, domain.LimitDeposit, domain.LimitDay, &higher)
+	if err != nil {
+		t.Fatalf("SetLimit increase: %v", err)
+	}
+	usage := status.Limits[0]
+	if *usage.Amount != 100 || usage.Pending == nil || !usage.Tracked || usage.Used != 100 || usage.Remaining != 0 {
+		t.Fatalf("usage after increase = %+v, want 100 of 100 used with the increase pending", usage)
+	}
+	if _, err := s.RecordDeposit(ctx, 7, 1); !domain.IsLimitError(err) {
+		t.Fatalf("RecordDeposit before the increase applies: error = %v, want a limit error", err)
+	}
+
+	s.now = func() time.Time { return now.Add(25 * time.Hour) }
+	if _, err := s.RecordDeposit(ctx, 7, 150); err != nil {
+		t.Fatalf("RecordDeposit after the increase and a new day: %v", err)
+	}
+
+	lower := 10.0
+	if _, err := s.SetLimit(ctx, 7, domain.LimitDeposit, domain.LimitDay, &lower); err != nil {
+		t.Fatalf("SetLimit decrease: %v", err)
+	}
+	if _, err := s.RecordDeposit(ctx, 7, 1); !domain.IsLimitError(err) {
+		t.Fatalf("RecordDeposit after a decrease: error = %v, want the lower limit to apply immediately", err)
+	}
+}
+
+func TestLimitsServiceDepositBlockedBySelfExclusion(t *testing.T) {
+	s, _ := newTestLimitsService(t)
+	ctx := context.Background()
+
+	if _, err := s.SelfExclude(ctx, 7, domain.Exclusion6Months); err != nil {
+		t.Fatalf("SelfExclude: %v", err)
+	}
+	_, err := s.RecordDeposit(ctx, 7, 10)
+	var limitErr *domain.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Reason != "SELF_EXCLUDED" {
+		t.Fatalf("RecordDeposit error = %v, want SELF_EXCLUDED", err)
+	}
+
+	status, err := s.GetLimits(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetLimits: %v", err)
+	}
+	if status.SelfExclusion == nil {
+		t.Fatal("self-exclusion missing from status")
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"bet/internal/repository"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type ReportServiceUseCase interface {
+	Summary(ctx context.Context, req domain.ReportRequest) (domain.Report, error)
+}
+
+type ReportService struct {
+	repo           repository.BetRepository
+	theoreticalRTP float64
+}
+
+func NewReportService(repo repository.BetRepository, houseEdge float64) *ReportService {
+	return &ReportService{
+		repo:           repo,
+		theoreticalRTP: 1 - houseEdge,
+	}
+}
+
+func (s *ReportService) Summary(ctx context.Context, req domain.ReportRequest) (domain.Report, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.Summary")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("report.granularity", string(req.Granularity)))
+
+	if ctx.Err() != nil {
+		return domain.Report{}, ctx.Err()
+	}
+
+	report, err := s.repo.Summary(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to build report")
+		return domain.Report{}, domain.NewRepositoryError("Summary", "failed to build report", err)
+	}
+	report.TheoreticalRTP = s.theoreticalRTP
+
+	span.SetAttributes(attribute.Int("report.buckets", len(report.Buckets)))
+
+	return report, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RiskServiceUseCase interface {
+	GetExposure(ctx context.Context) (domain.ExposureReport, error)
+}
+
+type ExposureReader interface {
+	Exposure(roundIDs []string) domain.ExposureReport
+}
+
+type RiskService struct {
+	exposure ExposureReader
+	rounds   RoundReader
+}
+
+func NewRiskService(exposure ExposureReader, rounds RoundReader) *RiskService {
+	return &RiskService{
+		exposure: exposure,
+		rounds:   rounds,
+	}
+}
+
+func (s *RiskService) GetExposure(ctx context.Context) (domain.ExposureReport, error) {
+	_, span := tracer.Start(ctx, "RiskService.GetExposure")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return domain.ExposureReport{}, ctx.Err()
+	}
+
+	var live []domain.RoundSummary
+	for _, round := range s.rounds.Rounds(2) {
+		if round.Round.Status != domain.RoundStatusCrashed {
+			live = append(live, round)
+		}
+	}
+
+	roundIDs := make([]string, len(live))
+	for i, round := range live {
+		roundIDs[i] = round.Round.ID
+	}
+
+	report := s.exposure.Exposure(roundIDs)
+	for i := range report.Rounds {
+		report.Rounds[i].Status = live[i].Round.Status
+	}
+
+	span.SetAttributes(
+		attribute.Float64("risk.exposure", report.Exposure),
+		attribute.Int("risk.rounds", len(report.Rounds)),
+	)
+	return report, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RoundServiceUseCase interface {
+	ListRounds(ctx context.Context, limit int) ([]domain.RoundSummary, error)
+	GetRound(ctx context.Context, id string) (domain.RoundSummary, error)
+}
+
+type RoundReader interface {
+	Rounds(limit int) []domain.RoundSummary
+	Round(id string) (domain.RoundSummary, error)
+}
+
+type RoundService struct {
+	rounds RoundReader
+}
+
+func NewRoundService(rounds RoundReader) *RoundService {
+	return &RoundService{rounds: rounds}
+}
+
+func (s *RoundService) ListRounds(ctx context.Context, limit int) ([]domain.RoundSummary, error) {
+	_, span := tracer.Start(ctx, "RoundService.ListRounds")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rounds := s.rounds.Rounds(limit)
+	span.SetAttributes(attribute.Int("rounds.result_count", len(rounds)))
+	return rounds, nil
+}
+
+func (s *RoundService) GetRound(ctx context.Context, id string) (domain.RoundSummary, error) {
+	_, span := tracer.Start(ctx, "RoundService.GetRound")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("round.id", id))
+
+	if ctx.Err() != nil {
+		return domain.RoundSummary{}, ctx.Err()
+	}
+
+	round, err := s.rounds.Round(id)
+	if err != nil {
+		span.RecordError(err)
+		return domain.RoundSummary{}, err
+	}
+	return round, nil
+}
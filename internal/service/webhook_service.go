@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bet/internal/domain"
+	"bet/internal/repository"
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type WebhookServiceUseCase interface {
+	CreateWebhook(ctx context.Context, url string, events []string, secret string, actor domain.Actor) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id string, actor domain.Actor) (*domain.Webhook, error)
+	ListDeliveries(ctx context.Context, webhookID string, limit int, actor domain.Actor) ([]domain.WebhookDelivery, error)
+}
+
+type WebhookTargetChecker interface {
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+type WebhookService struct {
+	repo    repository.WebhookRepository
+	targets WebhookTargetChecker
+}
+
+func NewWebhookService(repo repository.WebhookRepository, targets WebhookTargetChecker) *WebhookService {
+	return &WebhookService{repo: repo, targets: targets}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, url string, events []string, secret string, actor domain.Actor) (*domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := s.targets.CheckURL(ctx, url); err != nil {
+		span.RecordError(err)
+		return nil, &domain.ValidationError{Field: "url", Message: err.Error()}
+	}
+
+	webhook := domain.NewWebhook(url, events, secret, actor)
+	span.SetAttributes(attribute.String("webhook.id", webhook.ID))
+
+	if err := s.repo.Create(ctx, webhook); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to create webhook")
+		return nil, domain.NewRepositoryError("CreateWebhook", "failed to create webhook", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id string, actor domain.Actor) (*domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetWebhook")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id))
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	webhook, err := s.repo.GetByID(ctx, id)
+	if err == nil && !webhook.VisibleTo(actor) {
+		err = domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, domain.NewRepositoryError("GetWebhook", fmt.Sprintf("failed to get webhook by id %s", id), err)
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, webhookID string, limit int, actor domain.Actor) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.ListDeliveries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", webhookID),
+		attribute.Int("deliveries.limit", limit),
+	)
+
+	if _, err := s.GetWebhook(ctx, webhookID, actor); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to list webhook deliveries")
+		return nil, domain.NewRepositoryError("ListDeliveries", "failed to list webhook deliveries", err)
+	}
+
+	span.SetAttributes(attribute.Int("deliveries.result_count", len(deliveries)))
+
+	return deliveries, nil
+}
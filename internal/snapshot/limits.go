@@ -0,0 +1,174 @@
+package snapshot
+
+import (
+	"bet/internal/domain"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+)
+
+type limitsRow struct {
+	UserID        int64           `json:"user_id"`
+	Limits        []limitRow      `json:"limits,omitempty"`
+	CoolOff       *restrictionRow `json:"cool_off,omitempty"`
+	SelfExclusion *restrictionRow `json:"self_exclusion,omitempty"`
+	Deposits      []depositRow    `json:"deposits,omitempty"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+type limitRow struct {
+	Type    domain.LimitType   `json:"type"`
+	Period  domain.LimitPeriod `json:"period"`
+	Amount  *float64           `json:"amount,omitempty"`
+	Pending *pendingLimitRow   `json:"pending,omitempty"`
+}
+
+type pendingLimitRow struct {
+	Amount      *float64  `json:"amount,omitempty"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+type depositRow struct {
+	Amount float64   `json:"amount"`
+	At     time.Time `json:"at"`
+}
+
+type restrictionRow struct {
+	Since time.Time  `json:"since"`
+	Until *time.Time `json:"until,omitempty"`
+}
+
+func newLimitsRow(limits domain.UserLimits) limitsRow {
+	row := limitsRow{
+		UserID:        limits.UserID,
+		CoolOff:       newRestrictionRow(limits.CoolOff),
+		SelfExclusion: newRestrictionRow(limits.SelfExclusion),
+		UpdatedAt:     limits.UpdatedAt,
+	}
+	for _, limit := range limits.Limits {
+		entry := limitRow{Type: limit.Type, Period: limit.Period, Amount: limit.Amount}
+		if limit.Pending != nil {
+			entry.Pending = &pendingLimitRow{Amount: limit.Pending.Amount, EffectiveAt: limit.Pending.EffectiveAt}
+		}
+		row.Limits = append(row.Limits, entry)
+	}
+	for _, deposit := range limits.Deposits {
+		row.Deposits = append(row.Deposits, depositRow{Amount: deposit.Amount, At: deposit.At})
+	}
+	return row
+}
+
+func newRestrictionRow(restriction *domain.Restriction) *restrictionRow {
+	if restriction == nil {
+		return nil
+	}
+	return &restrictionRow{Since: restriction.Since, Until: restriction.Until}
+}
+
+func (r limitsRow) UserLimits() domain.UserLimits {
+	limits := domain.UserLimits{
+		UserID:        r.UserID,
+		CoolOff:       r.CoolOff.restriction(),
+		SelfExclusion: r.SelfExclusion.restriction(),
+		UpdatedAt:     r.UpdatedAt,
+	}
+	for _, entry := range r.Limits {
+		limit := domain.Limit{Type: entry.Type, Period: entry.Period, Amount: entry.Amount}
+		if entry.Pending != nil {
+			limit.Pending = &domain.PendingLimit{Amount: entry.Pending.Amount, EffectiveAt: entry.Pending.EffectiveAt}
+		}
+		limits.Limits = append(limits.Limits, limit)
+	}
+	for _, deposit := range r.Deposits {
+		limits.Deposits = append(limits.Deposits, domain.Deposit{Amount: deposit.Amount, At: deposit.At})
+	}
+	return limits
+}
+
+func (r *restrictionRow) restriction() *domain.Restriction {
+	if r == nil {
+		return nil
+	}
+	return &domain.Restriction{Since: r.Since, Until: r.Until}
+}
+
+type countingWriter struct {
+	w     io.Writer
+	hash  hash.Hash
+	bytes int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.hash.Write(p[:n])
+	c.bytes += int64(n)
+	return n, err
+}
+
+func (m *Manager) writeLimits(ctx context.Context, file *os.File, snapshot *domain.Snapshot) error {
+	limits, err := m.limits.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	counter := &countingWriter{w: file, hash: sha256.New()}
+	gz := gzip.NewWriter(counter)
+	encoder := json.NewEncoder(gz)
+	for _, userLimits := range limits {
+		if err := encoder.Encode(newLimitsRow(userLimits)); err != nil {
+			return err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	snapshot.Limits = len(limits)
+	snapshot.LimitsBytes = counter.bytes
+	snapshot.LimitsChecksum = hex.EncodeToString(counter.hash.Sum(nil))
+	return nil
+}
+
+func (m *Manager) loadLimits(ctx context.Context, snapshot domain.Snapshot) error {
+	file, err := os.Open(m.limitsPath(snapshot.ID))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+	var limits []domain.UserLimits
+	for {
+		var row limitsRow
+		if err := decoder.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		limits = append(limits, row.UserLimits())
+	}
+	if len(limits) != snapshot.Limits {
+		return fmt.Errorf("read %d user limits, manifest says %d", len(limits), snapshot.Limits)
+	}
+
+	return m.limits.Import(ctx, limits)
+}
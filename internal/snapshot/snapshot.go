@@ -0,0 +1,526 @@
+package snapshot
+
+import (
+	"bet/internal/domain"
+	"bet/internal/export"
+	"bet/internal/metrics"
+	"bet/internal/repository"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	idLayout         = "20060102T150405.000000000Z"
+	dataExt          = ".ndjson.gz"
+	limitsExt        = ".limits.ndjson.gz"
+	manifestExt      = ".json"
+	tmpExt           = ".tmp"
+	defaultRetain    = 5
+	restoreBatchSize = 1000
+)
+
+type Config struct {
+	Dir      string
+	Retain   int
+	Interval time.Duration
+	Logger   *zap.Logger
+}
+
+type manifest struct {
+	ID             string    `json:"id"`
+	Bets           int       `json:"bets"`
+	Bytes          int64     `json:"bytes"`
+	Checksum       string    `json:"checksum"`
+	Limits         int       `json:"limits"`
+	LimitsBytes    int64     `json:"limits_bytes,omitempty"`
+	LimitsChecksum string    `json:"limits_checksum,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type Manager struct {
+	config Config
+	repo   repository.BetRepository
+	limits repository.LimitRepository
+	logger *zap.Logger
+
+	mu sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewManager(config Config, repo repository.BetRepository, limits repository.LimitRepository) (*Manager, error) {
+	if config.Retain <= 0 {
+		config.Retain = defaultRetain
+	}
+	if config.Logger == nil {
+		config.Logger = zap.NewNop()
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot dir: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Manager{
+		config: config,
+		repo:   repo,
+		limits: limits,
+		logger: config.Logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+func (m *Manager) Start() {
+	if m.config.Interval <= 0 {
+		return
+	}
+
+	m.wg.Add(1)
+	go m.loop()
+}
+
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.logger.Info("shutting down snapshot manager...")
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		m.logger.Warn("snapshot manager shutdown timeout")
+		return ctx.Err()
+	}
+
+	snapshot, err := m.Create(ctx)
+	if err != nil {
+		return fmt.Errorf("final snapshot: %w", err)
+	}
+	if _, err := m.Compact(); err != nil {
+		return fmt.Errorf("compact snapshots: %w", err)
+	}
+
+	m.logger.Info("snapshot manager shutdown complete", zap.String("snapshot_id", snapshot.ID), zap.Int("bets", snapshot.Bets))
+	return nil
+}
+
+func (m *Manager) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		snapshot, err := m.Create(m.ctx)
+		if err != nil {
+			if m.ctx.Err() == nil {
+				m.logger.Error("periodic snapshot failed", zap.Error(err))
+			}
+			continue
+		}
+		result, err := m.Compact()
+		if err != nil {
+			m.logger.Error("snapshot compaction failed", zap.Error(err))
+			continue
+		}
+
+		m.logger.Info("periodic snapshot created",
+			zap.String("snapshot_id", snapshot.ID),
+			zap.Int("bets", snapshot.Bets),
+			zap.Int("removed", len(result.Removed)),
+		)
+	}
+}
+
+func (m *Manager) Create(ctx context.Context) (domain.Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	createdAt := time.Now().UTC()
+	id := createdAt.Format(idLayout)
+
+	tmp, err := os.CreateTemp(m.config.Dir, id+".*"+tmpExt)
+	if err != nil {
+		metrics.SnapshotFailures.Add(1)
+		return domain.Snapshot{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	snapshot, err := m.write(ctx, tmp, id, createdAt)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), m.dataPath(id))
+	}
+	if err == nil {
+		err = m.createLimits(ctx, &snapshot)
+	}
+	if err == nil {
+		err = m.writeManifest(snapshot)
+	}
+	if err != nil {
+		os.Remove(m.dataPath(id))
+		os.Remove(m.limitsPath(id))
+		metrics.SnapshotFailures.Add(1)
+		return domain.Snapshot{}, err
+	}
+
+	metrics.SnapshotsCreated.Add(1)
+	return snapshot, nil
+}
+
+func (m *Manager) createLimits(ctx context.Context, snapshot *domain.Snapshot) error {
+	tmp, err := os.CreateTemp(m.config.Dir, snapshot.ID+limitsExt+".*"+tmpExt)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = m.writeLimits(ctx, tmp, snapshot)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.limitsPath(snapshot.ID))
+}
+
+func (m *Manager) write(ctx context.Context, file *os.File, id string, createdAt time.Time) (domain.Snapshot, error) {
+	writer, err := export.NewWriter(file, export.Config{Format: export.FormatNDJSON, Compression: export.CompressionGzip})
+	if err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	bets := m.repo.Stream(ctx, domain.ExportBetsRequest{
+		Sort: domain.SortParams{SortBy: "created_at", Order: "asc"},
+	})
+	for bet, err := range bets {
+		if err != nil {
+			return domain.Snapshot{}, err
+		}
+		if err := writer.Write(bet); err != nil {
+			return domain.Snapshot{}, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return domain.Snapshot{}, err
+	}
+	if err := file.Sync(); err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	return domain.Snapshot{
+		ID:        id,
+		Bets:      writer.Rows(),
+		Bytes:     writer.Bytes(),
+		Checksum:  writer.Checksum(),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (m *Manager) List() ([]domain.Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.listLocked()
+}
+
+func (m *Manager) Compact() (domain.CompactResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshots, err := m.listLocked()
+	if err != nil {
+		return domain.CompactResult{}, err
+	}
+
+	var result domain.CompactResult
+	for i, snapshot := range snapshots {
+		if i < m.config.Retain {
+			result.Kept++
+			continue
+		}
+		if err := os.Remove(m.manifestPath(snapshot.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+		if err := os.Remove(m.dataPath(snapshot.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+		if err := os.Remove(m.limitsPath(snapshot.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+		result.Removed = append(result.Removed, snapshot)
+	}
+
+	if err := m.removeStrayFiles(); err != nil {
+		return result, err
+	}
+
+	metrics.SnapshotsRemoved.Add(int64(len(result.Removed)))
+	return result, nil
+}
+
+func (m *Manager) Restore(ctx context.Context) (domain.Snapshot, bool, error) {
+	snapshots, err := m.List()
+	if err != nil {
+		return domain.Snapshot{}, false, err
+	}
+
+	for _, snapshot := range snapshots {
+		if err := m.verify(snapshot); err != nil {
+			m.logger.Warn("skipping unreadable snapshot", zap.String("snapshot_id", snapshot.ID), zap.Error(err))
+			continue
+		}
+		if err := m.load(ctx, snapshot); err != nil {
+			return snapshot, false, fmt.Errorf("restore snapshot %s: %w", snapshot.ID, err)
+		}
+		if snapshot.LimitsChecksum != "" {
+			if err := m.loadLimits(ctx, snapshot); err != nil {
+				return snapshot, false, fmt.Errorf("restore limits from snapshot %s: %w", snapshot.ID, err)
+			}
+		}
+		return snapshot, true, nil
+	}
+
+	return domain.Snapshot{}, false, nil
+}
+
+func (m *Manager) verify(snapshot domain.Snapshot) error {
+	if err := verifyFile(m.dataPath(snapshot.ID), snapshot.Bytes, snapshot.Checksum); err != nil {
+		return err
+	}
+	if snapshot.LimitsChecksum == "" {
+		return nil
+	}
+	if err := verifyFile(m.limitsPath(snapshot.ID), snapshot.LimitsBytes, snapshot.LimitsChecksum); err != nil {
+		return fmt.Errorf("limits: %w", err)
+	}
+	return nil
+}
+
+func verifyFile(path string, bytes int64, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+	if size != bytes {
+		return fmt.Errorf("size is %d bytes, manifest says %d", size, bytes)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return fmt.Errorf("checksum is %s, manifest says %s", sum, checksum)
+	}
+	return nil
+}
+
+func (m *Manager) load(ctx context.Context, snapshot domain.Snapshot) error {
+	file, err := os.Open(m.dataPath(snapshot.ID))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+	batch := make([]domain.Bet, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := m.repo.Import(ctx, batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows := 0; ; rows++ {
+		var row export.Row
+		if err := decoder.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				if rows != snapshot.Bets {
+					return fmt.Errorf("read %d bets, manifest says %d", rows, snapshot.Bets)
+				}
+				break
+			}
+			return err
+		}
+
+		bet := row.Bet()
+		if bet.Status == domain.BetStatusPending {
+			bet.Void(domain.VoidReasonTechnicalError, snapshot.CreatedAt)
+		}
+		batch = append(batch, bet)
+
+		if len(batch) >= restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+func (m *Manager) listLocked() ([]domain.Snapshot, error) {
+	entries, err := os.ReadDir(m.config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []domain.Snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, manifestExt) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(m.config.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var mf manifest
+		if err := json.Unmarshal(data, &mf); err != nil || mf.ID != strings.TrimSuffix(name, manifestExt) {
+			m.logger.Warn("ignoring corrupt snapshot manifest", zap.String("file", name), zap.Error(err))
+			continue
+		}
+
+		snapshots = append(snapshots, domain.Snapshot{
+			ID:             mf.ID,
+			Bets:           mf.Bets,
+			Bytes:          mf.Bytes,
+			Checksum:       mf.Checksum,
+			Limits:         mf.Limits,
+			LimitsBytes:    mf.LimitsBytes,
+			LimitsChecksum: mf.LimitsChecksum,
+			CreatedAt:      mf.CreatedAt,
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+func (m *Manager) writeManifest(snapshot domain.Snapshot) error {
+	data, err := json.MarshalIndent(manifest{
+		ID:             snapshot.ID,
+		Bets:           snapshot.Bets,
+		Bytes:          snapshot.Bytes,
+		Checksum:       snapshot.Checksum,
+		Limits:         snapshot.Limits,
+		LimitsBytes:    snapshot.LimitsBytes,
+		LimitsChecksum: snapshot.LimitsChecksum,
+		CreatedAt:      snapshot.CreatedAt,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(m.config.Dir, snapshot.ID+manifestExt+".*"+tmpExt)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), m.manifestPath(snapshot.ID))
+}
+
+func (m *Manager) removeStrayFiles() error {
+	entries, err := os.ReadDir(m.config.Dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+
+		stray := strings.HasSuffix(name, tmpExt)
+		id, ok := strings.CutSuffix(name, limitsExt)
+		if !ok {
+			id, ok = strings.CutSuffix(name, dataExt)
+		}
+		if ok {
+			_, err := os.Stat(m.manifestPath(id))
+			stray = errors.Is(err, os.ErrNotExist)
+		}
+		if !stray {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(m.config.Dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		m.logger.Info("removed stray snapshot file", zap.String("file", name))
+	}
+	return nil
+}
+
+func (m *Manager) dataPath(id string) string {
+	return filepath.Join(m.config.Dir, id+dataExt)
+}
+
+func (m *Manager) limitsPath(id string) string {
+	return filepath.Join(m.config.Dir, id+limitsExt)
+}
+
+func (m *Manager) manifestPath(id string) string {
+	return filepath.Join(m.config.Dir, id+manifestExt)
+}
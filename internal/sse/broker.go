@@ -0,0 +1,169 @@
+package sse
+
+import (
+	"bet/internal/domain"
+	"bet/internal/game"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type Config struct {
+	ReplaySize       int
+	SubscriberBuffer int
+	Logger           *zap.Logger
+}
+
+type Event struct {
+	ID  string
+	Seq uint64
+	Bet domain.Bet
+}
+
+type Subscription struct {
+	C    <-chan Event
+	ch   chan Event
+	done chan struct{}
+	once sync.Once
+}
+
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+type Broker struct {
+	config Config
+	logger *zap.Logger
+	epoch  string
+
+	mu          sync.Mutex
+	seq         uint64
+	replay      []Event
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+func NewBroker(config Config) *Broker {
+	if config.ReplaySize <= 0 {
+		config.ReplaySize = 1000
+	}
+	if config.SubscriberBuffer <= 0 {
+		config.SubscriberBuffer = 64
+	}
+
+	return &Broker{
+		config:      config,
+		logger:      config.Logger,
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		replay:      make([]Event, 0, config.ReplaySize),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *Broker) Publish(event game.Event) {
+	if event.Type != game.EventBetPlaced || event.Bet == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.seq++
+	ev := Event{
+		ID:  fmt.Sprintf("%s-%d", b.epoch, b.seq),
+		Seq: b.seq,
+		Bet: *event.Bet,
+	}
+
+	if len(b.replay) == b.config.ReplaySize {
+		copy(b.replay, b.replay[1:])
+		b.replay = b.replay[:len(b.replay)-1]
+	}
+	b.replay = append(b.replay, ev)
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- ev:
+		default:
+			b.logger.Warn("closing lagging bet stream subscriber", zap.String("last_event_id", ev.ID))
+			delete(b.subscribers, sub)
+			sub.close()
+		}
+	}
+}
+
+func (b *Broker) Subscribe(lastEventID string) (*Subscription, []Event) {
+	ch := make(chan Event, b.config.SubscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, done: make(chan struct{})}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.close()
+		return sub, nil
+	}
+
+	b.subscribers[sub] = struct{}{}
+
+	return sub, b.replayAfter(lastEventID)
+}
+
+func (b *Broker) replayAfter(lastEventID string) []Event {
+	if lastEventID == "" {
+		return nil
+	}
+
+	var after uint64
+	epoch, seqStr, ok := strings.Cut(lastEventID, "-")
+	if ok && epoch == b.epoch {
+		seq, err := strconv.ParseUint(seqStr, 10, 64)
+		if err == nil {
+			after = seq
+		}
+	}
+
+	events := make([]Event, 0, len(b.replay))
+	for _, ev := range b.replay {
+		if ev.Seq > after {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	delete(b.subscribers, sub)
+	b.mu.Unlock()
+
+	sub.close()
+}
+
+func (b *Broker) Shutdown(ctx context.Context) error {
+	b.logger.Info("shutting down bet stream broker...")
+
+	b.mu.Lock()
+	b.closed = true
+	for sub := range b.subscribers {
+		sub.close()
+	}
+	b.subscribers = make(map[*Subscription]struct{})
+	b.mu.Unlock()
+
+	b.logger.Info("bet stream broker shutdown complete")
+	return nil
+}
@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"bet/internal/domain"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func ListBetsAttributes(req domain.ListBetsRequest) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Int("bets.page", req.Pagination.Page),
+		attribute.Int("bets.limit", req.Pagination.Limit),
+	}
+
+	return append(attrs, ExportBetsAttributes(domain.ExportBetsRequest{Filters: req.Filters, Sort: req.Sort})...)
+}
+
+func ExportBetsAttributes(req domain.ExportBetsRequest) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("bets.sort_by", req.Sort.SortBy),
+		attribute.String("bets.order", req.Sort.Order),
+	}
+
+	if req.Filters.UserID != nil {
+		attrs = append(attrs, attribute.Int64("bets.filter.user_id", *req.Filters.UserID))
+	}
+	if req.Filters.MinAmount != nil {
+		attrs = append(attrs, attribute.Float64("bets.filter.min_amount", *req.Filters.MinAmount))
+	}
+	if req.Filters.MaxAmount != nil {
+		attrs = append(attrs, attribute.Float64("bets.filter.max_amount", *req.Filters.MaxAmount))
+	}
+
+	return attrs
+}
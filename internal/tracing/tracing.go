@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	FilePath     string
+	ServiceName  string
+	SampleRatio  float64
+}
+
+type Provider struct {
+	tp   *sdktrace.TracerProvider
+	file io.Closer
+}
+
+func NewProvider(ctx context.Context, config Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if config.Exporter == "" || config.Exporter == ExporterNone {
+		return &Provider{}, nil
+	}
+
+	if config.ServiceName == "" {
+		config.ServiceName = "bet-api"
+	}
+
+	p := &Provider{}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch config.Exporter {
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		file, openErr := os.OpenFile(config.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to open trace file: %w", openErr)
+		}
+		p.file = file
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.OTLPEndpoint)}
+		if config.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", config.Exporter)
+	}
+	if err != nil {
+		if p.file != nil {
+			p.file.Close()
+		}
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(attribute.String("service.name", config.ServiceName))
+
+	p.tp = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(p.tp)
+
+	return p, nil
+}
+
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+
+	err := p.tp.Shutdown(ctx)
+
+	if p.file != nil {
+		if closeErr := p.file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
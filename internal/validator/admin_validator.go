@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"bet/internal/domain"
+	"bet/internal/openapi"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type AdminValidator interface {
+	ValidateRoundID(id string) error
+	ValidateRoundsLimit(limit int) error
+	ValidateCreateAPIKey(label string, scopes []string, userID int64, operator bool, operatorName string) error
+	ValidateAPIKeyID(id string) error
+	ValidateReport(from, to, granularity, currency, operator string) error
+}
+
+type adminValidator struct {
+	doc         *openapi.Document
+	roundID     *openapi.Parameter
+	roundsLimit *openapi.Parameter
+	keyLabel    *openapi.Schema
+	keyScopes   *openapi.Schema
+	keyUserID   *openapi.Schema
+	keyOperator *openapi.Schema
+	keyID       *openapi.Parameter
+	reportFrom  *openapi.Parameter
+	reportTo    *openapi.Parameter
+	granularity *openapi.Parameter
+	currency    *openapi.Parameter
+	operator    *openapi.Parameter
+}
+
+func NewAdminValidator(doc *openapi.Document) AdminValidator {
+	return &adminValidator{
+		doc:         doc,
+		roundID:     mustParameter(doc, http.MethodGet, "/v1/admin/rounds/{id}", "path", "id"),
+		roundsLimit: mustParameter(doc, http.MethodGet, "/v1/admin/rounds", "query", "limit"),
+		keyLabel:    mustProperty(doc, "CreateAPIKeyRequest", "label"),
+		keyScopes:   mustProperty(doc, "CreateAPIKeyRequest", "scopes"),
+		keyUserID:   mustProperty(doc, "CreateAPIKeyRequest", "user_id"),
+		keyOperator: mustProperty(doc, "CreateAPIKeyRequest", "operator_name"),
+		keyID:       mustParameter(doc, http.MethodDelete, "/v1/admin/api-keys/{id}", "path", "id"),
+		reportFrom:  mustParameter(doc, http.MethodGet, "/v1/reports/summary", "query", "from"),
+		reportTo:    mustParameter(doc, http.MethodGet, "/v1/reports/summary", "query", "to"),
+		granularity: mustParameter(doc, http.MethodGet, "/v1/reports/summary", "query", "granularity"),
+		currency:    mustParameter(doc, http.MethodGet, "/v1/reports/summary", "query", "currency"),
+		operator:    mustParameter(doc, http.MethodGet, "/v1/reports/summary", "query", "operator"),
+	}
+}
+
+func (v *adminValidator) ValidateRoundID(id string) error {
+	return FromFieldErrors(v.doc.Validate(v.roundID.Name, v.roundID.Schema, id))
+}
+
+func (v *adminValidator) ValidateRoundsLimit(limit int) error {
+	return FromFieldErrors(v.doc.Validate(v.roundsLimit.Name, v.roundsLimit.Schema, limit))
+}
+
+func (v *adminValidator) ValidateCreateAPIKey(label string, scopes []string, userID int64, operator bool, operatorName string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate("label", v.keyLabel, label)...)
+	errs = append(errs, v.doc.Validate("scopes", v.keyScopes, scopes)...)
+	if userID != 0 {
+		errs = append(errs, v.doc.Validate("user_id", v.keyUserID, userID)...)
+		if operator {
+			errs = append(errs, openapi.FieldError{Field: "operator", Message: "a key bound to a user cannot also be an operator key"})
+		}
+	}
+	if operatorName != "" {
+		errs = append(errs, v.doc.Validate("operator_name", v.keyOperator, operatorName)...)
+		if !operator {
+			errs = append(errs, openapi.FieldError{Field: "operator_name", Message: "operator_name requires operator to be true"})
+		}
+	}
+	return FromFieldErrors(errs)
+}
+
+func (v *adminValidator) ValidateAPIKeyID(id string) error {
+	return FromFieldErrors(v.doc.Validate(v.keyID.Name, v.keyID.Schema, id))
+}
+
+func (v *adminValidator) ValidateReport(from, to, granularity, currency, operator string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate(v.reportFrom.Name, v.reportFrom.Schema, from)...)
+	errs = append(errs, v.doc.Validate(v.reportTo.Name, v.reportTo.Schema, to)...)
+	errs = append(errs, v.doc.Validate(v.granularity.Name, v.granularity.Schema, granularity)...)
+	if currency != "" {
+		errs = append(errs, v.doc.Validate(v.currency.Name, v.currency.Schema, currency)...)
+	}
+	if operator != "" {
+		errs = append(errs, v.doc.Validate(v.operator.Name, v.operator.Schema, operator)...)
+	}
+	if len(errs) > 0 {
+		return FromFieldErrors(errs)
+	}
+
+	start, _ := time.Parse(time.RFC3339, from)
+	end, _ := time.Parse(time.RFC3339, to)
+	switch {
+	case !end.After(start):
+		errs = append(errs, openapi.FieldError{Field: "to", Message: "to must be after from"})
+	case domain.ReportGranularity(granularity).Buckets(start, end) > domain.MaxReportBuckets:
+		errs = append(errs, openapi.FieldError{
+			Field:   "to",
+			Message: fmt.Sprintf("range must not span more than %d %s buckets", domain.MaxReportBuckets, granularity),
+		})
+	}
+	return FromFieldErrors(errs)
+}
@@ -2,9 +2,9 @@ package validator
 
 import (
 	"bet/internal/domain"
-	"fmt"
+	"bet/internal/openapi"
 	"math"
-	"regexp"
+	"net/http"
 )
 
 type BetValidator interface {
@@ -16,168 +16,191 @@ type BetValidator interface {
 	ValidateSort(sortBy, order string) error
 	ValidateBetID(id string) error
 	ValidateNumber(value float64) error
+	ValidateBatchSize(size int) error
+	ValidatePayload(schema string, payload interface{}) error
+	ValidateVoidRequest(reason, note string) error
+	ValidateExport(format, compression string) error
+	ValidateImportedBet(bet domain.Bet) error
+	ValidateImportBatchSize(size int) error
+	ValidateStatsRange(from, to string) error
 }
 
-const (
-	MinAmount      = 1.0
-	MaxAmount      = 100000.0
-	MinCrashPoint  = 1.0
-	MaxCrashPoint  = 100.0
-	MinUserID      = 1
-	MaxUserID      = 999999999
-	MaxBetIDLength = 36
-)
-
-var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
-
-type betValidator struct{}
-
-func NewBetValidator() BetValidator {
-	return &betValidator{}
+type betValidator struct {
+	doc        *openapi.Document
+	userID     *openapi.Schema
+	amount     *openapi.Schema
+	crashPoint *openapi.Schema
+	currency   *openapi.Schema
+	operator   *openapi.Schema
+	betID      *openapi.Parameter
+	page       *openapi.Parameter
+	limit      *openapi.Parameter
+	sortBy     *openapi.Parameter
+	order      *openapi.Parameter
+	batch      *openapi.Schema
+	voidReason *openapi.Schema
+	voidNote   *openapi.Schema
+	format     *openapi.Parameter
+	compress   *openapi.Parameter
+	roundID    *openapi.Schema
+	status     *openapi.Schema
+	payout     *openapi.Schema
+	imports    *openapi.Schema
+	statsFrom  *openapi.Parameter
+	statsTo    *openapi.Parameter
 }
 
-func (v *betValidator) ValidateCreateRequest(userID int64, amount, crashPoint float64) error {
-	if err := v.ValidateUserID(userID); err != nil {
-		return err
-	}
-
-	if err := v.ValidateAmount(amount); err != nil {
-		return err
-	}
-
-	if err := v.ValidateCrashPoint(crashPoint); err != nil {
-		return err
+func NewBetValidator(doc *openapi.Document) BetValidator {
+	return &betValidator{
+		doc:        doc,
+		userID:     mustProperty(doc, "CreateBetRequest", "user_id"),
+		amount:     mustProperty(doc, "CreateBetRequest", "amount"),
+		crashPoint: mustProperty(doc, "CreateBetRequest", "crash_point"),
+		currency:   mustProperty(doc, "BetDTO", "currency"),
+		operator:   mustProperty(doc, "BetDTO", "operator"),
+		betID:      mustParameter(doc, http.MethodGet, "/v1/bets/{id}", "path", "id"),
+		page:       mustParameter(doc, http.MethodGet, "/v1/bets", "query", "page"),
+		limit:      mustParameter(doc, http.MethodGet, "/v1/bets", "query", "limit"),
+		sortBy:     mustParameter(doc, http.MethodGet, "/v1/bets", "query", "sort_by"),
+		order:      mustParameter(doc, http.MethodGet, "/v1/bets", "query", "order"),
+		batch:      mustProperty(doc, "CreateBetsRequest", "bets"),
+		voidReason: mustProperty(doc, "VoidBetRequest", "reason"),
+		voidNote:   mustProperty(doc, "VoidBetRequest", "note"),
+		format:     mustParameter(doc, http.MethodGet, "/v1/bets/export", "query", "format"),
+		compress:   mustParameter(doc, http.MethodGet, "/v1/bets/export", "query", "compression"),
+		roundID:    mustProperty(doc, "BetDTO", "round_id"),
+		status:     mustProperty(doc, "BetDTO", "status"),
+		payout:     mustProperty(doc, "BetDTO", "payout"),
+		imports:    mustProperty(doc, "ImportBetsRequest", "bets"),
+		statsFrom:  mustParameter(doc, http.MethodGet, "/v1/users/{id}/stats", "query", "from"),
+		statsTo:    mustParameter(doc, http.MethodGet, "/v1/users/{id}/stats", "query", "to"),
 	}
+}
 
-	return nil
+func (v *betValidator) ValidateCreateRequest(userID int64, amount, crashPoint float64) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate("user_id", v.userID, userID)...)
+	errs = append(errs, v.doc.Validate("amount", v.amount, amount)...)
+	errs = append(errs, v.doc.Validate("crash_point", v.crashPoint, crashPoint)...)
+	return FromFieldErrors(errs)
 }
 
 func (v *betValidator) ValidateUserID(userID int64) error {
-	if userID < MinUserID || userID > MaxUserID {
-		return &domain.ValidationError{
-			Field:   "user_id",
-			Message: fmt.Sprintf("user_id must be between %d and %d", MinUserID, MaxUserID),
-		}
-	}
-	return nil
+	return FromFieldErrors(v.doc.Validate("user_id", v.userID, userID))
 }
 
 func (v *betValidator) ValidateAmount(amount float64) error {
-	if err := v.ValidateNumber(amount); err != nil {
-		return &domain.ValidationError{
-			Field:   "amount",
-			Message: "amount must be a valid numeric value",
-		}
-	}
+	return FromFieldErrors(v.doc.Validate("amount", v.amount, amount))
+}
 
-	if amount < MinAmount {
-		return &domain.ValidationError{
-			Field:   "amount",
-			Message: fmt.Sprintf("amount must be at least %.2f", MinAmount),
-		}
-	}
+func (v *betValidator) ValidateCrashPoint(crashPoint float64) error {
+	return FromFieldErrors(v.doc.Validate("crash_point", v.crashPoint, crashPoint))
+}
 
-	if amount > MaxAmount {
-		return &domain.ValidationError{
-			Field:   "amount",
-			Message: fmt.Sprintf("amount must not exceed %.2f", MaxAmount),
-		}
+func (v *betValidator) ValidatePagination(page, limit int) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate(v.page.Name, v.page.Schema, page)...)
+	errs = append(errs, v.doc.Validate(v.limit.Name, v.limit.Schema, limit)...)
+	return FromFieldErrors(errs)
+}
+
+func (v *betValidator) ValidateSort(sortBy, order string) error {
+	var errs []openapi.FieldError
+	if sortBy != "" {
+		errs = append(errs, v.doc.Validate(v.sortBy.Name, v.sortBy.Schema, sortBy)...)
+	}
+	if order != "" {
+		errs = append(errs, v.doc.Validate(v.order.Name, v.order.Schema, order)...)
 	}
+	return FromFieldErrors(errs)
+}
 
-	return nil
+func (v *betValidator) ValidateBetID(id string) error {
+	return FromFieldErrors(v.doc.Validate(v.betID.Name, v.betID.Schema, id))
 }
 
-func (v *betValidator) ValidateCrashPoint(crashPoint float64) error {
-	if err := v.ValidateNumber(crashPoint); err != nil {
-		return &domain.ValidationError{
-			Field:   "crash_point",
-			Message: "crash_point must be a valid numeric value",
-		}
-	}
+func (v *betValidator) ValidateBatchSize(size int) error {
+	bounds := *v.batch
+	bounds.Items = nil
+	return FromFieldErrors(v.doc.Validate("bets", &bounds, make([]interface{}, size)))
+}
 
-	if crashPoint < MinCrashPoint {
-		return &domain.ValidationError{
-			Field:   "crash_point",
-			Message: fmt.Sprintf("crash_point must be at least %.2f", MinCrashPoint),
-		}
-	}
+func (v *betValidator) ValidateImportBatchSize(size int) error {
+	bounds := *v.imports
+	bounds.Items = nil
+	return FromFieldErrors(v.doc.Validate("bets", &bounds, make([]interface{}, size)))
+}
 
-	if crashPoint > MaxCrashPoint {
-		return &domain.ValidationError{
-			Field:   "crash_point",
-			Message: fmt.Sprintf("crash_point must not exceed %.2f", MaxCrashPoint),
-		}
+func (v *betValidator) ValidateVoidRequest(reason, note string) error {
+	errs := v.doc.Validate("reason", v.voidReason, reason)
+	if note != "" {
+		errs = append(errs, v.doc.Validate("note", v.voidNote, note)...)
 	}
+	return FromFieldErrors(errs)
+}
 
-	return nil
+func (v *betValidator) ValidateExport(format, compression string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate(v.format.Name, v.format.Schema, format)...)
+	errs = append(errs, v.doc.Validate(v.compress.Name, v.compress.Schema, compression)...)
+	return FromFieldErrors(errs)
 }
 
-func (v *betValidator) ValidatePagination(page, limit int) error {
-	if page < 1 {
-		return &domain.ValidationError{
-			Field:   "page",
-			Message: "page must be at least 1",
-		}
+func (v *betValidator) ValidateImportedBet(bet domain.Bet) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate("id", v.betID.Schema, bet.ID)...)
+	errs = append(errs, v.doc.Validate("user_id", v.userID, bet.UserID)...)
+	errs = append(errs, v.doc.Validate("amount", v.amount, bet.Amount)...)
+	errs = append(errs, v.doc.Validate("crash_point", v.crashPoint, bet.CrashPoint)...)
+	if bet.Currency != "" {
+		errs = append(errs, v.doc.Validate("currency", v.currency, bet.Currency)...)
 	}
-
-	if limit < 1 {
-		return &domain.ValidationError{
-			Field:   "limit",
-			Message: "limit must be at least 1",
-		}
+	if bet.Operator != "" {
+		errs = append(errs, v.doc.Validate("operator", v.operator, bet.Operator)...)
 	}
-
-	if limit > 100 {
-		return &domain.ValidationError{
-			Field:   "limit",
-			Message: "limit must not exceed 100",
-		}
+	if bet.RoundID != "" {
+		errs = append(errs, v.doc.Validate("round_id", v.roundID, bet.RoundID)...)
+	}
+	errs = append(errs, v.doc.Validate("status", v.status, string(bet.Status))...)
+	errs = append(errs, v.doc.Validate("payout", v.payout, bet.Payout)...)
+	if bet.VoidReason != "" {
+		errs = append(errs, v.doc.Validate("void_reason", v.voidReason, string(bet.VoidReason))...)
 	}
 
-	return nil
-}
-
-func (v *betValidator) ValidateSort(sortBy, order string) error {
-	if sortBy != "" && sortBy != "amount" && sortBy != "created_at" {
-		return &domain.ValidationError{
-			Field:   "sort_by",
-			Message: "sort_by must be either 'amount' or 'created_at'",
-		}
+	switch {
+	case bet.CreatedAt.IsZero():
+		errs = append(errs, openapi.FieldError{Field: "created_at", Message: "created_at is required"})
+	case bet.Status == domain.BetStatusPending:
+		errs = append(errs, openapi.FieldError{Field: "status", Message: "status must be a final status; pending bets cannot be imported"})
+	case bet.SettledAt == nil:
+		errs = append(errs, openapi.FieldError{Field: "settled_at", Message: "settled_at is required for " + string(bet.Status) + " bets"})
+	case bet.SettledAt.Before(bet.CreatedAt):
+		errs = append(errs, openapi.FieldError{Field: "settled_at", Message: "settled_at must not be before created_at"})
 	}
 
-	if order != "" && order != "asc" && order != "desc" {
-		return &domain.ValidationError{
-			Field:   "order",
-			Message: "order must be either 'asc' or 'desc'",
-		}
+	if bet.Status == domain.BetStatusVoided && bet.VoidReason == "" {
+		errs = append(errs, openapi.FieldError{Field: "void_reason", Message: "void_reason is required for voided bets"})
 	}
 
-	return nil
+	return FromFieldErrors(errs)
 }
 
-func (v *betValidator) ValidateBetID(id string) error {
-	if id == "" {
-		return &domain.ValidationError{
-			Field:   "id",
-			Message: "bet id is required",
-		}
+func (v *betValidator) ValidateStatsRange(from, to string) error {
+	var errs []openapi.FieldError
+	if from != "" {
+		errs = append(errs, v.doc.Validate(v.statsFrom.Name, v.statsFrom.Schema, from)...)
 	}
-
-	if len(id) > MaxBetIDLength {
-		return &domain.ValidationError{
-			Field:   "id",
-			Message: fmt.Sprintf("bet id must not exceed %d characters", MaxBetIDLength),
-		}
+	if to != "" {
+		errs = append(errs, v.doc.Validate(v.statsTo.Name, v.statsTo.Schema, to)...)
 	}
-
-	if !uuidRegex.MatchString(id) {
-		return &domain.ValidationError{
-			Field:   "id",
-			Message: "bet id must be a valid UUID format",
-		}
+	if len(errs) == 0 && from != "" && to != "" && to < from {
+		errs = append(errs, openapi.FieldError{Field: "to", Message: "to must not be before from"})
 	}
+	return FromFieldErrors(errs)
+}
 
-	return nil
+func (v *betValidator) ValidatePayload(schema string, payload interface{}) error {
+	return FromFieldErrors(v.doc.Validate("", openapi.Ref(schema), payload))
 }
 
 func (v *betValidator) ValidateNumber(value float64) error {
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"bet/internal/openapi"
+	"net/http"
+)
+
+type FraudValidator interface {
+	ValidateFlagFilter(status string, userID *int64, limit int) error
+	ValidateFlagID(id string) error
+	ValidateReview(status, note string) error
+}
+
+type fraudValidator struct {
+	doc          *openapi.Document
+	filterStatus *openapi.Parameter
+	filterUserID *openapi.Parameter
+	limit        *openapi.Parameter
+	flagID       *openapi.Parameter
+	reviewStatus *openapi.Schema
+	reviewNote   *openapi.Schema
+}
+
+func NewFraudValidator(doc *openapi.Document) FraudValidator {
+	return &fraudValidator{
+		doc:          doc,
+		filterStatus: mustParameter(doc, http.MethodGet, "/v1/admin/fraud/flags", "query", "status"),
+		filterUserID: mustParameter(doc, http.MethodGet, "/v1/admin/fraud/flags", "query", "user_id"),
+		limit:        mustParameter(doc, http.MethodGet, "/v1/admin/fraud/flags", "query", "limit"),
+		flagID:       mustParameter(doc, http.MethodPost, "/v1/admin/fraud/flags/{id}/review", "path", "id"),
+		reviewStatus: mustProperty(doc, "ReviewFraudFlagRequest", "status"),
+		reviewNote:   mustProperty(doc, "ReviewFraudFlagRequest", "note"),
+	}
+}
+
+func (v *fraudValidator) ValidateFlagFilter(status string, userID *int64, limit int) error {
+	var errs []openapi.FieldError
+	if status != "" {
+		errs = append(errs, v.doc.Validate(v.filterStatus.Name, v.filterStatus.Schema, status)...)
+	}
+	if userID != nil {
+		errs = append(errs, v.doc.Validate(v.filterUserID.Name, v.filterUserID.Schema, *userID)...)
+	}
+	errs = append(errs, v.doc.Validate(v.limit.Name, v.limit.Schema, limit)...)
+	return FromFieldErrors(errs)
+}
+
+func (v *fraudValidator) ValidateFlagID(id string) error {
+	return FromFieldErrors(v.doc.Validate(v.flagID.Name, v.flagID.Schema, id))
+}
+
+func (v *fraudValidator) ValidateReview(status, note string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate("status", v.reviewStatus, status)...)
+	errs = append(errs, v.doc.Validate("note", v.reviewNote, note)...)
+	return FromFieldErrors(errs)
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"bet/internal/openapi"
+	"net/http"
+)
+
+type LeaderboardValidator interface {
+	ValidateLeaderboard(boardType, window string, limit int, aroundUser *int64) error
+}
+
+type leaderboardValidator struct {
+	doc        *openapi.Document
+	boardType  *openapi.Parameter
+	window     *openapi.Parameter
+	limit      *openapi.Parameter
+	aroundUser *openapi.Parameter
+}
+
+func NewLeaderboardValidator(doc *openapi.Document) LeaderboardValidator {
+	return &leaderboardValidator{
+		doc:        doc,
+		boardType:  mustParameter(doc, http.MethodGet, "/v1/leaderboards/{type}", "path", "type"),
+		window:     mustParameter(doc, http.MethodGet, "/v1/leaderboards/{type}", "query", "window"),
+		limit:      mustParameter(doc, http.MethodGet, "/v1/leaderboards/{type}", "query", "limit"),
+		aroundUser: mustParameter(doc, http.MethodGet, "/v1/leaderboards/{type}", "query", "around_user"),
+	}
+}
+
+func (v *leaderboardValidator) ValidateLeaderboard(boardType, window string, limit int, aroundUser *int64) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate(v.boardType.Name, v.boardType.Schema, boardType)...)
+	errs = append(errs, v.doc.Validate(v.window.Name, v.window.Schema, window)...)
+	errs = append(errs, v.doc.Validate(v.limit.Name, v.limit.Schema, limit)...)
+	if aroundUser != nil {
+		errs = append(errs, v.doc.Validate(v.aroundUser.Name, v.aroundUser.Schema, *aroundUser)...)
+	}
+	return FromFieldErrors(errs)
+}
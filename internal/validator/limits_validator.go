@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"bet/internal/openapi"
+	"net/http"
+)
+
+type LimitsValidator interface {
+	ValidateUserID(userID int64) error
+	ValidateLimit(limitType, period string) error
+	ValidateLimitAmount(amount float64) error
+	ValidateDepositAmount(amount float64) error
+	ValidateCoolOff(days int) error
+	ValidateSelfExclusion(duration string) error
+}
+
+type limitsValidator struct {
+	doc       *openapi.Document
+	userID    *openapi.Parameter
+	limitType *openapi.Parameter
+	period    *openapi.Parameter
+	amount    *openapi.Schema
+	deposit   *openapi.Schema
+	days      *openapi.Schema
+	duration  *openapi.Schema
+}
+
+func NewLimitsValidator(doc *openapi.Document) LimitsValidator {
+	return &limitsValidator{
+		doc:       doc,
+		userID:    mustParameter(doc, http.MethodGet, "/v1/users/{id}/limits", "path", "id"),
+		limitType: mustParameter(doc, http.MethodPut, "/v1/users/{id}/limits/{type}/{period}", "path", "type"),
+		period:    mustParameter(doc, http.MethodPut, "/v1/users/{id}/limits/{type}/{period}", "path", "period"),
+		amount:    mustProperty(doc, "SetLimitRequest", "amount"),
+		deposit:   mustProperty(doc, "DepositRequest", "amount"),
+		days:      mustProperty(doc, "CoolOffRequest", "days"),
+		duration:  mustProperty(doc, "SelfExclusionRequest", "duration"),
+	}
+}
+
+func (v *limitsValidator) ValidateUserID(userID int64) error {
+	return FromFieldErrors(v.doc.Validate("user_id", v.userID.Schema, userID))
+}
+
+func (v *limitsValidator) ValidateLimit(limitType, period string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate(v.limitType.Name, v.limitType.Schema, limitType)...)
+	errs = append(errs, v.doc.Validate(v.period.Name, v.period.Schema, period)...)
+	return FromFieldErrors(errs)
+}
+
+func (v *limitsValidator) ValidateLimitAmount(amount float64) error {
+	return FromFieldErrors(v.doc.Validate("amount", v.amount, amount))
+}
+
+func (v *limitsValidator) ValidateDepositAmount(amount float64) error {
+	return FromFieldErrors(v.doc.Validate("amount", v.deposit, amount))
+}
+
+func (v *limitsValidator) ValidateCoolOff(days int) error {
+	return FromFieldErrors(v.doc.Validate("days", v.days, days))
+}
+
+func (v *limitsValidator) ValidateSelfExclusion(duration string) error {
+	return FromFieldErrors(v.doc.Validate("duration", v.duration, duration))
+}
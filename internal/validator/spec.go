@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"bet/internal/domain"
+	"bet/internal/openapi"
+	"fmt"
+)
+
+func FromFieldErrors(errs []openapi.FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	violations := make(domain.ValidationErrors, len(errs))
+	for i, err := range errs {
+		violations[i] = &domain.ValidationError{Field: err.Field, Message: err.Message}
+	}
+
+	if len(violations) == 1 {
+		return violations[0]
+	}
+	return violations
+}
+
+func mustProperty(doc *openapi.Document, schema, name string) *openapi.Schema {
+	s := doc.Property(schema, name)
+	if s == nil {
+		panic(fmt.Sprintf("validator: openapi schema %s has no property %q", schema, name))
+	}
+	return s
+}
+
+func mustParameter(doc *openapi.Document, method, path, in, name string) *openapi.Parameter {
+	op := doc.Operation(method, path)
+	if op == nil {
+		panic(fmt.Sprintf("validator: openapi document has no operation %s %s", method, path))
+	}
+
+	p := op.Parameter(in, name)
+	if p == nil {
+		panic(fmt.Sprintf("validator: openapi operation %s %s has no %s parameter %q", method, path, in, name))
+	}
+	return p
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"bet/internal/openapi"
+	"net/http"
+)
+
+type WebhookValidator interface {
+	ValidateCreateRequest(rawURL string, events []string, secret string) error
+	ValidateWebhookID(id string) error
+	ValidateDeliveriesLimit(limit int) error
+}
+
+type webhookValidator struct {
+	doc    *openapi.Document
+	url    *openapi.Schema
+	events *openapi.Schema
+	secret *openapi.Schema
+	id     *openapi.Parameter
+	limit  *openapi.Parameter
+}
+
+func NewWebhookValidator(doc *openapi.Document) WebhookValidator {
+	return &webhookValidator{
+		doc:    doc,
+		url:    mustProperty(doc, "CreateWebhookRequest", "url"),
+		events: mustProperty(doc, "CreateWebhookRequest", "events"),
+		secret: mustProperty(doc, "CreateWebhookRequest", "secret"),
+		id:     mustParameter(doc, http.MethodGet, "/v1/webhooks/{id}/deliveries", "path", "id"),
+		limit:  mustParameter(doc, http.MethodGet, "/v1/webhooks/{id}/deliveries", "query", "limit"),
+	}
+}
+
+func (v *webhookValidator) ValidateCreateRequest(rawURL string, events []string, secret string) error {
+	var errs []openapi.FieldError
+	errs = append(errs, v.doc.Validate("url", v.url, rawURL)...)
+	errs = append(errs, v.doc.Validate("events", v.events, events)...)
+	errs = append(errs, v.doc.Validate("secret", v.secret, secret)...)
+	return FromFieldErrors(errs)
+}
+
+func (v *webhookValidator) ValidateWebhookID(id string) error {
+	return FromFieldErrors(v.doc.Validate(v.id.Name, v.id.Schema, id))
+}
+
+func (v *webhookValidator) ValidateDeliveriesLimit(limit int) error {
+	return FromFieldErrors(v.doc.Validate(v.limit.Name, v.limit.Schema, limit))
+}
@@ -0,0 +1,398 @@
+package webhook
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/metrics"
+	"bet/internal/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	maxResponseBody = 64 << 10
+)
+
+var ErrQueueFull = errors.New("webhook delivery queue full")
+
+var SupportedEvents = []string{
+	string(events.TypeBetPlaced),
+	string(events.TypeBetSettled),
+	string(events.TypeBetCancelled),
+	string(events.TypeBetVoided),
+}
+
+type Config struct {
+	Workers      int
+	QueueSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+	DisableAfter int
+	Guard        Guard
+	Client       *http.Client
+	Logger       *zap.Logger
+}
+
+type job struct {
+	webhookID string
+	eventID   string
+	eventType string
+	body      []byte
+	attempt   int
+}
+
+type Dispatcher struct {
+	config Config
+	repo   repository.WebhookRepository
+	client *http.Client
+	logger *zap.Logger
+
+	queue chan job
+
+	pendingMu sync.Mutex
+	pending   int
+
+	failuresMu sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(config Config, repo repository.WebhookRepository) *Dispatcher {
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1024
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 500 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Minute
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.DisableAfter <= 0 {
+		config.DisableAfter = 10
+	}
+
+	client := config.Client
+	if client == nil {
+		client = config.Guard.Client(config.Timeout)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Dispatcher{
+		config: config,
+		repo:   repo,
+		client: client,
+		logger: config.Logger,
+		queue:  make(chan job, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (d *Dispatcher) Start() {
+	for i := 0; i < d.config.Workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+}
+
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.logger.Info("shutting down webhook dispatcher...")
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.logger.Info("webhook dispatcher shutdown complete")
+		return nil
+	case <-ctx.Done():
+		d.logger.Warn("webhook dispatcher shutdown timeout")
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) HandleEvent(ctx context.Context, record events.OutboxRecord) error {
+	eventType := string(record.Event.EventType())
+
+	bet, ok := betFromRecord(record)
+	if !ok {
+		return nil
+	}
+
+	active, err := d.repo.ListActive(ctx, eventType)
+	if err != nil {
+		return err
+	}
+
+	webhooks := active[:0]
+	for _, webhook := range active {
+		if webhook.ReceivesFor(bet.UserID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payloadFromRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	if err := d.reserve(len(webhooks)); err != nil {
+		return err
+	}
+
+	for i, webhook := range webhooks {
+		j := job{
+			webhookID: webhook.ID,
+			eventID:   record.ID,
+			eventType: eventType,
+			body:      body,
+			attempt:   1,
+		}
+		select {
+		case d.queue <- j:
+		case <-d.ctx.Done():
+			for range webhooks[i:] {
+				d.release()
+			}
+			return d.ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) reserve(n int) error {
+	if d.ctx.Err() != nil {
+		return d.ctx.Err()
+	}
+
+	d.pendingMu.Lock()
+	defer d.pendingMu.Unlock()
+
+	if d.pending+n > d.config.QueueSize {
+		return ErrQueueFull
+	}
+	d.pending += n
+	return nil
+}
+
+func (d *Dispatcher) release() {
+	d.pendingMu.Lock()
+	d.pending--
+	d.pendingMu.Unlock()
+}
+
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case j := <-d.queue:
+			d.process(j)
+		}
+	}
+}
+
+func (d *Dispatcher) process(j job) {
+	webhook, err := d.repo.GetByID(d.ctx, j.webhookID)
+	if err != nil || !webhook.Active {
+		d.release()
+		return
+	}
+
+	delivery := d.send(webhook, j)
+	if err := d.repo.AddDelivery(d.ctx, delivery); err != nil && d.ctx.Err() == nil {
+		d.logger.Error("failed to record webhook delivery", zap.String("webhook_id", j.webhookID), zap.Error(err))
+	}
+
+	if delivery.Success {
+		metrics.WebhookDeliveries.Add(1)
+		d.release()
+		d.recordOutcome(j.webhookID, true)
+		return
+	}
+
+	metrics.WebhookFailures.Add(1)
+
+	if j.attempt >= d.config.MaxAttempts {
+		d.logger.Warn("webhook delivery exhausted retries",
+			zap.String("webhook_id", j.webhookID),
+			zap.String("event_id", j.eventID),
+			zap.Int("attempts", j.attempt),
+		)
+		d.release()
+		d.recordOutcome(j.webhookID, false)
+		return
+	}
+
+	d.scheduleRetry(j)
+}
+
+func (d *Dispatcher) send(webhook *domain.Webhook, j job) *domain.WebhookDelivery {
+	start := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID:        uuid.New().String(),
+		WebhookID: webhook.ID,
+		EventID:   j.eventID,
+		EventType: j.eventType,
+		Attempt:   j.attempt,
+		CreatedAt: start,
+	}
+
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+
+	ctx, cancel := context.WithTimeout(d.ctx, d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(j.body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bet-api-webhooks/1.0")
+	req.Header.Set(HeaderID, webhook.ID)
+	req.Header.Set(HeaderEvent, j.eventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, j.body))
+
+	resp, err := d.client.Do(req)
+	delivery.Duration = time.Since(start)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	delivery.StatusCode = resp.StatusCode
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	return delivery
+}
+
+func (d *Dispatcher) scheduleRetry(j job) {
+	delay := d.backoff(j.attempt)
+	j.attempt++
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-d.ctx.Done():
+			d.release()
+			return
+		case <-timer.C:
+		}
+
+		select {
+		case d.queue <- j:
+		case <-d.ctx.Done():
+			d.release()
+		}
+	}()
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.config.BaseBackoff << (attempt - 1)
+	if delay <= 0 || delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
+
+func (d *Dispatcher) recordOutcome(webhookID string, success bool) {
+	d.failuresMu.Lock()
+	defer d.failuresMu.Unlock()
+
+	webhook, err := d.repo.GetByID(d.ctx, webhookID)
+	if err != nil {
+		return
+	}
+
+	if success {
+		if webhook.ConsecutiveFailures == 0 {
+			return
+		}
+		webhook.ConsecutiveFailures = 0
+	} else {
+		webhook.ConsecutiveFailures++
+		if webhook.ConsecutiveFailures >= d.config.DisableAfter && webhook.Active {
+			now := time.Now()
+			webhook.Active = false
+			webhook.DisabledAt = &now
+			metrics.WebhooksDisabled.Add(1)
+			d.logger.Warn("webhook disabled after repeated failures",
+				zap.String("webhook_id", webhook.ID),
+				zap.Int("consecutive_failures", webhook.ConsecutiveFailures),
+			)
+		}
+	}
+
+	if err := d.repo.Update(d.ctx, webhook); err != nil && d.ctx.Err() == nil {
+		d.logger.Error("failed to update webhook", zap.String("webhook_id", webhookID), zap.Error(err))
+	}
+}
+
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"bet/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testSecret = "0123456789abcdef"
+
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	failures int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	if rc.failures > 0 {
+		rc.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.requests)
+}
+
+func (rc *receiver) events() map[string]int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	seen := make(map[string]int)
+	for _, body := range rc.bodies {
+		var payload eventPayload
+		if err := json.Unmarshal(body, &payload); err == nil {
+			seen[payload.ID]++
+		}
+	}
+	return seen
+}
+
+func newTestDispatcher(t *testing.T, config Config) (*Dispatcher, repository.WebhookRepository) {
+	t.Helper()
+
+	config.Logger = zap.NewNop()
+	if config.BaseBackoff == 0 {
+		config.BaseBackoff = time.Millisecond
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = 5 * time.Millisecond
+	}
+
+	repo := repository.NewInMemoryWebhookRepository()
+	d := NewDispatcher(config, repo)
+	t.Cleanup(func() { d.Shutdown(context.Background()) })
+	return d, repo
+}
+
+func registerWebhook(t *testing.T, repo repository.WebhookRepository, url string) *domain.Webhook {
+	t.Helper()
+
+	return registerOwnedWebhook(t, repo, url, domain.Actor{ID: "key:test", Operator: true})
+}
+
+func registerOwnedWebhook(t *testing.T, repo repository.WebhookRepository, url string, owner domain.Actor) *domain.Webhook {
+	t.Helper()
+
+	webhook := domain.NewWebhook(url, SupportedEvents, testSecret, owner)
+	if err := repo.Create(context.Background(), webhook); err != nil {
+		t.Fatal(err)
+	}
+	return webhook
+}
+
+func placedRecord(id string) events.OutboxRecord {
+	return events.OutboxRecord{
+		ID:    id,
+		Event: events.BetPlaced{Bet: domain.Bet{ID: "bet-" + id, UserID: 1, Amount: 10, CrashPoint: 2}, At: time.Now()},
+	}
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestDispatcherSignsAndRetriesDeliveries(t *testing.T) {
+	rc := &receiver{failures: 2}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	d, repo := newTestDispatcher(t, Config{Workers: 1, MaxAttempts: 5, Guard: Guard{AllowPrivate: true}})
+	webhook := registerWebhook(t, repo, server.URL)
+	d.Start()
+
+	if err := d.HandleEvent(context.Background(), placedRecord("evt-1")); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+	waitFor(t, "three delivery attempts", func() bool { return rc.count() == 3 })
+
+	rc.mu.Lock()
+	for i, req := range rc.requests {
+		timestamp := req.Header.Get(HeaderTimestamp)
+		if got, want := req.Header.Get(HeaderSignature), Sign(testSecret, timestamp, rc.bodies[i]); got != want {
+			t.Errorf("attempt %d: signature %q, want %q", i+1, got, want)
+		}
+		if got := req.Header.Get(HeaderID); got != webhook.ID {
+			t.Errorf("attempt %d: %s %q, want %q", i+1, HeaderID, got, webhook.ID)
+		}
+		if got := req.Header.Get(HeaderEvent); got != string(events.TypeBetPlaced) {
+			t.Errorf("attempt %d: %s %q, want %q", i+1, HeaderEvent, got, events.TypeBetPlaced)
+		}
+	}
+	rc.mu.Unlock()
+
+	var deliveries []domain.WebhookDelivery
+	waitFor(t, "the successful delivery to be recorded", func() bool {
+		deliveries, _ = repo.ListDeliveries(context.Background(), webhook.ID, 0)
+		return len(deliveries) == 3
+	})
+	if !deliveries[0].Success || deliveries[0].Attempt != 3 {
+		t.Errorf("last delivery: success %v on attempt %d, want success on attempt 3", deliveries[0].Success, deliveries[0].Attempt)
+	}
+	for _, delivery := range deliveries[1:] {
+		if delivery.Success || delivery.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("attempt %d: success %v with status %d, want a failed 503", delivery.Attempt, delivery.Success, delivery.StatusCode)
+		}
+	}
+}
+
+func TestDispatcherDeliversOnlyToOwnersActingForTheUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner domain.Actor
+		want  bool
+	}{
+		{name: "bound to the bet's user", owner: domain.Actor{ID: "key:own", UserID: 1}, want: true},
+		{name: "bound to another user", owner: domain.Actor{ID: "key:other", UserID: 2}},
+		{name: "unbound key", owner: domain.Actor{ID: "key:unbound"}},
+		{name: "operator", owner: domain.Actor{ID: "key:operator", Operator: true}, want: true},
+		{name: "admin", owner: domain.Actor{ID: "key:admin", Admin: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &receiver{}
+			server := httptest.NewServer(rc)
+			defer server.Close()
+
+			d, repo := newTestDispatcher(t, Config{Workers: 1, MaxAttempts: 1, Guard: Guard{AllowPrivate: true}})
+			webhook := registerOwnedWebhook(t, repo, server.URL, tt.owner)
+			d.Start()
+
+			if err := d.HandleEvent(context.Background(), placedRecord("evt-1")); err != nil {
+				t.Fatalf("HandleEvent: %v", err)
+			}
+
+			if tt.want {
+				waitFor(t, "the delivery", func() bool { return rc.count() == 1 })
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+			if rc.count() != 0 {
+				t.Fatalf("received %d deliveries of user 1's bet, want none", rc.count())
+			}
+			if deliveries, _ := repo.ListDeliveries(context.Background(), webhook.ID, 0); len(deliveries) != 0 {
+				t.Fatalf("recorded %d deliveries, want none", len(deliveries))
+			}
+		})
+	}
+}
+
+func TestDispatcherRefusesPrivateTargetsAtDialTime(t *testing.T) {
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	d, repo := newTestDispatcher(t, Config{Workers: 1, MaxAttempts: 1})
+	webhook := registerWebhook(t, repo, server.URL)
+	d.Start()
+
+	if err := d.HandleEvent(context.Background(), placedRecord("evt-1")); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+
+	var deliveries []domain.WebhookDelivery
+	waitFor(t, "the failed delivery to be recorded", func() bool {
+		deliveries, _ = repo.ListDeliveries(context.Background(), webhook.ID, 0)
+		return len(deliveries) == 1
+	})
+	if deliveries[0].Success || !strings.Contains(deliveries[0].Error, ErrForbiddenTarget.Error()) {
+		t.Errorf("delivery error %q, want it to mention %q", deliveries[0].Error, ErrForbiddenTarget)
+	}
+	if rc.count() != 0 {
+		t.Errorf("receiver got %d requests, want none", rc.count())
+	}
+}
+
+func TestDispatcherQueueFullEnqueuesNothing(t *testing.T) {
+	first, second := &receiver{}, &receiver{}
+	firstServer := httptest.NewServer(first)
+	defer firstServer.Close()
+	secondServer := httptest.NewServer(second)
+	defer secondServer.Close()
+
+	d, repo := newTestDispatcher(t, Config{Workers: 2, QueueSize: 3, MaxAttempts: 1, Guard: Guard{AllowPrivate: true}})
+	registerWebhook(t, repo, firstServer.URL)
+	registerWebhook(t, repo, secondServer.URL)
+
+	if err := d.HandleEvent(context.Background(), placedRecord("evt-1")); err != nil {
+		t.Fatalf("first event: %v", err)
+	}
+	if err := d.HandleEvent(context.Background(), placedRecord("evt-2")); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("second event: got %v, want %v", err, ErrQueueFull)
+	}
+	if got := len(d.queue); got != 2 {
+		t.Fatalf("queue holds %d jobs after a rejected event, want 2", got)
+	}
+
+	d.Start()
+	waitFor(t, "the first event to drain", func() bool { return first.count() == 1 && second.count() == 1 })
+	waitFor(t, "the queue slots to be released", func() bool {
+		d.pendingMu.Lock()
+		defer d.pendingMu.Unlock()
+		return d.pending == 0
+	})
+
+	if err := d.HandleEvent(context.Background(), placedRecord("evt-2")); err != nil {
+		t.Fatalf("redelivered second event: %v", err)
+	}
+	waitFor(t, "the second event", func() bool { return first.count() == 2 && second.count() == 2 })
+
+	for name, rc := range map[string]*receiver{"first": first, "second": second} {
+		for id, n := range rc.events() {
+			if n != 1 {
+				t.Errorf("%s webhook received %s %d times, want once", name, id, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenTarget = errors.New("webhook target resolves to a loopback, link-local or private address")
+
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+type Guard struct {
+	AllowPrivate bool
+	Resolver     *net.Resolver
+}
+
+func (g Guard) CheckURL(ctx context.Context, rawURL string) error {
+	if g.AllowPrivate {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if forbiddenAddr(addr) {
+			return ErrForbiddenTarget
+		}
+		return nil
+	}
+
+	resolver := g.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if forbiddenAddr(addr) {
+			return ErrForbiddenTarget
+		}
+	}
+	return nil
+}
+
+func (g Guard) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Resolver: g.Resolver}
+	if !g.AllowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if forbiddenAddr(addrPort.Addr()) {
+				return ErrForbiddenTarget
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func forbiddenAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"bet/internal/domain"
+	"bet/internal/events"
+	"time"
+)
+
+type betPayload struct {
+	ID         string  `json:"id"`
+	UserID     int64   `json:"user_id"`
+	Amount     float64 `json:"amount"`
+	CrashPoint float64 `json:"crash_point"`
+	Currency   string  `json:"currency,omitempty"`
+	Operator   string  `json:"operator,omitempty"`
+	RoundID    string  `json:"round_id"`
+	Status     string  `json:"status"`
+	Payout     float64 `json:"payout"`
+	CreatedAt  string  `json:"created_at"`
+	SettledAt  string  `json:"settled_at,omitempty"`
+	VoidReason string  `json:"void_reason,omitempty"`
+}
+
+type eventPayload struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	OccurredAt      string     `json:"occurred_at"`
+	Bet             betPayload `json:"bet"`
+	RoundCrashPoint float64    `json:"round_crash_point,omitempty"`
+}
+
+func betFromRecord(record events.OutboxRecord) (domain.Bet, bool) {
+	switch e := record.Event.(type) {
+	case events.BetPlaced:
+		return e.Bet, true
+	case events.BetSettled:
+		return e.Bet, true
+	case events.BetCancelled:
+		return e.Bet, true
+	case events.BetVoided:
+		return e.Bet, true
+	}
+	return domain.Bet{}, false
+}
+
+func payloadFromRecord(record events.OutboxRecord) eventPayload {
+	payload := eventPayload{
+		ID:         record.ID,
+		Type:       string(record.Event.EventType()),
+		OccurredAt: record.Event.OccurredAt().UTC().Format(time.RFC3339Nano),
+	}
+
+	switch e := record.Event.(type) {
+	case events.BetPlaced:
+		payload.Bet = betPayloadFromDomain(e.Bet)
+	case events.BetSettled:
+		payload.Bet = betPayloadFromDomain(e.Bet)
+		payload.RoundCrashPoint = e.RoundCrashPoint
+	case events.BetCancelled:
+		payload.Bet = betPayloadFromDomain(e.Bet)
+	case events.BetVoided:
+		payload.Bet = betPayloadFromDomain(e.Bet)
+	}
+
+	return payload
+}
+
+func betPayloadFromDomain(bet domain.Bet) betPayload {
+	payload := betPayload{
+		ID:         bet.ID,
+		UserID:     bet.UserID,
+		Amount:     bet.Amount,
+		CrashPoint: bet.CrashPoint,
+		Currency:   bet.Currency,
+		Operator:   bet.Operator,
+		RoundID:    bet.RoundID,
+		Status:     string(bet.Status),
+		Payout:     bet.Payout,
+		CreatedAt:  bet.CreatedAt.UTC().Format(time.RFC3339),
+		VoidReason: string(bet.VoidReason),
+	}
+
+	if bet.SettledAt != nil {
+		payload.SettledAt = bet.SettledAt.UTC().Format(time.RFC3339)
+	}
+
+	return payload
+}